@@ -0,0 +1,419 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/handler"
+	"stock/logger"
+	"stock/model"
+	"stock/proto"
+	"stock/repo"
+	"stock/server"
+	"stock/simulator"
+	"stock/usecase"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	simulateBatchSize    = 500
+	simulatePollInterval = time.Second
+)
+
+// runCommand runs a one-off subcommand instead of serving the GRPC and Kafka servers
+func runCommand(cfg model.Config, name string, args []string) error {
+	switch name {
+	case "migrate":
+		return runMigrate(cfg, args)
+	case "compact":
+		return runCompact(cfg, args)
+	case "export":
+		return runExport(cfg, args)
+	case "replay":
+		return runReplay(cfg, args)
+	case "simulate":
+		return runSimulate(cfg, args)
+	default:
+		return fmt.Errorf("unknown command %s", name)
+	}
+}
+
+// runMigrate rewrites stock summaries that are still stored as JSON with the compact encoding, moving those stored
+// under untagged keys to their hash-tagged keys, moves stock summaries dated at midnight UTC to the start of their
+// trading day, then recomputes the VWAPs of stock summaries stored without one or with another precision. It is safe
+// to run while the Kafka consumer is updating stock summaries: the repo's replaceMemberScript only rewrites a summary
+// the consumer hasn't changed, as the summaries it writes are already migrated, and mergeMemberScript only moves a
+// summary while the day it's moved to is unchanged, merging it again with what the consumer wrote there otherwise.
+func runMigrate(cfg model.Config, _ []string) error {
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		return errors.New("failed to initialize repo")
+	}
+
+	migrated, err := stockRepo.MigrateStockSummaries(context.Background())
+	if err != nil {
+		return err
+	}
+
+	logger.For("migrate").Info("Migrated stock summaries", "migrated", migrated)
+
+	migrated, err = stockRepo.MigrateStockSummaryDates(context.Background(), func(stockCode string) *time.Location {
+		return cfg.Schedule.GetLocation(cfg.Instruments.Get(stockCode))
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.For("migrate").Info("Rescored stock summaries", "migrated", migrated)
+
+	migrated, err = stockRepo.MigrateStockSummaryVWAP(context.Background(), cfg.VWAP.Precision)
+	if err != nil {
+		return err
+	}
+
+	logger.For("migrate").Info("Recomputed stock summary VWAPs", "migrated", migrated, "precision", cfg.VWAP.Precision)
+	return nil
+}
+
+// runCompact applies the retention policy once. With -dry-run, it only reports the daily stock summaries that would
+// be downsampled into monthly summaries.
+func runCompact(cfg model.Config, args []string) error {
+	flags := flag.NewFlagSet("compact", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would be archived without writing anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		return errors.New("failed to initialize repo")
+	}
+
+	stockHandler := handler.New(cfg, usecase.New(cfg, stockRepo))
+
+	_, err := stockHandler.CompactStockSummaries(context.Background(), *dryRun)
+	return err
+}
+
+// runExport writes the stock summaries of the given stock codes (every stock by default) over a date range
+// as CSV or Parquet to a file, or to stdout
+func runExport(cfg model.Config, args []string) (err error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	stockCodes := flags.String("codes", "", "comma separated stock codes to export; every stock when empty")
+	fromDate := flags.String("from", "", "first date to export, in the yyyy-mm-dd format")
+	toDate := flags.String("to", "", "last date to export, in the yyyy-mm-dd format")
+	format := flags.String("format", string(model.ExportFormatCSV), "export format, csv or parquet")
+	output := flags.String("output", "", "file to write the export to; stdout when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	req := &proto.ExportStockSummariesRequest{
+		FromDate: *fromDate,
+		ToDate:   *toDate,
+		Format:   *format,
+	}
+	if *stockCodes != "" {
+		req.StockCodes = strings.Split(*stockCodes, ",")
+	}
+
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		return errors.New("failed to initialize repo")
+	}
+
+	stockHandler := handler.New(cfg, usecase.New(cfg, stockRepo))
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = file
+	}
+
+	buffered := bufio.NewWriter(w)
+	if err := stockHandler.WriteStockSummaries(context.Background(), req, buffered); err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
+
+// runReplay moves the consumer group's committed offsets back to replay transactions, after clearing the stock
+// summaries they are applied to. The Kafka consumers must be stopped while it runs; once restarted, they recompute
+// the cleared summaries. Flags default to the replay config. With -dry-run, it only reports what would be replayed.
+func runReplay(cfg model.Config, args []string) error {
+	replay := cfg.Kafka.Replay
+
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.StringVar(&replay.From, "from", replay.From, "where to replay from: earliest, offsets or timestamp")
+	flags.StringVar(&replay.Topic, "topic", replay.Topic, "topic of the -offsets partitions; may be omitted when a single topic is consumed")
+	offsets := flags.String("offsets", "", "comma separated partition=offset pairs to replay from, e.g. 0=120,1=98")
+	flags.StringVar(&replay.Timestamp, "timestamp", replay.Timestamp, "time to replay from, in the RFC 3339 format")
+	flags.StringVar(&replay.ClearFromDate, "clear-from", replay.ClearFromDate, "first date of the stock summaries to clear, in the yyyy-mm-dd format")
+	dryRun := flags.Bool("dry-run", false, "report the offsets and dates that would be replayed without changing anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *offsets != "" {
+		parsedOffsets, err := parseReplayOffsets(*offsets)
+		if err != nil {
+			return err
+		}
+		replay.Offsets = parsedOffsets
+	}
+
+	replayer, err := server.NewKafkaReplayer(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = replayer.Close()
+	}()
+
+	plan, err := replayer.Plan(cfg.Kafka.GetTopicNames(), replay)
+	if err != nil {
+		return err
+	}
+	plan.DryRun = *dryRun
+
+	log := logger.For("replay")
+	log.Info("Planned replay", "offsets", plan.Offsets, "dry_run", plan.DryRun)
+
+	if plan.DryRun {
+		clearFromDate := "every date"
+		if !plan.ClearFromDate.IsZero() {
+			clearFromDate = plan.ClearFromDate.Format("2006-01-02")
+		}
+		log.Info("Would clear stock summaries", "from_date", clearFromDate)
+		return nil
+	}
+
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		return errors.New("failed to initialize repo")
+	}
+
+	stockHandler := handler.New(cfg, usecase.New(cfg, stockRepo))
+
+	// Summaries are cleared first: if committing fails, replaying again clears the same summaries
+	if _, err := stockHandler.ClearStockSummaries(context.Background(), plan.ClearFromDate); err != nil {
+		return err
+	}
+
+	if err := replayer.Commit(plan); err != nil {
+		return err
+	}
+
+	log.Info("Committed replay offsets, restart the consumers to replay transactions")
+	return nil
+}
+
+// parseReplayOffsets parses comma separated partition=offset pairs
+func parseReplayOffsets(value string) (map[int32]int64, error) {
+	offsets := map[int32]int64{}
+	for _, pair := range strings.Split(value, ",") {
+		partition, offset, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid partition offset %s", pair)
+		}
+
+		parsedPartition, err := strconv.ParseInt(strings.TrimSpace(partition), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partition %s: %w", partition, err)
+		}
+
+		parsedOffset, err := strconv.ParseInt(strings.TrimSpace(offset), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset %s: %w", offset, err)
+		}
+
+		offsets[int32(parsedPartition)] = parsedOffset
+	}
+
+	return offsets, nil
+}
+
+// runSimulate generates a reproducible trading day of random-walk prices, writes its transactions to a Kafka topic or
+// to a file as JSON lines, and with -verify waits until the stored stock summaries match the expected ones.
+// Simulated stocks are coded SIM0001, SIM0002, ... so that they don't mix with real ones.
+func runSimulate(cfg model.Config, args []string) (err error) {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	seed := flags.Int64("seed", 1, "seed of the simulation; the same seed and options produce the same transactions")
+	stocks := flags.Int("stocks", 10, "number of simulated stocks")
+	transactions := flags.Int("transactions", 1000, "number of transactions per stock")
+	date := flags.String("date", "", "simulated trading day, in the yyyy-mm-dd format; today when empty")
+	output := flags.String("output", "", "file to write the transactions to as JSON lines; the Kafka topic when empty")
+	defaultTopic := ""
+	if topics := cfg.Kafka.GetTopicNames(); len(topics) > 0 {
+		defaultTopic = topics[0]
+	}
+	topic := flags.String("topic", defaultTopic, "Kafka topic to write the transactions to")
+	write := flags.Bool("write", true, "write the transactions; disable to only verify a simulation written before")
+	verify := flags.Bool("verify", false, "wait until the stored stock summaries match the expected ones")
+	timeout := flags.Duration("timeout", 2*time.Minute, "how long -verify waits for the stock summaries to match")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	simulatedDate := time.Now().UTC()
+	if *date != "" {
+		simulatedDate, err = time.Parse("2006-01-02", *date)
+		if err != nil {
+			return fmt.Errorf("invalid date %s: %w", *date, err)
+		}
+	}
+
+	simulation, err := simulator.Simulate(simulator.Options{
+		Seed:          *seed,
+		Stocks:        *stocks,
+		Transactions:  *transactions,
+		Date:          simulatedDate,
+		Schedule:      cfg.Schedule,
+		TickSizes:     cfg.Validation.TickSizes,
+		VWAPPrecision: cfg.VWAP.Precision,
+	})
+	if err != nil {
+		return err
+	}
+
+	log := logger.For("simulate")
+	log.Info("Simulated trading day", "seed", *seed, "date", simulatedDate.Format("2006-01-02"),
+		"stocks", *stocks, "transactions", len(simulation.Transactions))
+
+	if *write {
+		switch {
+		case *output != "":
+			err = writeSimulationFile(*output, simulation.Transactions)
+		case *topic == "":
+			err = errors.New("either -output or -topic is required")
+		default:
+			err = publishSimulation(cfg.Kafka, *topic, simulation.Transactions)
+		}
+		if err != nil {
+			return err
+		}
+		destination := *output
+		if destination == "" {
+			destination = "kafka topic " + *topic
+		}
+		log.Info("Wrote transactions", "destination", destination)
+	}
+
+	if !*verify {
+		return nil
+	}
+
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		return errors.New("failed to initialize repo")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	for {
+		mismatches, err := simulator.Verify(ctx, stockRepo, simulation.Expected)
+		if err != nil {
+			return err
+		}
+
+		if len(mismatches) == 0 {
+			log.Info("Stored stock summaries match", "stocks", len(simulation.Expected))
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, mismatch := range mismatches {
+				log.Error("Stock summary mismatch", logger.KeyStockCode, mismatch.Expected.StockCode,
+					"expected", mismatch.Expected, "actual", mismatch.Actual)
+			}
+			return fmt.Errorf("%d of %d stock summaries don't match", len(mismatches), len(simulation.Expected))
+		case <-time.After(simulatePollInterval):
+		}
+	}
+}
+
+// writeSimulationFile writes transactions to path as JSON lines
+func writeSimulationFile(path string, transactions []model.KafkaTransaction) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	buffered := bufio.NewWriter(file)
+	encoder := json.NewEncoder(buffered)
+	for _, transaction := range transactions {
+		if err := encoder.Encode(transaction); err != nil {
+			return err
+		}
+	}
+
+	return buffered.Flush()
+}
+
+// publishSimulation produces transactions to topic in batches, keyed by stock code so that each stock's transactions
+// stay in order on a single partition
+func publishSimulation(cfg model.KafkaConsumer, topic string, transactions []model.KafkaTransaction) (err error) {
+	producer, err := server.NewSyncProducer(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := producer.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	batch := make([]*sarama.ProducerMessage, 0, simulateBatchSize)
+	for i, transaction := range transactions {
+		value, err := json.Marshal(transaction)
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, &sarama.ProducerMessage{
+			Topic: topic,
+			Key:   sarama.StringEncoder(transaction.StockCode),
+			Value: sarama.ByteEncoder(value),
+		})
+
+		if len(batch) == simulateBatchSize || i == len(transactions)-1 {
+			if err := producer.SendMessages(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "stock/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,65 @@ func (m *MockStockUsecase) EXPECT() *MockStockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ClearStockSummaries mocks base method.
+func (m *MockStockUsecase) ClearStockSummaries(ctx context.Context, fromDate time.Time) (model.ClearReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearStockSummaries", ctx, fromDate)
+	ret0, _ := ret[0].(model.ClearReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearStockSummaries indicates an expected call of ClearStockSummaries.
+func (mr *MockStockUsecaseMockRecorder) ClearStockSummaries(ctx, fromDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearStockSummaries", reflect.TypeOf((*MockStockUsecase)(nil).ClearStockSummaries), ctx, fromDate)
+}
+
+// CompactStockSummaries mocks base method.
+func (m *MockStockUsecase) CompactStockSummaries(ctx context.Context, now time.Time, dryRun bool) (model.RetentionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactStockSummaries", ctx, now, dryRun)
+	ret0, _ := ret[0].(model.RetentionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompactStockSummaries indicates an expected call of CompactStockSummaries.
+func (mr *MockStockUsecaseMockRecorder) CompactStockSummaries(ctx, now, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactStockSummaries", reflect.TypeOf((*MockStockUsecase)(nil).CompactStockSummaries), ctx, now, dryRun)
+}
+
+// DeleteStockSummaries mocks base method.
+func (m *MockStockUsecase) DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStockSummaries", ctx, request)
+	ret0, _ := ret[0].([]model.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStockSummaries indicates an expected call of DeleteStockSummaries.
+func (mr *MockStockUsecaseMockRecorder) DeleteStockSummaries(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStockSummaries", reflect.TypeOf((*MockStockUsecase)(nil).DeleteStockSummaries), ctx, request)
+}
+
+// ExportStockSummaries mocks base method.
+func (m *MockStockUsecase) ExportStockSummaries(ctx context.Context, request model.ExportStockSummariesRequest, write func([]model.Summary) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportStockSummaries", ctx, request, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportStockSummaries indicates an expected call of ExportStockSummaries.
+func (mr *MockStockUsecaseMockRecorder) ExportStockSummaries(ctx, request, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportStockSummaries", reflect.TypeOf((*MockStockUsecase)(nil).ExportStockSummaries), ctx, request, write)
+}
+
 // GetStockSummary mocks base method.
 func (m *MockStockUsecase) GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +110,53 @@ func (mr *MockStockUsecaseMockRecorder) GetStockSummary(ctx, request interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockSummary", reflect.TypeOf((*MockStockUsecase)(nil).GetStockSummary), ctx, request)
 }
 
+// GetTransactions mocks base method.
+func (m *MockStockUsecase) GetTransactions(ctx context.Context, stockCode string, date time.Time) ([]model.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, stockCode, date)
+	ret0, _ := ret[0].([]model.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockStockUsecaseMockRecorder) GetTransactions(ctx, stockCode, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockStockUsecase)(nil).GetTransactions), ctx, stockCode, date)
+}
+
+// OverrideStockSummary mocks base method.
+func (m *MockStockUsecase) OverrideStockSummary(ctx context.Context, request model.OverrideStockSummaryRequest) (model.Summary, model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideStockSummary", ctx, request)
+	ret0, _ := ret[0].(model.Summary)
+	ret1, _ := ret[1].(model.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OverrideStockSummary indicates an expected call of OverrideStockSummary.
+func (mr *MockStockUsecaseMockRecorder) OverrideStockSummary(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideStockSummary", reflect.TypeOf((*MockStockUsecase)(nil).OverrideStockSummary), ctx, request)
+}
+
+// RecomputeStockSummary mocks base method.
+func (m *MockStockUsecase) RecomputeStockSummary(ctx context.Context, stockCode string, date time.Time) (model.Summary, model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeStockSummary", ctx, stockCode, date)
+	ret0, _ := ret[0].(model.Summary)
+	ret1, _ := ret[1].(model.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecomputeStockSummary indicates an expected call of RecomputeStockSummary.
+func (mr *MockStockUsecaseMockRecorder) RecomputeStockSummary(ctx, stockCode, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeStockSummary", reflect.TypeOf((*MockStockUsecase)(nil).RecomputeStockSummary), ctx, stockCode, date)
+}
+
 // UpdateStockSummary mocks base method.
 func (m *MockStockUsecase) UpdateStockSummary(ctx context.Context, transaction model.Transaction) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,220 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	auditActionOverride  = "override"
+	auditActionDelete    = "delete"
+	auditActionRecompute = "recompute"
+)
+
+// OverrideStockSummary corrects fields of a stock's summary, e.g. a High set by a bad tick, and audit-logs the change
+func (h *Handler) OverrideStockSummary(ctx context.Context, req *proto.OverrideStockSummaryRequest) (*proto.OverrideStockSummaryResponse, error) {
+	identity, err := getAdminIdentity(ctx, req.GetReason())
+	if err != nil {
+		return &proto.OverrideStockSummaryResponse{}, err
+	}
+
+	request, err := h.convertProtoToOverrideRequest(req)
+	if err != nil {
+		return &proto.OverrideStockSummaryResponse{}, err
+	}
+
+	before, after, err := h.stockUsecase.OverrideStockSummary(ctx, request)
+	if errors.Is(err, model.ErrSummaryNotFound) {
+		return &proto.OverrideStockSummaryResponse{}, status.Errorf(codes.NotFound, "stock %s has no summary on %s",
+			req.GetStockCode(), req.GetDate())
+	}
+	if errors.Is(err, model.ErrSummaryChanged) {
+		return &proto.OverrideStockSummaryResponse{}, status.Errorf(codes.Aborted, "stock %s summary on %s kept changing, retry",
+			req.GetStockCode(), req.GetDate())
+	}
+	if err != nil {
+		return &proto.OverrideStockSummaryResponse{}, err
+	}
+
+	audit(ctx, identity, auditActionOverride, req.GetReason(),
+		slog.String("date", req.GetDate()),
+		slog.Any("before", before),
+		slog.Any("after", after))
+
+	return &proto.OverrideStockSummaryResponse{
+		Before: convertSummaryToProto(before),
+		After:  convertSummaryToProto(after),
+	}, nil
+}
+
+// DeleteStockSummary deletes a stock's summaries of a date range and audit-logs the deleted summaries
+func (h *Handler) DeleteStockSummary(ctx context.Context, req *proto.DeleteStockSummaryRequest) (*proto.DeleteStockSummaryResponse, error) {
+	identity, err := getAdminIdentity(ctx, req.GetReason())
+	if err != nil {
+		return &proto.DeleteStockSummaryResponse{}, err
+	}
+
+	request, err := convertProtoToRequest(&proto.GetStockSummaryRequest{
+		StockCode: req.GetStockCode(),
+		FromDate:  req.GetFromDate(),
+		ToDate:    req.GetToDate(),
+	})
+	if err != nil {
+		return &proto.DeleteStockSummaryResponse{}, err
+	}
+
+	deleted, err := h.stockUsecase.DeleteStockSummaries(ctx, request)
+	if err != nil {
+		return &proto.DeleteStockSummaryResponse{}, err
+	}
+
+	audit(ctx, identity, auditActionDelete, req.GetReason(),
+		slog.String("from_date", req.GetFromDate()),
+		slog.String("to_date", req.GetToDate()),
+		slog.Any("deleted", deleted))
+
+	return &proto.DeleteStockSummaryResponse{
+		Deleted: convertResponseToProto(deleted).GetResult(),
+	}, nil
+}
+
+// RecomputeStockSummary rebuilds a stock's summary from its journaled transactions, e.g. after a bad override, and
+// audit-logs the change
+func (h *Handler) RecomputeStockSummary(ctx context.Context, req *proto.RecomputeStockSummaryRequest) (*proto.RecomputeStockSummaryResponse, error) {
+	identity, err := getAdminIdentity(ctx, req.GetReason())
+	if err != nil {
+		return &proto.RecomputeStockSummaryResponse{}, err
+	}
+
+	date, err := convertProtoToDate(req.GetStockCode(), req.GetDate())
+	if err != nil {
+		return &proto.RecomputeStockSummaryResponse{}, err
+	}
+
+	before, after, err := h.stockUsecase.RecomputeStockSummary(ctx, req.GetStockCode(), date)
+	if err != nil {
+		return &proto.RecomputeStockSummaryResponse{}, convertJournalError(err, req.GetStockCode(), req.GetDate())
+	}
+
+	audit(ctx, identity, auditActionRecompute, req.GetReason(),
+		slog.String("date", req.GetDate()),
+		slog.Any("before", before),
+		slog.Any("after", after))
+
+	response := &proto.RecomputeStockSummaryResponse{After: convertSummaryToProto(after)}
+	if before.StockCode != "" {
+		response.Before = convertSummaryToProto(before)
+	}
+
+	return response, nil
+}
+
+// getAdminIdentity returns the identity of the caller of an admin method, which must be authenticated and give a reason
+// for the audit log
+func getAdminIdentity(ctx context.Context, reason string) (model.Identity, error) {
+	identity, ok := model.IdentityFromContext(ctx)
+	if !ok {
+		return model.Identity{}, status.Error(codes.Unauthenticated, "admin methods require authentication")
+	}
+
+	if reason == "" {
+		return model.Identity{}, status.Error(codes.InvalidArgument, "reason cannot be empty")
+	}
+
+	return identity, nil
+}
+
+// audit logs a change of stored stock summaries with the caller who made it and why
+func audit(ctx context.Context, identity model.Identity, action, reason string, attrs ...any) {
+	attrs = append([]any{
+		slog.String("action", action),
+		slog.String(logger.KeyClientID, identity.ClientID),
+		slog.String(logger.KeyReason, reason),
+	}, attrs...)
+
+	logger.For("audit").InfoContext(ctx, "Changed stock summaries", attrs...)
+}
+
+// convertProtoToDate validates the stock code and parses the date of a request for a single summary
+func convertProtoToDate(stockCode, dateString string) (time.Time, error) {
+	if stockCode == "" {
+		return time.Time{}, status.Error(codes.InvalidArgument, "stockCode cannot be empty")
+	}
+
+	if dateString == "" {
+		return time.Time{}, status.Error(codes.InvalidArgument, "date cannot be empty")
+	}
+	date, err := time.Parse(stockSummaryDateFmt, dateString)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, "invalid date format; please input string with format yyyy-mm-dd")
+	}
+
+	return date, nil
+}
+
+// convertProtoToOverrideRequest parses the decimals of an override at the scales of the stock's instrument
+func (h *Handler) convertProtoToOverrideRequest(req *proto.OverrideStockSummaryRequest) (model.OverrideStockSummaryRequest, error) {
+	date, err := convertProtoToDate(req.GetStockCode(), req.GetDate())
+	if err != nil {
+		return model.OverrideStockSummaryRequest{}, err
+	}
+
+	decimals := req.GetDecimals()
+	if decimals.GetAverage() != "" || decimals.GetVwap() != "" {
+		return model.OverrideStockSummaryRequest{}, status.Error(codes.InvalidArgument,
+			"average and vwap can't be overridden; they are recomputed from value and volume")
+	}
+
+	instrument := h.instruments.Get(req.GetStockCode())
+	override := model.SummaryOverride{}
+	for _, field := range []struct {
+		name   string
+		value  string
+		scale  int32
+		target **int64
+	}{
+		{"prev", decimals.GetPrev(), instrument.PriceScale, &override.Prev},
+		{"open", decimals.GetOpen(), instrument.PriceScale, &override.Open},
+		{"high", decimals.GetHigh(), instrument.PriceScale, &override.High},
+		{"low", decimals.GetLow(), instrument.PriceScale, &override.Low},
+		{"close", decimals.GetClose(), instrument.PriceScale, &override.Close},
+		{"volume", decimals.GetVolume(), instrument.QuantityScale, &override.Volume},
+		{"value", decimals.GetValue(), instrument.PriceScale + instrument.QuantityScale, &override.Value},
+		{"buy_volume", decimals.GetBuyVolume(), instrument.QuantityScale, &override.BuyVolume},
+		{"sell_volume", decimals.GetSellVolume(), instrument.QuantityScale, &override.SellVolume},
+	} {
+		if field.value == "" {
+			continue
+		}
+
+		value, err := model.ParseDecimal(field.value, field.scale)
+		if err != nil {
+			return model.OverrideStockSummaryRequest{}, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field.name, err)
+		}
+		*field.target = &value
+	}
+
+	if override.IsEmpty() {
+		return model.OverrideStockSummaryRequest{}, status.Error(codes.InvalidArgument, "decimals must set at least one field")
+	}
+
+	return model.OverrideStockSummaryRequest{
+		StockCode: req.GetStockCode(),
+		Date:      date,
+		Override:  override,
+	}, nil
+}
@@ -0,0 +1,469 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock "stock/handler/_mock"
+	"stock/model"
+	"stock/proto"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func Test_Handler_OverrideStockSummary(t *testing.T) {
+	adminCtx := model.ContextWithIdentity(context.Background(), model.Identity{ClientID: "ops", Method: model.AuthMethodJWT})
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	high, price := int64(615000), int64(612500)
+
+	before := model.Summary{StockCode: "USDIDR", Date: date, High: 6150000, Low: 612500, PriceScale: 2}
+	after := model.Summary{StockCode: "USDIDR", Date: date, High: 615000, Low: 612500, PriceScale: 2}
+
+	type args struct {
+		ctx   context.Context
+		input *proto.OverrideStockSummaryRequest
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse *proto.OverrideStockSummaryResponse
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150"},
+					Reason:    "bad tick",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), model.OverrideStockSummaryRequest{
+						StockCode: "USDIDR",
+						Date:      date,
+						Override:  model.SummaryOverride{High: &high},
+					}).Return(before, after, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.OverrideStockSummaryResponse{
+				Before: convertSummaryToProto(before),
+				After:  convertSummaryToProto(after),
+			},
+		},
+		{
+			name: "success-close-and-low",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{Low: "6125", Close: "6125.00"},
+					Reason:    "bad tick",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), model.OverrideStockSummaryRequest{
+						StockCode: "USDIDR",
+						Date:      date,
+						Override:  model.SummaryOverride{Low: &price, Close: &price},
+					}).Return(before, after, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.OverrideStockSummaryResponse{
+				Before: convertSummaryToProto(before),
+				After:  convertSummaryToProto(after),
+			},
+		},
+		{
+			name: "error-unauthenticated",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150"},
+					Reason:    "bad tick",
+				},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.Unauthenticated,
+		},
+		{
+			name: "error-empty-reason",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150"},
+				},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-empty-override",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Reason:    "bad tick",
+				},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-override-vwap",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{Vwap: "6150.1234"},
+					Reason:    "bad tick",
+				},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-invalid-decimal",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150.125"},
+					Reason:    "bad tick",
+				},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-not-found",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150"},
+					Reason:    "bad tick",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, model.ErrSummaryNotFound)
+
+					return m
+				},
+			},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.NotFound,
+		},
+		{
+			name: "error-summary-changed",
+			args: args{
+				ctx: adminCtx,
+				input: &proto.OverrideStockSummaryRequest{
+					StockCode: "USDIDR",
+					Date:      "2023-08-29",
+					Decimals:  &proto.Decimals{High: "6150"},
+					Reason:    "bad tick",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, model.ErrSummaryChanged)
+
+					return m
+				},
+			},
+			wantResponse: &proto.OverrideStockSummaryResponse{},
+			wantCode:     codes.Aborted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+				instruments:  model.Instruments{{StockCode: "USDIDR", PriceScale: 2}},
+			}
+
+			gotResponse, err := h.OverrideStockSummary(tt.args.ctx, tt.args.input)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Handler.OverrideStockSummary() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if !protobuf.Equal(gotResponse, tt.wantResponse) {
+				t.Errorf("Handler.OverrideStockSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Handler_DeleteStockSummary(t *testing.T) {
+	adminCtx := model.ContextWithIdentity(context.Background(), model.Identity{ClientID: "ops", Method: model.AuthMethodAPIKey})
+	fromDate := time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	deleted := []model.Summary{{StockCode: "BBCA", Date: toDate, Close: 9050}}
+
+	type args struct {
+		ctx   context.Context
+		input *proto.DeleteStockSummaryRequest
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse *proto.DeleteStockSummaryResponse
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.DeleteStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-28", ToDate: "2023-08-29", Reason: "duplicate day"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().DeleteStockSummaries(gomock.Any(), model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate}).
+						Return(deleted, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.DeleteStockSummaryResponse{Deleted: []*proto.StockSummary{convertSummaryToProto(deleted[0])}},
+		},
+		{
+			name: "error-invalid-date-range",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.DeleteStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-29", ToDate: "2023-08-28", Reason: "duplicate day"},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.DeleteStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-delete-stock-summaries",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.DeleteStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-28", ToDate: "2023-08-29", Reason: "duplicate day"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().DeleteStockSummaries(gomock.Any(), gomock.Any()).Return(nil, errors.New("error-delete-stock-summaries"))
+
+					return m
+				},
+			},
+			wantResponse: &proto.DeleteStockSummaryResponse{},
+			wantCode:     codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			gotResponse, err := h.DeleteStockSummary(tt.args.ctx, tt.args.input)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Handler.DeleteStockSummary() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if !protobuf.Equal(gotResponse, tt.wantResponse) {
+				t.Errorf("Handler.DeleteStockSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Handler_RecomputeStockSummary(t *testing.T) {
+	adminCtx := model.ContextWithIdentity(context.Background(), model.Identity{ClientID: "ops", Method: model.AuthMethodAPIKey})
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	before := model.Summary{StockCode: "BBCA", Date: date, High: 90500, Close: 9025}
+	after := model.Summary{StockCode: "BBCA", Date: date, High: 9050, Close: 9025}
+
+	type args struct {
+		ctx   context.Context
+		input *proto.RecomputeStockSummaryRequest
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse *proto.RecomputeStockSummaryResponse
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.RecomputeStockSummaryRequest{StockCode: "BBCA", Date: "2023-08-29", Reason: "bad override"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().RecomputeStockSummary(gomock.Any(), "BBCA", date).Return(before, after, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.RecomputeStockSummaryResponse{
+				Before: convertSummaryToProto(before),
+				After:  convertSummaryToProto(after),
+			},
+		},
+		{
+			name: "success-deleted-summary",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.RecomputeStockSummaryRequest{StockCode: "BBCA", Date: "2023-08-29", Reason: "deleted by mistake"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().RecomputeStockSummary(gomock.Any(), "BBCA", date).Return(model.Summary{}, after, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.RecomputeStockSummaryResponse{After: convertSummaryToProto(after)},
+		},
+		{
+			name: "error-empty-reason",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.RecomputeStockSummaryRequest{StockCode: "BBCA", Date: "2023-08-29"},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.RecomputeStockSummaryResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-journal-not-found",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.RecomputeStockSummaryRequest{StockCode: "BBCA", Date: "2023-08-29", Reason: "bad override"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().RecomputeStockSummary(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, model.ErrJournalNotFound)
+
+					return m
+				},
+			},
+			wantResponse: &proto.RecomputeStockSummaryResponse{},
+			wantCode:     codes.NotFound,
+		},
+		{
+			name: "error-journal-disabled",
+			args: args{
+				ctx:   adminCtx,
+				input: &proto.RecomputeStockSummaryRequest{StockCode: "BBCA", Date: "2023-08-29", Reason: "bad override"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().RecomputeStockSummary(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, model.ErrJournalDisabled)
+
+					return m
+				},
+			},
+			wantResponse: &proto.RecomputeStockSummaryResponse{},
+			wantCode:     codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			gotResponse, err := h.RecomputeStockSummary(tt.args.ctx, tt.args.input)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Handler.RecomputeStockSummary() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if !protobuf.Equal(gotResponse, tt.wantResponse) {
+				t.Errorf("Handler.RecomputeStockSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+
+	"stock/model"
+)
+
+const (
+	// csvTransactionFields is the number of fields of a CSV transaction event, which are the fields of
+	// model.KafkaTransaction in the order they are declared
+	csvTransactionFields = 9
+)
+
+// transactionDecoder decodes the value of a Kafka message into a transaction event
+type transactionDecoder func(data []byte) (model.KafkaTransaction, error)
+
+// newTransactionDecoders returns the decoder of each topic; topics are validated by the Kafka server beforehand
+func newTransactionDecoders(topics []model.KafkaTopic) map[string]transactionDecoder {
+	decoders := map[string]transactionDecoder{}
+	for _, topic := range topics {
+		switch topic.Decoder {
+		case model.KafkaDecoderCSV:
+			decoders[topic.Name] = decodeCSVTransaction
+		default:
+			decoders[topic.Name] = decodeJSONTransaction
+		}
+	}
+	return decoders
+}
+
+// getDecoder returns the decoder of topic, messages of unknown topics being decoded as JSON
+func (h *Handler) getDecoder(topic string) transactionDecoder {
+	if decoder, ok := h.decoders[topic]; ok {
+		return decoder
+	}
+	return decodeJSONTransaction
+}
+
+func decodeJSONTransaction(data []byte) (model.KafkaTransaction, error) {
+	input := model.KafkaTransaction{}
+	err := json.Unmarshal(data, &input)
+	return input, err
+}
+
+func decodeCSVTransaction(data []byte) (model.KafkaTransaction, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = csvTransactionFields
+
+	record, err := reader.Read()
+	if err != nil {
+		return model.KafkaTransaction{}, err
+	}
+
+	return model.KafkaTransaction{
+		Type:             record[0],
+		OrderBook:        record[1],
+		OrderNumber:      record[2],
+		OrderVerb:        record[3],
+		Quantity:         record[4],
+		Price:            record[5],
+		StockCode:        record[6],
+		ExecutedQuantity: record[7],
+		ExecutionPrice:   record[8],
+	}, nil
+}
@@ -0,0 +1,194 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportChunkSize is the size of the chunks streamed by ExportStockSummaries
+	exportChunkSize = 64 * 1024
+)
+
+var (
+	exportColumns = []string{"stock_code", "date", "prev", "open", "high", "low", "close", "volume", "value", "average",
+		"period"}
+)
+
+// summaryEncoder writes stock summaries in an export format
+type summaryEncoder interface {
+	Encode(summaries []model.Summary) error
+	Close() error
+}
+
+// ExportStockSummaries streams the requested stock summaries as a CSV or Parquet file, in chunks of exportChunkSize
+func (h *Handler) ExportStockSummaries(req *proto.ExportStockSummariesRequest, stream proto.Stock_ExportStockSummariesServer) error {
+	w := &exportChunkWriter{stream: stream}
+	if err := h.WriteStockSummaries(stream.Context(), req, w); err != nil {
+		return err
+	}
+
+	return w.flush()
+}
+
+// WriteStockSummaries writes the requested stock summaries to w in the requested format
+func (h *Handler) WriteStockSummaries(ctx context.Context, req *proto.ExportStockSummariesRequest, w io.Writer) error {
+	request, err := convertProtoToExportRequest(req)
+	if err != nil {
+		return err
+	}
+
+	var encoder summaryEncoder
+	switch request.Format {
+	case model.ExportFormatCSV:
+		encoder = newCSVEncoder(w)
+	case model.ExportFormatParquet:
+		encoder = newParquetEncoder(w)
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported export format %s", request.Format)
+	}
+
+	if err := h.stockUsecase.ExportStockSummaries(ctx, request, encoder.Encode); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func convertProtoToExportRequest(req *proto.ExportStockSummariesRequest) (model.ExportStockSummariesRequest, error) {
+	for _, stockCode := range req.GetStockCodes() {
+		if stockCode == "" {
+			return model.ExportStockSummariesRequest{}, status.Error(codes.InvalidArgument, "stockCodes cannot contain an empty stock code")
+		}
+	}
+
+	fromDate, toDate, err := convertProtoToDateRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return model.ExportStockSummariesRequest{}, err
+	}
+
+	format := model.ExportFormat(req.GetFormat())
+	if format == "" {
+		format = model.ExportFormatCSV
+	}
+	if format != model.ExportFormatCSV && format != model.ExportFormatParquet {
+		return model.ExportStockSummariesRequest{}, status.Errorf(codes.InvalidArgument, "invalid format %s; please input csv or parquet", format)
+	}
+
+	return model.ExportStockSummariesRequest{
+		StockCodes: req.GetStockCodes(),
+		FromDate:   fromDate,
+		ToDate:     toDate,
+		Format:     format,
+	}, nil
+}
+
+// csvEncoder writes stock summaries as CSV with a header row. Prices, volumes and values are written as decimals.
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (encoder *csvEncoder) Encode(summaries []model.Summary) error {
+	if err := encoder.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, summary := range summaries {
+		priceScale, quantityScale := summary.PriceScale, summary.QuantityScale
+		record := []string{
+			summary.StockCode,
+			summary.Date.Format(stockSummaryDateFmt),
+			model.FormatDecimal(summary.Prev, priceScale),
+			model.FormatDecimal(summary.Open, priceScale),
+			model.FormatDecimal(summary.High, priceScale),
+			model.FormatDecimal(summary.Low, priceScale),
+			model.FormatDecimal(summary.Close, priceScale),
+			model.FormatDecimal(summary.Volume, quantityScale),
+			model.FormatDecimal(summary.Value, priceScale+quantityScale),
+			model.FormatDecimal(summary.Average, priceScale),
+			summary.Period(),
+		}
+
+		if err := encoder.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	encoder.w.Flush()
+	return encoder.w.Error()
+}
+
+func (encoder *csvEncoder) Close() error {
+	if err := encoder.writeHeader(); err != nil {
+		return err
+	}
+
+	encoder.w.Flush()
+	return encoder.w.Error()
+}
+
+func (encoder *csvEncoder) writeHeader() error {
+	if encoder.headerWritten {
+		return nil
+	}
+
+	encoder.headerWritten = true
+	return encoder.w.Write(exportColumns)
+}
+
+// exportChunkWriter buffers the exported file and sends it on the stream in chunks of exportChunkSize
+type exportChunkWriter struct {
+	stream proto.Stock_ExportStockSummariesServer
+	buffer []byte
+}
+
+func (w *exportChunkWriter) Write(data []byte) (int, error) {
+	w.buffer = append(w.buffer, data...)
+	for len(w.buffer) >= exportChunkSize {
+		if err := w.send(w.buffer[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buffer = w.buffer[exportChunkSize:]
+	}
+
+	return len(data), nil
+}
+
+func (w *exportChunkWriter) flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	err := w.send(w.buffer)
+	w.buffer = nil
+	return err
+}
+
+func (w *exportChunkWriter) send(chunk []byte) error {
+	// The chunk is copied, as the buffer is reused once Send returns
+	data := make([]byte, len(chunk))
+	copy(data, chunk)
+
+	if err := w.stream.Send(&proto.ExportStockSummariesResponse{Data: data}); err != nil {
+		return fmt.Errorf("failed to send export chunk: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,512 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mock "stock/handler/_mock"
+	"stock/model"
+	"stock/proto"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	exportSummaries = []model.Summary{
+		{
+			StockCode: "BBCA",
+			Date:      time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC),
+			Prev:      8950,
+			Open:      9000,
+			High:      9050,
+			Low:       8950,
+			Close:     9000,
+			Volume:    200,
+			Value:     1800000,
+			Average:   9000,
+		},
+		{
+			StockCode: "BBCA",
+			Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+			Prev:      9000,
+			Open:      9025,
+			High:      9100,
+			Low:       9000,
+			Close:     9050,
+			Volume:    100,
+			Value:     905000,
+			Average:   9050,
+		},
+	}
+)
+
+// mockExportUsecase returns a usecase passing pages of summaries to the export
+func mockExportUsecase(ctrl *gomock.Controller, pages ...[]model.Summary) StockUsecase {
+	m := mock.NewMockStockUsecase(ctrl)
+	m.EXPECT().ExportStockSummaries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request model.ExportStockSummariesRequest, write func([]model.Summary) error) error {
+			for _, page := range pages {
+				if err := write(page); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	return m
+}
+
+func Test_Handler_WriteStockSummaries(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *proto.ExportStockSummariesRequest
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantOutput string
+		wantCode   codes.Code
+	}{
+		{
+			name: "success-csv",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					StockCodes: []string{"BBCA"},
+					FromDate:   "2023-08-01",
+					ToDate:     "2023-08-31",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().ExportStockSummaries(gomock.Any(), model.ExportStockSummariesRequest{
+						StockCodes: []string{"BBCA"},
+						FromDate:   time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+						ToDate:     time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+						Format:     model.ExportFormatCSV,
+					}, gomock.Any()).DoAndReturn(
+						func(ctx context.Context, request model.ExportStockSummariesRequest, write func([]model.Summary) error) error {
+							if err := write(exportSummaries[:1]); err != nil {
+								return err
+							}
+							return write(exportSummaries[1:])
+						})
+					return m
+				},
+			},
+			wantOutput: "stock_code,date,prev,open,high,low,close,volume,value,average,period\n" +
+				"BBCA,2023-08-28,8950,9000,9050,8950,9000,200,1800000,9000,day\n" +
+				"BBCA,2023-08-29,9000,9025,9100,9000,9050,100,905000,9050,day\n",
+		},
+		{
+			name: "success-csv-decimal",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					StockCodes: []string{"USDIDR"},
+					FromDate:   "2023-08-29",
+					ToDate:     "2023-08-29",
+					Format:     "csv",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mockExportUsecase(ctrl, []model.Summary{
+						{
+							StockCode:     "USDIDR",
+							Date:          time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+							Prev:          1523400,
+							Open:          1523425,
+							High:          1523450,
+							Low:           1523425,
+							Close:         1523450,
+							Volume:        10010,
+							Value:         15249484375,
+							Average:       1523425,
+							PriceScale:    2,
+							QuantityScale: 1,
+						},
+					})
+				},
+			},
+			wantOutput: "stock_code,date,prev,open,high,low,close,volume,value,average,period\n" +
+				"USDIDR,2023-08-29,15234.00,15234.25,15234.50,15234.25,15234.50,1001.0,15249484.375,15234.25,day\n",
+		},
+		{
+			name: "success-csv-empty",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					FromDate: "2023-08-01",
+					ToDate:   "2023-08-31",
+					Format:   "csv",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mockExportUsecase(ctrl)
+				},
+			},
+			wantOutput: "stock_code,date,prev,open,high,low,close,volume,value,average,period\n",
+		},
+		{
+			name: "error-invalid-format",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					FromDate: "2023-08-01",
+					ToDate:   "2023-08-31",
+					Format:   "xlsx",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mock.NewMockStockUsecase(ctrl)
+				},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "error-empty-stock-code",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					StockCodes: []string{"BBCA", ""},
+					FromDate:   "2023-08-01",
+					ToDate:     "2023-08-31",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mock.NewMockStockUsecase(ctrl)
+				},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "error-invalid-date-range",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					FromDate: "2023-08-31",
+					ToDate:   "2023-08-01",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mock.NewMockStockUsecase(ctrl)
+				},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "error-export-stock-summaries",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.ExportStockSummariesRequest{
+					FromDate: "2023-08-01",
+					ToDate:   "2023-08-31",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().ExportStockSummaries(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(errors.New("error-export-stock-summaries"))
+					return m
+				},
+			},
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			var output bytes.Buffer
+			err := h.WriteStockSummaries(tt.args.ctx, tt.args.input, &output)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("handler.WriteStockSummaries() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if err == nil && output.String() != tt.wantOutput {
+				t.Errorf("handler.WriteStockSummaries() gotOutput = %q, wantOutput %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+// mockExportStream collects the chunks sent by ExportStockSummaries
+type mockExportStream struct {
+	grpc.ServerStream
+	chunks [][]byte
+}
+
+func (stream *mockExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (stream *mockExportStream) Send(resp *proto.ExportStockSummariesResponse) error {
+	stream.chunks = append(stream.chunks, resp.GetData())
+	return nil
+}
+
+func Test_Handler_ExportStockSummaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Enough rows for the CSV to span several chunks
+	var summaries []model.Summary
+	for i := 0; i < 3000; i++ {
+		summaries = append(summaries, exportSummaries[i%len(exportSummaries)])
+	}
+
+	h := &Handler{
+		stockUsecase: mockExportUsecase(ctrl, summaries[:1000], summaries[1000:]),
+	}
+
+	stream := &mockExportStream{}
+	err := h.ExportStockSummaries(&proto.ExportStockSummariesRequest{
+		StockCodes: []string{"BBCA"},
+		FromDate:   "2023-08-01",
+		ToDate:     "2023-08-31",
+	}, stream)
+	if err != nil {
+		t.Fatalf("handler.ExportStockSummaries() err = %v", err)
+	}
+
+	if len(stream.chunks) < 2 {
+		t.Fatalf("handler.ExportStockSummaries() sent %d chunks, want several", len(stream.chunks))
+	}
+
+	var output []byte
+	for i, chunk := range stream.chunks {
+		if len(chunk) > exportChunkSize || (i < len(stream.chunks)-1 && len(chunk) != exportChunkSize) {
+			t.Errorf("handler.ExportStockSummaries() chunk %d has %d bytes, want %d", i, len(chunk), exportChunkSize)
+		}
+		output = append(output, chunk...)
+	}
+
+	if lines := strings.Count(string(output), "\n"); lines != len(summaries)+1 {
+		t.Errorf("handler.ExportStockSummaries() got %d lines, want %d", lines, len(summaries)+1)
+	}
+}
+
+func Test_parquetEncoder(t *testing.T) {
+	tests := []struct {
+		name          string
+		rows          int
+		wantRowGroups int
+	}{
+		{
+			name:          "success-empty",
+			rows:          0,
+			wantRowGroups: 0,
+		},
+		{
+			name:          "success-single-row-group",
+			rows:          len(exportSummaries),
+			wantRowGroups: 1,
+		},
+		{
+			name:          "success-many-row-groups",
+			rows:          parquetRowGroupSize + 1,
+			wantRowGroups: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var summaries []model.Summary
+			for i := 0; i < tt.rows; i++ {
+				summary := exportSummaries[i%len(exportSummaries)]
+				summary.Volume = int64(i)
+				summary.PriceScale = int32(i % 3)
+				summaries = append(summaries, summary)
+			}
+
+			var output bytes.Buffer
+			encoder := newParquetEncoder(&output)
+			for start := 0; start < len(summaries); start += 500 {
+				end := start + 500
+				if end > len(summaries) {
+					end = len(summaries)
+				}
+				if err := encoder.Encode(summaries[start:end]); err != nil {
+					t.Fatalf("parquetEncoder.Encode() err = %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("parquetEncoder.Close() err = %v", err)
+			}
+
+			data := output.Bytes()
+			if !bytes.HasPrefix(data, []byte(parquetMagic)) || !bytes.HasSuffix(data, []byte(parquetMagic)) {
+				t.Fatalf("parquetEncoder wrote no Parquet magic")
+			}
+
+			footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+			metadata := readThriftStruct(t, data[len(data)-8-footerSize:len(data)-8])
+
+			if numRows := metadata[3].(int64); numRows != int64(tt.rows) {
+				t.Errorf("parquetEncoder num_rows = %d, want %d", numRows, tt.rows)
+			}
+
+			schema := metadata[2].([]interface{})
+			if len(schema) != len(parquetColumns)+1 {
+				t.Fatalf("parquetEncoder schema has %d elements, want %d", len(schema), len(parquetColumns)+1)
+			}
+			for i, column := range parquetColumns {
+				if name := string(schema[i+1].(map[int16]interface{})[4].([]byte)); name != column.name {
+					t.Errorf("parquetEncoder schema element %d = %s, want %s", i+1, name, column.name)
+				}
+			}
+
+			rowGroups, _ := metadata[4].([]interface{})
+			if len(rowGroups) != tt.wantRowGroups {
+				t.Fatalf("parquetEncoder wrote %d row groups, want %d", len(rowGroups), tt.wantRowGroups)
+			}
+
+			// Read back the volume column, which holds the row index, and the price scale column
+			volumeColumn, priceScaleColumn := 7, 11
+			var volumes, priceScales []int64
+			for _, rowGroup := range rowGroups {
+				columns := rowGroup.(map[int16]interface{})[1].([]interface{})
+				volumes = append(volumes, readParquetColumn(t, data, columns[volumeColumn], 8)...)
+				priceScales = append(priceScales, readParquetColumn(t, data, columns[priceScaleColumn], 4)...)
+			}
+
+			for i, volume := range volumes {
+				if volume != int64(i) {
+					t.Fatalf("parquetEncoder volume %d = %d, want %d", i, volume, i)
+				}
+				if priceScales[i] != int64(i%3) {
+					t.Fatalf("parquetEncoder price_scale %d = %d, want %d", i, priceScales[i], i%3)
+				}
+			}
+			if len(volumes) != tt.rows || len(priceScales) != tt.rows {
+				t.Errorf("parquetEncoder wrote %d volumes and %d price scales, want %d", len(volumes), len(priceScales), tt.rows)
+			}
+		})
+	}
+}
+
+// readParquetColumn returns the values of a column chunk's single PLAIN encoded page of integers of width bytes
+func readParquetColumn(t *testing.T, data []byte, columnChunk interface{}, width int64) []int64 {
+	columnMetaData := columnChunk.(map[int16]interface{})[3].(map[int16]interface{})
+	offset := columnMetaData[9].(int64)
+
+	reader := &thriftReader{t: t, data: data[offset:]}
+	pageHeader := reader.readStruct()
+	numValues := pageHeader[5].(map[int16]interface{})[1].(int64)
+
+	values := data[offset+int64(reader.pos):]
+	result := make([]int64, 0, numValues)
+	for i := int64(0); i < numValues; i++ {
+		if width == 4 {
+			result = append(result, int64(int32(binary.LittleEndian.Uint32(values[i*width:]))))
+		} else {
+			result = append(result, int64(binary.LittleEndian.Uint64(values[i*width:])))
+		}
+	}
+	return result
+}
+
+func readThriftStruct(t *testing.T, data []byte) map[int16]interface{} {
+	reader := &thriftReader{t: t, data: data}
+	return reader.readStruct()
+}
+
+// thriftReader decodes Thrift compact protocol structs into maps of field ID to value
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var lastID int16
+	for {
+		header := r.data[r.pos]
+		r.pos++
+		if header == 0 {
+			return fields
+		}
+
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.readZigZag())
+		}
+		fields[id] = r.readValue(header & 0x0f)
+		lastID = id
+	}
+}
+
+func (r *thriftReader) readValue(valueType byte) interface{} {
+	switch valueType {
+	case 1, 2:
+		return valueType == 1
+	case thriftTypeI32, thriftTypeI64:
+		return r.readZigZag()
+	case thriftTypeBinary:
+		size := int(r.readUvarint())
+		value := r.data[r.pos : r.pos+size]
+		r.pos += size
+		return value
+	case thriftTypeList:
+		header := r.data[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.readUvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.readValue(header & 0x0f)
+		}
+		return list
+	case thriftTypeStruct:
+		return r.readStruct()
+	default:
+		r.t.Fatalf("unexpected thrift type %d", valueType)
+		return nil
+	}
+}
+
+func (r *thriftReader) readUvarint() uint64 {
+	value, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("invalid varint at %d", r.pos)
+	}
+	r.pos += n
+	return value
+}
+
+func (r *thriftReader) readZigZag() int64 {
+	value := r.readUvarint()
+	return int64(value>>1) ^ -int64(value&1)
+}
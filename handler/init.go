@@ -7,6 +7,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"stock/model"
 	"stock/proto"
@@ -16,15 +17,29 @@ import (
 type StockUsecase interface {
 	UpdateStockSummary(ctx context.Context, transaction model.Transaction) error
 	GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error)
+	CompactStockSummaries(ctx context.Context, now time.Time, dryRun bool) (model.RetentionReport, error)
+	ExportStockSummaries(ctx context.Context, request model.ExportStockSummariesRequest, write func(summaries []model.Summary) error) error
+	ClearStockSummaries(ctx context.Context, fromDate time.Time) (model.ClearReport, error)
+	OverrideStockSummary(ctx context.Context, request model.OverrideStockSummaryRequest) (before, after model.Summary, err error)
+	DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error)
+	GetTransactions(ctx context.Context, stockCode string, date time.Time) ([]model.JournalEntry, error)
+	RecomputeStockSummary(ctx context.Context, stockCode string, date time.Time) (before, after model.Summary, err error)
 }
 
 type Handler struct {
 	proto.UnimplementedStockServer
+	proto.UnimplementedStockAdminServer
 	stockUsecase StockUsecase
+	schedule     model.TradingSchedule
+	instruments  model.Instruments
+	decoders     map[string]transactionDecoder
 }
 
-func New(stockUsecase StockUsecase) *Handler {
+func New(cfg model.Config, stockUsecase StockUsecase) *Handler {
 	return &Handler{
 		stockUsecase: stockUsecase,
+		schedule:     cfg.Schedule,
+		instruments:  cfg.Instruments,
+		decoders:     newTransactionDecoders(cfg.Kafka.GetTopics()),
 	}
 }
@@ -0,0 +1,76 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetTransactions returns the journaled transactions of a stock's summary, in the order they were applied
+func (h *Handler) GetTransactions(ctx context.Context, req *proto.GetTransactionsRequest) (*proto.GetTransactionsResponse, error) {
+	date, err := convertProtoToDate(req.GetStockCode(), req.GetDate())
+	if err != nil {
+		return &proto.GetTransactionsResponse{}, err
+	}
+
+	entries, err := h.stockUsecase.GetTransactions(ctx, req.GetStockCode(), date)
+	if err != nil {
+		return &proto.GetTransactionsResponse{}, convertJournalError(err, req.GetStockCode(), req.GetDate())
+	}
+
+	return convertJournalToProto(entries), nil
+}
+
+// convertJournalError returns the status of an error of the journal of stockCode on date
+func convertJournalError(err error, stockCode, date string) error {
+	switch {
+	case errors.Is(err, model.ErrJournalDisabled):
+		return status.Error(codes.FailedPrecondition, "the transaction journal is disabled")
+	case errors.Is(err, model.ErrJournalNotFound):
+		return status.Errorf(codes.NotFound, "stock %s has no journaled transactions on %s", stockCode, date)
+	default:
+		return err
+	}
+}
+
+func convertJournalToProto(entries []model.JournalEntry) *proto.GetTransactionsResponse {
+	response := &proto.GetTransactionsResponse{
+		Transactions: []*proto.Transaction{},
+	}
+	for _, entry := range entries {
+		transaction := entry.Transaction
+
+		timestamp := ""
+		if !transaction.Timestamp.IsZero() {
+			timestamp = transaction.Timestamp.Format(time.RFC3339)
+		}
+
+		response.Transactions = append(response.Transactions, &proto.Transaction{
+			Id:            entry.ID,
+			Type:          string(transaction.Type),
+			OrderNumber:   transaction.OrderNumber,
+			Price:         transaction.Price,
+			Quantity:      transaction.Quantity,
+			Timestamp:     timestamp,
+			Sequence:      transaction.Sequence,
+			Session:       string(transaction.Session),
+			Board:         string(transaction.Board),
+			Side:          string(transaction.Side),
+			PriceScale:    transaction.PriceScale,
+			QuantityScale: transaction.QuantityScale,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,157 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock "stock/handler/_mock"
+	"stock/model"
+	"stock/proto"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func Test_Handler_GetTransactions(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx   context.Context
+		input *proto.GetTransactionsRequest
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse *proto.GetTransactionsResponse
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   context.Background(),
+				input: &proto.GetTransactionsRequest{StockCode: "BBCA", Date: "2023-08-29"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", date).Return([]model.JournalEntry{
+						{ID: "1693272600000-0", Transaction: model.Transaction{Type: model.TransactionTypeA, StockCode: "BBCA", Price: 9000, Date: date}},
+						{ID: "1693301400000-0", Transaction: model.Transaction{
+							Type:        model.TransactionTypeE,
+							StockCode:   "BBCA",
+							OrderNumber: "202308290930000001",
+							Price:       9050,
+							Quantity:    100,
+							Date:        date,
+							Timestamp:   time.Date(2023, 8, 29, 9, 30, 0, 0, time.UTC),
+							Sequence:    1,
+							Session:     model.SessionOne,
+							Board:       model.BoardRegular,
+							Side:        model.SideBuy,
+						}},
+					}, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.GetTransactionsResponse{
+				Transactions: []*proto.Transaction{
+					{Id: "1693272600000-0", Type: "A", Price: 9000},
+					{
+						Id:          "1693301400000-0",
+						Type:        "E",
+						OrderNumber: "202308290930000001",
+						Price:       9050,
+						Quantity:    100,
+						Timestamp:   "2023-08-29T09:30:00Z",
+						Sequence:    1,
+						Session:     "session_one",
+						Board:       "regular",
+						Side:        "buy",
+					},
+				},
+			},
+		},
+		{
+			name: "error-empty-date",
+			args: args{
+				ctx:   context.Background(),
+				input: &proto.GetTransactionsRequest{StockCode: "BBCA"},
+			},
+			fields:       fields{stockUsecase: func(ctrl *gomock.Controller) StockUsecase { return mock.NewMockStockUsecase(ctrl) }},
+			wantResponse: &proto.GetTransactionsResponse{},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "error-journal-disabled",
+			args: args{
+				ctx:   context.Background(),
+				input: &proto.GetTransactionsRequest{StockCode: "BBCA", Date: "2023-08-29"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, model.ErrJournalDisabled)
+
+					return m
+				},
+			},
+			wantResponse: &proto.GetTransactionsResponse{},
+			wantCode:     codes.FailedPrecondition,
+		},
+		{
+			name: "error-get-transactions",
+			args: args{
+				ctx:   context.Background(),
+				input: &proto.GetTransactionsRequest{StockCode: "BBCA", Date: "2023-08-29"},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error-get-transactions"))
+
+					return m
+				},
+			},
+			wantResponse: &proto.GetTransactionsResponse{},
+			wantCode:     codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			gotResponse, err := h.GetTransactions(tt.args.ctx, tt.args.input)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Handler.GetTransactions() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if !protobuf.Equal(gotResponse, tt.wantResponse) {
+				t.Errorf("Handler.GetTransactions() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
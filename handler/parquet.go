@@ -0,0 +1,339 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"stock/model"
+)
+
+// Parquet physical types, converted types and enums as defined in parquet.thrift
+const (
+	parquetMagic = "PAR1"
+
+	parquetTypeInt32     = 1
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+
+	parquetConvertedTypeNone = -1
+	parquetConvertedTypeUTF8 = 0
+	parquetConvertedTypeDate = 6
+
+	parquetRepetitionRequired = 0
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+	parquetPageTypeData       = 0
+
+	// parquetRowGroupSize bounds the number of rows buffered before they are written as a row group
+	parquetRowGroupSize = 10000
+)
+
+// Thrift compact protocol field types
+const (
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+// parquetColumn is a required column of the exported file, PLAIN encoded
+type parquetColumn struct {
+	name          string
+	physicalType  int32
+	convertedType int32
+	appendValue   func(data []byte, summary model.Summary) []byte
+}
+
+var (
+	parquetColumns = []parquetColumn{
+		parquetStringColumn("stock_code", func(summary model.Summary) string { return summary.StockCode }),
+		{name: "date", physicalType: parquetTypeInt32, convertedType: parquetConvertedTypeDate,
+			appendValue: func(data []byte, summary model.Summary) []byte {
+				// Days since the epoch of the summary's local date
+				days := model.TradingDate(summary.Date, time.UTC).Sub(time.Unix(0, 0).UTC()) / (24 * time.Hour)
+				return binary.LittleEndian.AppendUint32(data, uint32(int32(days)))
+			}},
+		parquetInt64Column("prev", func(summary model.Summary) int64 { return summary.Prev }),
+		parquetInt64Column("open", func(summary model.Summary) int64 { return summary.Open }),
+		parquetInt64Column("high", func(summary model.Summary) int64 { return summary.High }),
+		parquetInt64Column("low", func(summary model.Summary) int64 { return summary.Low }),
+		parquetInt64Column("close", func(summary model.Summary) int64 { return summary.Close }),
+		parquetInt64Column("volume", func(summary model.Summary) int64 { return summary.Volume }),
+		parquetInt64Column("value", func(summary model.Summary) int64 { return summary.Value }),
+		parquetInt64Column("average", func(summary model.Summary) int64 { return summary.Average }),
+		parquetStringColumn("period", model.Summary.Period),
+		parquetInt32Column("price_scale", func(summary model.Summary) int32 { return summary.PriceScale }),
+		parquetInt32Column("quantity_scale", func(summary model.Summary) int32 { return summary.QuantityScale }),
+	}
+)
+
+func parquetStringColumn(name string, value func(summary model.Summary) string) parquetColumn {
+	return parquetColumn{
+		name:          name,
+		physicalType:  parquetTypeByteArray,
+		convertedType: parquetConvertedTypeUTF8,
+		appendValue: func(data []byte, summary model.Summary) []byte {
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(value(summary))))
+			return append(data, value(summary)...)
+		},
+	}
+}
+
+func parquetInt32Column(name string, value func(summary model.Summary) int32) parquetColumn {
+	return parquetColumn{
+		name:          name,
+		physicalType:  parquetTypeInt32,
+		convertedType: parquetConvertedTypeNone,
+		appendValue: func(data []byte, summary model.Summary) []byte {
+			return binary.LittleEndian.AppendUint32(data, uint32(value(summary)))
+		},
+	}
+}
+
+func parquetInt64Column(name string, value func(summary model.Summary) int64) parquetColumn {
+	return parquetColumn{
+		name:          name,
+		physicalType:  parquetTypeInt64,
+		convertedType: parquetConvertedTypeNone,
+		appendValue: func(data []byte, summary model.Summary) []byte {
+			return binary.LittleEndian.AppendUint64(data, uint64(value(summary)))
+		},
+	}
+}
+
+type parquetRowGroup struct {
+	columns   []parquetColumnChunk
+	numRows   int64
+	totalSize int64
+}
+
+type parquetColumnChunk struct {
+	offset int64
+	size   int64
+}
+
+// parquetEncoder writes stock summaries as an uncompressed Parquet file, one row group per parquetRowGroupSize rows,
+// so that only a row group is held in memory. The file is complete once the encoder is closed. Prices, volumes and
+// values are the stored scaled integers, as scales differ between stocks; each row holds its price and quantity scales.
+type parquetEncoder struct {
+	w         io.Writer
+	offset    int64
+	rows      []model.Summary
+	rowGroups []parquetRowGroup
+	numRows   int64
+}
+
+func newParquetEncoder(w io.Writer) *parquetEncoder {
+	return &parquetEncoder{w: w}
+}
+
+func (encoder *parquetEncoder) Encode(summaries []model.Summary) error {
+	encoder.rows = append(encoder.rows, summaries...)
+	for len(encoder.rows) >= parquetRowGroupSize {
+		if err := encoder.writeRowGroup(encoder.rows[:parquetRowGroupSize]); err != nil {
+			return err
+		}
+		encoder.rows = encoder.rows[parquetRowGroupSize:]
+	}
+
+	return nil
+}
+
+func (encoder *parquetEncoder) Close() error {
+	if len(encoder.rows) > 0 || encoder.offset == 0 {
+		if err := encoder.writeRowGroup(encoder.rows); err != nil {
+			return err
+		}
+		encoder.rows = nil
+	}
+
+	footer := encoder.appendFileMetaData(nil)
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	return encoder.write(append(footer, parquetMagic...))
+}
+
+func (encoder *parquetEncoder) writeRowGroup(rows []model.Summary) error {
+	if encoder.offset == 0 {
+		if err := encoder.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	rowGroup := parquetRowGroup{numRows: int64(len(rows))}
+	for _, column := range parquetColumns {
+		var values []byte
+		for _, row := range rows {
+			values = column.appendValue(values, row)
+		}
+
+		chunk := appendParquetPageHeader(nil, len(rows), len(values))
+		chunk = append(chunk, values...)
+
+		rowGroup.columns = append(rowGroup.columns, parquetColumnChunk{offset: encoder.offset, size: int64(len(chunk))})
+		rowGroup.totalSize += int64(len(chunk))
+
+		if err := encoder.write(chunk); err != nil {
+			return err
+		}
+	}
+
+	encoder.rowGroups = append(encoder.rowGroups, rowGroup)
+	encoder.numRows += rowGroup.numRows
+	return nil
+}
+
+func (encoder *parquetEncoder) write(data []byte) error {
+	n, err := encoder.w.Write(data)
+	encoder.offset += int64(n)
+	return err
+}
+
+// appendParquetPageHeader appends the PageHeader of a data page. Required, non-nested columns have no
+// repetition or definition levels, so the page only holds the values.
+func appendParquetPageHeader(data []byte, numValues, size int) []byte {
+	w := thriftCompactWriter{data: data, fieldIDs: []int16{0}}
+	w.writeI32(1, parquetPageTypeData)
+	w.writeI32(2, int32(size))
+	w.writeI32(3, int32(size))
+	w.writeStructBegin(5)
+	w.writeI32(1, int32(numValues))
+	w.writeI32(2, parquetEncodingPlain)
+	w.writeI32(3, parquetEncodingRLE)
+	w.writeI32(4, parquetEncodingRLE)
+	w.writeStructEnd()
+	w.writeStructEnd()
+	return w.data
+}
+
+// appendFileMetaData appends the FileMetaData of the written row groups
+func (encoder *parquetEncoder) appendFileMetaData(data []byte) []byte {
+	w := thriftCompactWriter{data: data, fieldIDs: []int16{0}}
+	w.writeI32(1, 1)
+
+	w.writeListBegin(2, thriftTypeStruct, len(parquetColumns)+1)
+	w.writeElementBegin()
+	w.writeBinary(4, "schema")
+	w.writeI32(5, int32(len(parquetColumns)))
+	w.writeStructEnd()
+	for _, column := range parquetColumns {
+		w.writeElementBegin()
+		w.writeI32(1, column.physicalType)
+		w.writeI32(3, parquetRepetitionRequired)
+		w.writeBinary(4, column.name)
+		if column.convertedType != parquetConvertedTypeNone {
+			w.writeI32(6, column.convertedType)
+		}
+		w.writeStructEnd()
+	}
+
+	w.writeI64(3, encoder.numRows)
+
+	w.writeListBegin(4, thriftTypeStruct, len(encoder.rowGroups))
+	for _, rowGroup := range encoder.rowGroups {
+		w.writeElementBegin()
+		w.writeListBegin(1, thriftTypeStruct, len(rowGroup.columns))
+		for i, chunk := range rowGroup.columns {
+			w.writeElementBegin()
+			w.writeI64(2, chunk.offset)
+			w.writeStructBegin(3)
+			w.writeI32(1, parquetColumns[i].physicalType)
+			w.writeListBegin(2, thriftTypeI32, 1)
+			w.appendI32(parquetEncodingPlain)
+			w.writeListBegin(3, thriftTypeBinary, 1)
+			w.appendBinary(parquetColumns[i].name)
+			w.writeI32(4, parquetCodecUncompressed)
+			w.writeI64(5, rowGroup.numRows)
+			w.writeI64(6, chunk.size)
+			w.writeI64(7, chunk.size)
+			w.writeI64(9, chunk.offset)
+			w.writeStructEnd()
+			w.writeStructEnd()
+		}
+		w.writeI64(2, rowGroup.totalSize)
+		w.writeI64(3, rowGroup.numRows)
+		w.writeStructEnd()
+	}
+
+	w.writeBinary(6, "stock")
+	w.writeStructEnd()
+	return w.data
+}
+
+// thriftCompactWriter encodes structs with the Thrift compact protocol, which Parquet uses for its metadata.
+// fieldIDs holds the last written field ID of each open struct, as field IDs are delta encoded.
+type thriftCompactWriter struct {
+	data     []byte
+	fieldIDs []int16
+}
+
+func (w *thriftCompactWriter) writeFieldHeader(id int16, fieldType byte) {
+	last := &w.fieldIDs[len(w.fieldIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.data = append(w.data, byte(delta)<<4|fieldType)
+	} else {
+		w.data = append(w.data, fieldType)
+		w.data = binary.AppendUvarint(w.data, uint64(uint16((id<<1)^(id>>15))))
+	}
+	*last = id
+}
+
+func (w *thriftCompactWriter) writeI32(id int16, value int32) {
+	w.writeFieldHeader(id, thriftTypeI32)
+	w.appendI32(value)
+}
+
+func (w *thriftCompactWriter) writeI64(id int16, value int64) {
+	w.writeFieldHeader(id, thriftTypeI64)
+	w.data = binary.AppendUvarint(w.data, uint64((value<<1)^(value>>63)))
+}
+
+func (w *thriftCompactWriter) writeBinary(id int16, value string) {
+	w.writeFieldHeader(id, thriftTypeBinary)
+	w.appendBinary(value)
+}
+
+func (w *thriftCompactWriter) writeStructBegin(id int16) {
+	w.writeFieldHeader(id, thriftTypeStruct)
+	w.writeElementBegin()
+}
+
+// writeElementBegin begins a struct that is an element of a list
+func (w *thriftCompactWriter) writeElementBegin() {
+	w.fieldIDs = append(w.fieldIDs, 0)
+}
+
+func (w *thriftCompactWriter) writeStructEnd() {
+	w.data = append(w.data, 0)
+	w.fieldIDs = w.fieldIDs[:len(w.fieldIDs)-1]
+}
+
+func (w *thriftCompactWriter) writeListBegin(id int16, elementType byte, size int) {
+	w.writeFieldHeader(id, thriftTypeList)
+	if size < 15 {
+		w.data = append(w.data, byte(size)<<4|elementType)
+		return
+	}
+	w.data = append(w.data, 0xf0|elementType)
+	w.data = binary.AppendUvarint(w.data, uint64(size))
+}
+
+func (w *thriftCompactWriter) appendI32(value int32) {
+	w.data = binary.AppendUvarint(w.data, uint64(uint32((value<<1)^(value>>31))))
+}
+
+func (w *thriftCompactWriter) appendBinary(value string) {
+	w.data = binary.AppendUvarint(w.data, uint64(len(value)))
+	w.data = append(w.data, value...)
+}
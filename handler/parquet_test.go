@@ -0,0 +1,684 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+)
+
+// Enums of parquet.thrift and type IDs of the Thrift compact protocol, spelled out from the specifications instead of
+// reusing the encoder's constants, so that the reader below doesn't share the encoder's mistakes
+const (
+	specTypeInt32     = 1
+	specTypeInt64     = 2
+	specTypeByteArray = 6
+
+	specConvertedTypeUTF8 = 0
+	specConvertedTypeDate = 6
+
+	specRepetitionRequired = 0
+	specEncodingPlain      = 0
+	specCodecUncompressed  = 0
+	specPageTypeDataPage   = 0
+
+	compactBooleanTrue  = 1
+	compactBooleanFalse = 2
+	compactByte         = 3
+	compactI16          = 4
+	compactI32          = 5
+	compactI64          = 6
+	compactDouble       = 7
+	compactBinary       = 8
+	compactList         = 9
+	compactSet          = 10
+	compactMap          = 11
+	compactStruct       = 12
+
+	// specNone marks an optional field that isn't set
+	specNone = -1
+)
+
+func Test_parquetEncoder_Interop(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() err = %v", err)
+	}
+
+	rows := []model.Summary{
+		{
+			StockCode:     "BBCA",
+			Date:          time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta),
+			Prev:          9000,
+			Open:          9025,
+			High:          9100,
+			Low:           9000,
+			Close:         9050,
+			Volume:        100,
+			Value:         905000,
+			Average:       9050,
+			QuantityScale: 2,
+		},
+		{
+			StockCode: "USDIDR",
+			Date:      time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+			Prev:      -1,
+			Open:      math.MinInt64,
+			High:      math.MaxInt64,
+			Volume:    math.MaxInt64,
+			Value:     1 << 40,
+			Monthly:   true,
+
+			PriceScale: 4,
+		},
+		{
+			StockCode: "",
+			Date:      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var manyRows []model.Summary
+	for i := 0; i < 2*parquetRowGroupSize+3; i++ {
+		summary := rows[i%len(rows)]
+		summary.Volume = int64(i)
+		summary.Date = summary.Date.AddDate(0, 0, i%400)
+		manyRows = append(manyRows, summary)
+	}
+
+	tests := []struct {
+		name string
+		rows []model.Summary
+	}{
+		{
+			name: "success-empty",
+		},
+		{
+			name: "success-rows",
+			rows: rows,
+		},
+		{
+			name: "success-many-row-groups",
+			rows: manyRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			encoder := newParquetEncoder(&output)
+			if err := encoder.Encode(tt.rows); err != nil {
+				t.Fatalf("parquetEncoder.Encode() err = %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("parquetEncoder.Close() err = %v", err)
+			}
+
+			file, err := readInteropParquetFile(output.Bytes())
+			if err != nil {
+				t.Fatalf("readInteropParquetFile() err = %v", err)
+			}
+
+			wantSchema := []interopSchemaElement{
+				{name: "stock_code", physicalType: specTypeByteArray, convertedType: specConvertedTypeUTF8},
+				{name: "date", physicalType: specTypeInt32, convertedType: specConvertedTypeDate},
+				{name: "prev", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "open", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "high", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "low", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "close", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "volume", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "value", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "average", physicalType: specTypeInt64, convertedType: specNone},
+				{name: "period", physicalType: specTypeByteArray, convertedType: specConvertedTypeUTF8},
+				{name: "price_scale", physicalType: specTypeInt32, convertedType: specNone},
+				{name: "quantity_scale", physicalType: specTypeInt32, convertedType: specNone},
+			}
+			for i := range wantSchema {
+				wantSchema[i].repetition = specRepetitionRequired
+			}
+			if !reflect.DeepEqual(file.columns, wantSchema) {
+				t.Errorf("parquetEncoder schema = %+v, want %+v", file.columns, wantSchema)
+			}
+
+			if file.numRows != int64(len(tt.rows)) || len(file.rows) != len(tt.rows) {
+				t.Fatalf("parquetEncoder num_rows = %d with %d rows read, want %d", file.numRows, len(file.rows), len(tt.rows))
+			}
+
+			for i, summary := range tt.rows {
+				// Days since the epoch of the summary's calendar date, in its own location
+				year, month, day := summary.Date.Date()
+				days := int32(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+
+				want := []interface{}{
+					summary.StockCode, days, summary.Prev, summary.Open, summary.High, summary.Low, summary.Close,
+					summary.Volume, summary.Value, summary.Average, summary.Period(), summary.PriceScale,
+					summary.QuantityScale,
+				}
+				if !reflect.DeepEqual(file.rows[i], want) {
+					t.Fatalf("parquetEncoder row %d = %v, want %v", i, file.rows[i], want)
+				}
+			}
+		})
+	}
+}
+
+// interopParquetFile is a Parquet file read by readInteropParquetFile: the leaf columns of its schema and its rows
+type interopParquetFile struct {
+	columns []interopSchemaElement
+	numRows int64
+	rows    [][]interface{}
+}
+
+type interopSchemaElement struct {
+	name          string
+	physicalType  int32
+	repetition    int32
+	convertedType int32
+}
+
+type interopFileMetaData struct {
+	version   int32
+	schema    []interopSchemaElement
+	children  []int32
+	numRows   int64
+	rowGroups []interopRowGroup
+}
+
+type interopRowGroup struct {
+	columns       []interopColumnMetaData
+	totalByteSize int64
+	numRows       int64
+}
+
+type interopColumnMetaData struct {
+	physicalType          int32
+	encodings             []int32
+	path                  []string
+	codec                 int32
+	numValues             int64
+	totalUncompressedSize int64
+	totalCompressedSize   int64
+	dataPageOffset        int64
+}
+
+type interopPageHeader struct {
+	pageType         int32
+	uncompressedSize int32
+	compressedSize   int32
+	numValues        int32
+	encoding         int32
+}
+
+// readInteropParquetFile reads a file of flat, required, uncompressed and PLAIN encoded columns, following the Parquet
+// format specification: the footer's FileMetaData, then the data pages of every column chunk of every row group
+func readInteropParquetFile(data []byte) (interopParquetFile, error) {
+	if len(data) < 12 || string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		return interopParquetFile{}, fmt.Errorf("no PAR1 magic")
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := int64(len(data)) - 8 - footerSize
+	if footerStart < 4 {
+		return interopParquetFile{}, fmt.Errorf("footer of %d bytes doesn't fit in the file", footerSize)
+	}
+
+	decoder := &compactDecoder{data: data[footerStart : len(data)-8]}
+	metadata := decoder.fileMetaData()
+	if decoder.err == nil && decoder.pos != len(decoder.data) {
+		decoder.fail("footer has %d trailing bytes", len(decoder.data)-decoder.pos)
+	}
+	if decoder.err != nil {
+		return interopParquetFile{}, fmt.Errorf("invalid FileMetaData: %w", decoder.err)
+	}
+
+	if metadata.version != 1 && metadata.version != 2 {
+		return interopParquetFile{}, fmt.Errorf("unknown version %d", metadata.version)
+	}
+	if len(metadata.schema) == 0 || int(metadata.children[0]) != len(metadata.schema)-1 {
+		return interopParquetFile{}, fmt.Errorf("schema root doesn't hold the %d other elements", len(metadata.schema)-1)
+	}
+
+	file := interopParquetFile{columns: metadata.schema[1:], numRows: metadata.numRows}
+	for i, column := range file.columns {
+		if metadata.children[i+1] != 0 || column.physicalType == specNone {
+			return interopParquetFile{}, fmt.Errorf("column %s isn't a leaf", column.name)
+		}
+		if column.repetition != specRepetitionRequired {
+			return interopParquetFile{}, fmt.Errorf("column %s isn't required", column.name)
+		}
+	}
+
+	var rowCount int64
+	for _, rowGroup := range metadata.rowGroups {
+		if len(rowGroup.columns) != len(file.columns) {
+			return interopParquetFile{}, fmt.Errorf("row group has %d columns, want %d", len(rowGroup.columns), len(file.columns))
+		}
+
+		values := make([][]interface{}, len(file.columns))
+		var byteSize int64
+		for i, chunk := range rowGroup.columns {
+			column := file.columns[i]
+			if chunk.physicalType != column.physicalType || !reflect.DeepEqual(chunk.path, []string{column.name}) {
+				return interopParquetFile{}, fmt.Errorf("column chunk %v of type %d doesn't match column %s", chunk.path,
+					chunk.physicalType, column.name)
+			}
+			if chunk.codec != specCodecUncompressed || chunk.numValues != rowGroup.numRows {
+				return interopParquetFile{}, fmt.Errorf("column chunk %s has codec %d and %d values in %d rows",
+					column.name, chunk.codec, chunk.numValues, rowGroup.numRows)
+			}
+			if chunk.dataPageOffset < 4 || chunk.dataPageOffset+chunk.totalCompressedSize > footerStart {
+				return interopParquetFile{}, fmt.Errorf("column chunk %s isn't within the data", column.name)
+			}
+
+			chunkData := data[chunk.dataPageOffset : chunk.dataPageOffset+chunk.totalCompressedSize]
+			var err error
+			values[i], err = readInteropColumnChunk(chunkData, column.physicalType, chunk.numValues)
+			if err != nil {
+				return interopParquetFile{}, fmt.Errorf("column chunk %s: %w", column.name, err)
+			}
+			byteSize += chunk.totalUncompressedSize
+		}
+
+		if byteSize != rowGroup.totalByteSize {
+			return interopParquetFile{}, fmt.Errorf("row group has %d bytes, want %d", byteSize, rowGroup.totalByteSize)
+		}
+
+		for row := int64(0); row < rowGroup.numRows; row++ {
+			fields := make([]interface{}, len(values))
+			for i := range values {
+				fields[i] = values[i][row]
+			}
+			file.rows = append(file.rows, fields)
+		}
+		rowCount += rowGroup.numRows
+	}
+
+	if rowCount != metadata.numRows {
+		return interopParquetFile{}, fmt.Errorf("row groups have %d rows, want %d", rowCount, metadata.numRows)
+	}
+
+	return file, nil
+}
+
+// readInteropColumnChunk reads numValues PLAIN encoded values of physicalType from the data pages of a chunk
+func readInteropColumnChunk(data []byte, physicalType int32, numValues int64) ([]interface{}, error) {
+	var values []interface{}
+	for pos := 0; pos < len(data); {
+		decoder := &compactDecoder{data: data[pos:]}
+		header := decoder.pageHeader()
+		if decoder.err != nil {
+			return nil, fmt.Errorf("invalid PageHeader: %w", decoder.err)
+		}
+		pos += decoder.pos
+
+		if header.pageType != specPageTypeDataPage || header.encoding != specEncodingPlain {
+			return nil, fmt.Errorf("page of type %d and encoding %d isn't a PLAIN data page", header.pageType,
+				header.encoding)
+		}
+		if header.compressedSize != header.uncompressedSize || int(header.compressedSize) > len(data)-pos {
+			return nil, fmt.Errorf("page of %d bytes doesn't fit in the chunk", header.compressedSize)
+		}
+
+		// Values of required, non-nested columns have no repetition or definition levels
+		page := data[pos : pos+int(header.compressedSize)]
+		pos += len(page)
+		for i := int32(0); i < header.numValues; i++ {
+			var value interface{}
+			switch {
+			case physicalType == specTypeInt32 && len(page) >= 4:
+				value, page = int32(binary.LittleEndian.Uint32(page)), page[4:]
+			case physicalType == specTypeInt64 && len(page) >= 8:
+				value, page = int64(binary.LittleEndian.Uint64(page)), page[8:]
+			case physicalType == specTypeByteArray && len(page) >= 4:
+				size := binary.LittleEndian.Uint32(page)
+				if uint64(size) > uint64(len(page)-4) {
+					return nil, fmt.Errorf("byte array of %d bytes doesn't fit in the page", size)
+				}
+				value, page = string(page[4:4+size]), page[4+size:]
+			default:
+				return nil, fmt.Errorf("page ends before value %d of type %d", i, physicalType)
+			}
+			values = append(values, value)
+		}
+		if len(page) != 0 {
+			return nil, fmt.Errorf("page has %d trailing bytes", len(page))
+		}
+	}
+
+	if int64(len(values)) != numValues {
+		return nil, fmt.Errorf("read %d values, want %d", len(values), numValues)
+	}
+
+	return values, nil
+}
+
+// compactDecoder decodes the Thrift compact protocol. The first error is kept in err, after which every read returns
+// zero values.
+type compactDecoder struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (d *compactDecoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (d *compactDecoder) readByte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if d.pos >= len(d.data) {
+		d.fail("unexpected end of data at %d", d.pos)
+		return 0
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+	return b
+}
+
+// varint reads a ULEB128 integer
+func (d *compactDecoder) varint() uint64 {
+	var value uint64
+	for shift := 0; shift < 64; shift += 7 {
+		b := d.readByte()
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value
+		}
+	}
+
+	d.fail("varint longer than 10 bytes at %d", d.pos)
+	return 0
+}
+
+func (d *compactDecoder) zigzag() int64 {
+	value := d.varint()
+	return int64(value>>1) ^ -int64(value&1)
+}
+
+func (d *compactDecoder) i32() int32 {
+	value := d.zigzag()
+	if value < math.MinInt32 || value > math.MaxInt32 {
+		d.fail("i32 %d out of range", value)
+	}
+	return int32(value)
+}
+
+func (d *compactDecoder) binary() string {
+	size := d.varint()
+	if size > uint64(len(d.data)-d.pos) {
+		d.fail("binary of %d bytes at %d doesn't fit in the data", size, d.pos)
+		return ""
+	}
+
+	value := string(d.data[d.pos : d.pos+int(size)])
+	d.pos += int(size)
+	return value
+}
+
+// fields calls field with the ID and type of each field of a struct, which has to read or skip the field's value,
+// until the struct's stop field. Field IDs are either deltas in the field header or zigzag varints following it.
+func (d *compactDecoder) fields(field func(id int16, fieldType byte)) {
+	var id int16
+	for d.err == nil {
+		header := d.readByte()
+		if header == 0 {
+			return
+		}
+
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(d.zigzag())
+		}
+		field(id, header&0x0f)
+	}
+}
+
+// list reads the header of a list or set and calls element for each of its elements
+func (d *compactDecoder) list(element func(elementType byte)) {
+	header := d.readByte()
+	size := uint64(header >> 4)
+	if size == 15 {
+		size = d.varint()
+	}
+
+	for i := uint64(0); i < size && d.err == nil; i++ {
+		element(header & 0x0f)
+	}
+}
+
+// expect fails when a field doesn't have the type its struct defines
+func (d *compactDecoder) expect(id int16, fieldType, want byte) bool {
+	if fieldType != want {
+		d.fail("field %d has type %d, want %d", id, fieldType, want)
+		return false
+	}
+	return true
+}
+
+// skip skips a value of valueType. Booleans are held in the field header, but take a byte as elements of lists.
+func (d *compactDecoder) skip(valueType byte, element bool) {
+	switch valueType {
+	case compactBooleanTrue, compactBooleanFalse:
+		if element {
+			d.readByte()
+		}
+	case compactByte:
+		d.readByte()
+	case compactI16, compactI32, compactI64:
+		d.varint()
+	case compactDouble:
+		for i := 0; i < 8; i++ {
+			d.readByte()
+		}
+	case compactBinary:
+		d.binary()
+	case compactList, compactSet:
+		d.list(func(elementType byte) { d.skip(elementType, true) })
+	case compactMap:
+		size := d.varint()
+		if size == 0 {
+			return
+		}
+		types := d.readByte()
+		for i := uint64(0); i < size && d.err == nil; i++ {
+			d.skip(types>>4, true)
+			d.skip(types&0x0f, true)
+		}
+	case compactStruct:
+		d.fields(func(_ int16, fieldType byte) { d.skip(fieldType, false) })
+	default:
+		d.fail("unknown type %d at %d", valueType, d.pos)
+	}
+}
+
+func (d *compactDecoder) fileMetaData() interopFileMetaData {
+	metadata := interopFileMetaData{version: specNone, numRows: specNone}
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 1 && d.expect(id, fieldType, compactI32):
+			metadata.version = d.i32()
+		case id == 2 && d.expect(id, fieldType, compactList):
+			d.list(func(elementType byte) {
+				if d.expect(id, elementType, compactStruct) {
+					element, children := d.schemaElement()
+					metadata.schema = append(metadata.schema, element)
+					metadata.children = append(metadata.children, children)
+				}
+			})
+		case id == 3 && d.expect(id, fieldType, compactI64):
+			metadata.numRows = d.zigzag()
+		case id == 4 && d.expect(id, fieldType, compactList):
+			d.list(func(elementType byte) {
+				if d.expect(id, elementType, compactStruct) {
+					metadata.rowGroups = append(metadata.rowGroups, d.rowGroup())
+				}
+			})
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	if metadata.version == specNone || metadata.numRows == specNone {
+		d.fail("FileMetaData misses required fields")
+	}
+	return metadata
+}
+
+// schemaElement returns an element of the schema and its number of children
+func (d *compactDecoder) schemaElement() (interopSchemaElement, int32) {
+	element := interopSchemaElement{physicalType: specNone, repetition: specNone, convertedType: specNone}
+	var children int32
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 1 && d.expect(id, fieldType, compactI32):
+			element.physicalType = d.i32()
+		case id == 3 && d.expect(id, fieldType, compactI32):
+			element.repetition = d.i32()
+		case id == 4 && d.expect(id, fieldType, compactBinary):
+			element.name = d.binary()
+		case id == 5 && d.expect(id, fieldType, compactI32):
+			children = d.i32()
+		case id == 6 && d.expect(id, fieldType, compactI32):
+			element.convertedType = d.i32()
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	return element, children
+}
+
+func (d *compactDecoder) rowGroup() interopRowGroup {
+	rowGroup := interopRowGroup{totalByteSize: specNone, numRows: specNone}
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 1 && d.expect(id, fieldType, compactList):
+			d.list(func(elementType byte) {
+				if d.expect(id, elementType, compactStruct) {
+					rowGroup.columns = append(rowGroup.columns, d.columnChunk())
+				}
+			})
+		case id == 2 && d.expect(id, fieldType, compactI64):
+			rowGroup.totalByteSize = d.zigzag()
+		case id == 3 && d.expect(id, fieldType, compactI64):
+			rowGroup.numRows = d.zigzag()
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	if rowGroup.totalByteSize == specNone || rowGroup.numRows == specNone {
+		d.fail("RowGroup misses required fields")
+	}
+	return rowGroup
+}
+
+// columnChunk returns the ColumnMetaData of a ColumnChunk, which this reader requires
+func (d *compactDecoder) columnChunk() interopColumnMetaData {
+	var metadata *interopColumnMetaData
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 3 && d.expect(id, fieldType, compactStruct):
+			columnMetaData := d.columnMetaData()
+			metadata = &columnMetaData
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	if metadata == nil {
+		d.fail("ColumnChunk has no ColumnMetaData")
+		return interopColumnMetaData{}
+	}
+	return *metadata
+}
+
+func (d *compactDecoder) columnMetaData() interopColumnMetaData {
+	metadata := interopColumnMetaData{physicalType: specNone, codec: specNone, numValues: specNone,
+		totalUncompressedSize: specNone, totalCompressedSize: specNone, dataPageOffset: specNone}
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 1 && d.expect(id, fieldType, compactI32):
+			metadata.physicalType = d.i32()
+		case id == 2 && d.expect(id, fieldType, compactList):
+			d.list(func(elementType byte) {
+				if d.expect(id, elementType, compactI32) {
+					metadata.encodings = append(metadata.encodings, d.i32())
+				}
+			})
+		case id == 3 && d.expect(id, fieldType, compactList):
+			d.list(func(elementType byte) {
+				if d.expect(id, elementType, compactBinary) {
+					metadata.path = append(metadata.path, d.binary())
+				}
+			})
+		case id == 4 && d.expect(id, fieldType, compactI32):
+			metadata.codec = d.i32()
+		case id == 5 && d.expect(id, fieldType, compactI64):
+			metadata.numValues = d.zigzag()
+		case id == 6 && d.expect(id, fieldType, compactI64):
+			metadata.totalUncompressedSize = d.zigzag()
+		case id == 7 && d.expect(id, fieldType, compactI64):
+			metadata.totalCompressedSize = d.zigzag()
+		case id == 9 && d.expect(id, fieldType, compactI64):
+			metadata.dataPageOffset = d.zigzag()
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	if metadata.physicalType == specNone || metadata.codec == specNone || metadata.numValues == specNone ||
+		metadata.totalUncompressedSize == specNone || metadata.totalCompressedSize == specNone ||
+		metadata.dataPageOffset == specNone || len(metadata.encodings) == 0 || len(metadata.path) == 0 {
+		d.fail("ColumnMetaData misses required fields")
+	}
+	return metadata
+}
+
+func (d *compactDecoder) pageHeader() interopPageHeader {
+	header := interopPageHeader{pageType: specNone, uncompressedSize: specNone, compressedSize: specNone,
+		numValues: specNone, encoding: specNone}
+	d.fields(func(id int16, fieldType byte) {
+		switch {
+		case id == 1 && d.expect(id, fieldType, compactI32):
+			header.pageType = d.i32()
+		case id == 2 && d.expect(id, fieldType, compactI32):
+			header.uncompressedSize = d.i32()
+		case id == 3 && d.expect(id, fieldType, compactI32):
+			header.compressedSize = d.i32()
+		case id == 5 && d.expect(id, fieldType, compactStruct):
+			d.fields(func(id int16, fieldType byte) {
+				switch {
+				case id == 1 && d.expect(id, fieldType, compactI32):
+					header.numValues = d.i32()
+				case id == 2 && d.expect(id, fieldType, compactI32):
+					header.encoding = d.i32()
+				default:
+					d.skip(fieldType, false)
+				}
+			})
+		default:
+			d.skip(fieldType, false)
+		}
+	})
+
+	if header.pageType == specNone || header.uncompressedSize == specNone || header.compressedSize == specNone {
+		d.fail("PageHeader misses required fields")
+	}
+	return header
+}
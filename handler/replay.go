@@ -0,0 +1,37 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+)
+
+// ClearStockSummaries clears the stock summaries dated fromDate or later before a replay and logs what was cleared
+func (h *Handler) ClearStockSummaries(ctx context.Context, fromDate time.Time) (model.ClearReport, error) {
+	report, err := h.stockUsecase.ClearStockSummaries(ctx, fromDate)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to clear stock summaries", logger.Err(err))
+		return report, err
+	}
+
+	clearedFrom := "every date"
+	if !fromDate.IsZero() {
+		clearedFrom = fromDate.Format(stockSummaryDateFmt)
+	}
+
+	slog.InfoContext(ctx, "Cleared stock summaries",
+		slog.String("from_date", clearedFrom),
+		slog.Int64("rows", report.Rows),
+		slog.Int("stocks", len(report.StockCodes)),
+		slog.Int64("journals", report.Journals))
+
+	return report, nil
+}
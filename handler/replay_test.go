@@ -0,0 +1,113 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mock "stock/handler/_mock"
+	"stock/model"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Handler_ClearStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx      context.Context
+		fromDate time.Time
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse model.ClearReport
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), fromDate).
+						Return(model.ClearReport{FromDate: fromDate, Rows: 2, StockCodes: []string{"BBCA"}}, nil)
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate, Rows: 2, StockCodes: []string{"BBCA"}},
+		},
+		{
+			name: "success-every-date",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), time.Time{}).
+						Return(model.ClearReport{Rows: 10, StockCodes: []string{"BBCA", "TLKM"}}, nil)
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{Rows: 10, StockCodes: []string{"BBCA", "TLKM"}},
+		},
+		{
+			name: "error-clear-stock-summaries",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), fromDate).
+						Return(model.ClearReport{FromDate: fromDate}, errors.New("error-clear-stock-summaries"))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			gotResponse, err := handler.ClearStockSummaries(tt.args.ctx, tt.args.fromDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handler.ClearStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("handler.ClearStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+)
+
+// CompactStockSummaries applies the retention policy to every stock and logs the resulting report
+func (h *Handler) CompactStockSummaries(ctx context.Context, dryRun bool) (model.RetentionReport, error) {
+	report, err := h.stockUsecase.CompactStockSummaries(ctx, time.Now(), dryRun)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to compact stock summaries", logger.Err(err))
+		return report, err
+	}
+
+	for _, stockReport := range report.Stocks {
+		slog.InfoContext(ctx, "Archived daily summaries of stock",
+			slog.String(logger.KeyStockCode, stockReport.StockCode),
+			slog.Int("daily_rows", stockReport.DailyRows),
+			slog.String("from_date", stockReport.FromDate.Format(stockSummaryDateFmt)),
+			slog.String("to_date", stockReport.ToDate.Format(stockSummaryDateFmt)),
+			slog.Int("monthly_rows", stockReport.MonthlyRows),
+			slog.Bool("dry_run", report.DryRun))
+	}
+
+	slog.InfoContext(ctx, "Archived daily summaries",
+		slog.Int("daily_rows", report.DailyRows),
+		slog.Int("stocks", len(report.Stocks)),
+		slog.String("cutoff", report.Cutoff.Format(stockSummaryDateFmt)),
+		slog.Int("monthly_rows", report.MonthlyRows),
+		slog.Int64("journals", report.Journals),
+		slog.Bool("dry_run", report.DryRun))
+
+	return report, nil
+}
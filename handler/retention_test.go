@@ -0,0 +1,116 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mock "stock/handler/_mock"
+	"stock/model"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Handler_CompactStockSummaries(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		dryRun bool
+	}
+	type fields struct {
+		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse model.RetentionReport
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:    context.Background(),
+				dryRun: true,
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().CompactStockSummaries(gomock.Any(), gomock.Any(), true).Return(model.RetentionReport{
+						Cutoff:      time.Time{}.AddDate(0, 0, 1),
+						DryRun:      true,
+						DailyRows:   1,
+						MonthlyRows: 1,
+						Stocks: []model.StockRetentionReport{
+							{
+								StockCode:   "BBCA",
+								DailyRows:   1,
+								MonthlyRows: 1,
+							},
+						},
+					}, nil)
+
+					return m
+				},
+			},
+			wantResponse: model.RetentionReport{
+				Cutoff:      time.Time{}.AddDate(0, 0, 1),
+				DryRun:      true,
+				DailyRows:   1,
+				MonthlyRows: 1,
+				Stocks: []model.StockRetentionReport{
+					{
+						StockCode:   "BBCA",
+						DailyRows:   1,
+						MonthlyRows: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "error-compact-stock-summaries",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().CompactStockSummaries(gomock.Any(), gomock.Any(), false).
+						Return(model.RetentionReport{}, errors.New("error-compact-stock-summaries"))
+
+					return m
+				},
+			},
+			wantResponse: model.RetentionReport{},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler := &Handler{
+				stockUsecase: tt.fields.stockUsecase(ctrl),
+			}
+
+			gotResponse, err := handler.CompactStockSummaries(tt.args.ctx, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handler.CompactStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("handler.CompactStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -7,11 +7,13 @@ package handler
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"stock/model"
 	"stock/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -36,36 +38,44 @@ func (h *Handler) GetStockSummary(ctx context.Context, req *proto.GetStockSummar
 func convertProtoToRequest(req *proto.GetStockSummaryRequest) (model.GetStockSummaryRequest, error) {
 	stockCode := req.GetStockCode()
 	if stockCode == "" {
-		return model.GetStockSummaryRequest{}, errors.New("stockCode cannot be empty")
+		return model.GetStockSummaryRequest{}, status.Error(codes.InvalidArgument, "stockCode cannot be empty")
 	}
 
-	toDateString := req.GetToDate()
+	fromDate, toDate, err := convertProtoToDateRange(req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		return model.GetStockSummaryRequest{}, err
+	}
+
+	return model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  fromDate,
+		ToDate:    toDate,
+	}, nil
+}
+
+// convertProtoToDateRange parses and validates the requested date range
+func convertProtoToDateRange(fromDateString, toDateString string) (time.Time, time.Time, error) {
 	if toDateString == "" {
-		return model.GetStockSummaryRequest{}, errors.New("toDate cannot be empty")
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "toDate cannot be empty")
 	}
 	toDate, err := time.Parse(stockSummaryDateFmt, toDateString)
 	if err != nil {
-		return model.GetStockSummaryRequest{}, errors.New("invalid toDate format; please input string with format yyyy-mm-dd")
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "invalid toDate format; please input string with format yyyy-mm-dd")
 	}
 
-	fromDateString := req.GetFromDate()
 	if fromDateString == "" {
-		return model.GetStockSummaryRequest{}, errors.New("fromDateString cannot be empty")
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "fromDateString cannot be empty")
 	}
 	fromDate, err := time.Parse(stockSummaryDateFmt, fromDateString)
 	if err != nil {
-		return model.GetStockSummaryRequest{}, errors.New("invalid fromDate format, please input string with format yyyy-mm-dd")
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "invalid fromDate format, please input string with format yyyy-mm-dd")
 	}
 
 	if fromDate.After(toDate) {
-		return model.GetStockSummaryRequest{}, errors.New("toDate must be before or equal to fromDate")
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "toDate must be before or equal to fromDate")
 	}
 
-	return model.GetStockSummaryRequest{
-		StockCode: stockCode,
-		FromDate:  fromDate,
-		ToDate:    toDate,
-	}, nil
+	return fromDate, toDate, nil
 }
 
 func convertResponseToProto(response []model.Summary) *proto.GetStockSummaryResponse {
@@ -73,17 +83,112 @@ func convertResponseToProto(response []model.Summary) *proto.GetStockSummaryResp
 		Result: []*proto.StockSummary{},
 	}
 	for _, stockSummary := range response {
-		result.Result = append(result.Result, &proto.StockSummary{
-			StockCode: stockSummary.StockCode,
-			Date:      stockSummary.Date.Format(stockSummaryDateFmt),
-			Prev:      stockSummary.Prev,
-			Open:      stockSummary.Open,
-			High:      stockSummary.High,
-			Low:       stockSummary.Low,
-			Close:     stockSummary.Close,
-			Volume:    stockSummary.Volume,
-			Value:     stockSummary.Value,
-			Average:   stockSummary.Average,
+		result.Result = append(result.Result, convertSummaryToProto(stockSummary))
+	}
+
+	return result
+}
+
+// convertSummaryToProto returns the response fields of a stock summary
+func convertSummaryToProto(stockSummary model.Summary) *proto.StockSummary {
+	return &proto.StockSummary{
+		StockCode: stockSummary.StockCode,
+		Date:      stockSummary.Date.Format(stockSummaryDateFmt),
+		Prev:      stockSummary.Prev,
+		Open:      stockSummary.Open,
+		High:      stockSummary.High,
+		Low:       stockSummary.Low,
+		Close:     stockSummary.Close,
+		Volume:    stockSummary.Volume,
+		Value:     stockSummary.Value,
+		Average:   stockSummary.Average,
+		Sessions:  convertSessionSummariesToProto(stockSummary),
+		Trades:    stockSummary.Trades,
+		Boards:    convertBoardSummariesToProto(stockSummary),
+
+		BuyVolume:  stockSummary.BuyVolume,
+		SellVolume: stockSummary.SellVolume,
+		Period:     stockSummary.Period(),
+
+		PriceScale:    stockSummary.PriceScale,
+		QuantityScale: stockSummary.QuantityScale,
+		Vwap:          stockSummary.VWAP,
+		VwapScale:     stockSummary.VWAPScale,
+		Decimals: &proto.Decimals{
+			Prev:    model.FormatDecimal(stockSummary.Prev, stockSummary.PriceScale),
+			Open:    model.FormatDecimal(stockSummary.Open, stockSummary.PriceScale),
+			High:    model.FormatDecimal(stockSummary.High, stockSummary.PriceScale),
+			Low:     model.FormatDecimal(stockSummary.Low, stockSummary.PriceScale),
+			Close:   model.FormatDecimal(stockSummary.Close, stockSummary.PriceScale),
+			Volume:  model.FormatDecimal(stockSummary.Volume, stockSummary.QuantityScale),
+			Value:   model.FormatDecimal(stockSummary.Value, stockSummary.PriceScale+stockSummary.QuantityScale),
+			Average: model.FormatDecimal(stockSummary.Average, stockSummary.PriceScale),
+			Vwap:    model.FormatDecimal(stockSummary.VWAP, stockSummary.VWAPScale),
+
+			BuyVolume:  model.FormatDecimal(stockSummary.BuyVolume, stockSummary.QuantityScale),
+			SellVolume: model.FormatDecimal(stockSummary.SellVolume, stockSummary.QuantityScale),
+		},
+	}
+}
+
+// convertSessionSummariesToProto returns the OHLCV data of every session that has trades in chronological order
+func convertSessionSummariesToProto(stockSummary model.Summary) []*proto.SessionSummary {
+	var result []*proto.SessionSummary
+	for _, session := range []model.Session{
+		model.SessionPreOpening,
+		model.SessionOne,
+		model.SessionTwo,
+		model.SessionPreClosing,
+	} {
+		sessionSummary := stockSummary.GetSessionSummary(session)
+		if sessionSummary.Volume == 0 {
+			continue
+		}
+
+		result = append(result, &proto.SessionSummary{
+			Session: string(session),
+			Open:    sessionSummary.Open,
+			High:    sessionSummary.High,
+			Low:     sessionSummary.Low,
+			Close:   sessionSummary.Close,
+			Volume:  sessionSummary.Volume,
+			Value:   sessionSummary.Value,
+			Decimals: &proto.Decimals{
+				Open:   model.FormatDecimal(sessionSummary.Open, stockSummary.PriceScale),
+				High:   model.FormatDecimal(sessionSummary.High, stockSummary.PriceScale),
+				Low:    model.FormatDecimal(sessionSummary.Low, stockSummary.PriceScale),
+				Close:  model.FormatDecimal(sessionSummary.Close, stockSummary.PriceScale),
+				Volume: model.FormatDecimal(sessionSummary.Volume, stockSummary.QuantityScale),
+				Value:  model.FormatDecimal(sessionSummary.Value, stockSummary.PriceScale+stockSummary.QuantityScale),
+			},
+		})
+	}
+
+	return result
+}
+
+// convertBoardSummariesToProto returns the turnover of every board that has trades
+func convertBoardSummariesToProto(stockSummary model.Summary) []*proto.BoardSummary {
+	var result []*proto.BoardSummary
+	for _, board := range []model.Board{
+		model.BoardRegular,
+		model.BoardNegotiated,
+		model.BoardCash,
+	} {
+		boardSummary := stockSummary.GetBoardSummary(board)
+		if boardSummary.Trades == 0 {
+			continue
+		}
+
+		result = append(result, &proto.BoardSummary{
+			Board:  string(board),
+			Volume: boardSummary.Volume,
+			Value:  boardSummary.Value,
+			Trades: boardSummary.Trades,
+			Decimals: &proto.Decimals{
+				Volume: model.FormatDecimal(boardSummary.Volume, stockSummary.QuantityScale),
+				Value:  model.FormatDecimal(boardSummary.Value, stockSummary.PriceScale+stockSummary.QuantityScale),
+			},
 		})
 	}
 
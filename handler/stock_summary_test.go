@@ -65,6 +65,14 @@ func Test_Handler_GetStockSummary(t *testing.T) {
 							Volume:    900,
 							Value:     7210000,
 							Average:   8011,
+							VWAP:      80111111,
+							VWAPScale: 4,
+							Trades:    5,
+
+							Regular:    model.BoardSummary{Volume: 700, Value: 5610000, Trades: 4},
+							Cash:       model.BoardSummary{Volume: 200, Value: 1600000, Trades: 1},
+							BuyVolume:  500,
+							SellVolume: 300,
 						},
 					}, nil)
 
@@ -84,6 +92,212 @@ func Test_Handler_GetStockSummary(t *testing.T) {
 						Volume:    900,
 						Value:     7210000,
 						Average:   8011,
+						Decimals: &proto.Decimals{
+							Prev: "8000", Open: "8050", High: "8100", Low: "7950", Close: "8100",
+							Volume: "900", Value: "7210000", Average: "8011", Vwap: "8011.1111", BuyVolume: "500", SellVolume: "300",
+						},
+						Vwap:      80111111,
+						VwapScale: 4,
+						Period:    model.PeriodDay,
+						Trades:    5,
+						Boards: []*proto.BoardSummary{
+							{Board: string(model.BoardRegular), Volume: 700, Value: 5610000, Trades: 4,
+								Decimals: &proto.Decimals{Volume: "700", Value: "5610000"}},
+							{Board: string(model.BoardCash), Volume: 200, Value: 1600000, Trades: 1,
+								Decimals: &proto.Decimals{Volume: "200", Value: "1600000"}},
+						},
+						BuyVolume:  500,
+						SellVolume: 300,
+					},
+				},
+			},
+		},
+		{
+			name: "success-decimal",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.GetStockSummaryRequest{
+					StockCode: "USDIDR",
+					FromDate:  "0001-01-03",
+					ToDate:    "0001-01-03",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "USDIDR",
+						FromDate:  time.Time{}.AddDate(0, 0, 2),
+						ToDate:    time.Time{}.AddDate(0, 0, 2),
+					}).Return([]model.Summary{
+						{
+							StockCode: "USDIDR",
+							Date:      time.Time{}.AddDate(0, 0, 2),
+							Prev:      1523400,
+							Open:      1523425,
+							High:      1523450,
+							Low:       1523425,
+							Close:     1523450,
+							Volume:    15,
+							Value:     22851400,
+							Average:   1523426,
+							SessionOne: model.SessionSummary{
+								Open:   1523425,
+								High:   1523450,
+								Low:    1523425,
+								Close:  1523450,
+								Volume: 15,
+								Value:  22851400,
+							},
+							PriceScale:    2,
+							QuantityScale: 1,
+							VWAP:          15234266667,
+							VWAPScale:     6,
+						},
+					}, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "USDIDR",
+						Date:      "0001-01-03",
+						Prev:      1523400,
+						Open:      1523425,
+						High:      1523450,
+						Low:       1523425,
+						Close:     1523450,
+						Volume:    15,
+						Value:     22851400,
+						Average:   1523426,
+						Sessions: []*proto.SessionSummary{
+							{
+								Session: "session_one",
+								Open:    1523425,
+								High:    1523450,
+								Low:     1523425,
+								Close:   1523450,
+								Volume:  15,
+								Value:   22851400,
+								Decimals: &proto.Decimals{
+									Open: "15234.25", High: "15234.50", Low: "15234.25", Close: "15234.50",
+									Volume: "1.5", Value: "22851.400",
+								},
+							},
+						},
+						PriceScale:    2,
+						QuantityScale: 1,
+						Decimals: &proto.Decimals{
+							Prev: "15234.00", Open: "15234.25", High: "15234.50", Low: "15234.25", Close: "15234.50",
+							Volume: "1.5", Value: "22851.400", Average: "15234.26", Vwap: "15234.266667",
+							BuyVolume: "0.0", SellVolume: "0.0",
+						},
+						Vwap:      15234266667,
+						VwapScale: 6,
+						Period:    model.PeriodDay,
+					},
+				},
+			},
+		},
+		{
+			name: "success-with-sessions",
+			args: args{
+				ctx: context.Background(),
+				input: &proto.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  "0001-01-03",
+					ToDate:    "0001-01-03",
+				},
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Time{}.AddDate(0, 0, 2),
+						ToDate:    time.Time{}.AddDate(0, 0, 2),
+					}).Return([]model.Summary{
+						{
+							StockCode: "BBCA",
+							Date:      time.Time{}.AddDate(0, 0, 2),
+							Prev:      8000,
+							Open:      8050,
+							High:      8100,
+							Low:       8050,
+							Close:     8100,
+							Volume:    300,
+							Value:     2425000,
+							Average:   8083,
+							PreOpening: model.SessionSummary{
+								Open:   8050,
+								High:   8050,
+								Low:    8050,
+								Close:  8050,
+								Volume: 100,
+								Value:  805000,
+							},
+							PreClosing: model.SessionSummary{
+								Open:   8100,
+								High:   8100,
+								Low:    8100,
+								Close:  8100,
+								Volume: 200,
+								Value:  1620000,
+							},
+						},
+					}, nil)
+
+					return m
+				},
+			},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "BBCA",
+						Date:      "0001-01-03",
+						Prev:      8000,
+						Open:      8050,
+						High:      8100,
+						Low:       8050,
+						Close:     8100,
+						Volume:    300,
+						Value:     2425000,
+						Average:   8083,
+						Sessions: []*proto.SessionSummary{
+							{
+								Session: "pre_opening",
+								Open:    8050,
+								High:    8050,
+								Low:     8050,
+								Close:   8050,
+								Volume:  100,
+								Value:   805000,
+								Decimals: &proto.Decimals{
+									Open: "8050", High: "8050", Low: "8050", Close: "8050", Volume: "100", Value: "805000",
+								},
+							},
+							{
+								Session: "pre_closing",
+								Open:    8100,
+								High:    8100,
+								Low:     8100,
+								Close:   8100,
+								Volume:  200,
+								Value:   1620000,
+								Decimals: &proto.Decimals{
+									Open: "8100", High: "8100", Low: "8100", Close: "8100", Volume: "200", Value: "1620000",
+								},
+							},
+						},
+						Decimals: &proto.Decimals{
+							Prev: "8000", Open: "8050", High: "8100", Low: "8050", Close: "8100",
+							Volume: "300", Value: "2425000", Average: "8083", Vwap: "0", BuyVolume: "0", SellVolume: "0",
+						},
+						Period: model.PeriodDay,
 					},
 				},
 			},
@@ -7,30 +7,69 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 
+	"stock/logger"
 	"stock/model"
+	"stock/tracing"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	tracer = otel.Tracer("stock/handler")
 )
 
-func (h *Handler) ProcessStockTransaction(data []byte) error {
-	input := model.KafkaTransaction{}
-	if err := json.Unmarshal(data, &input); err != nil {
-		log.Printf("[Error][ProcessStockTransaction] error unmarshaling Transaction event: %v", err)
+func (h *Handler) ProcessStockTransaction(ctx context.Context, message *sarama.ConsumerMessage) (err error) {
+	ctx, span := tracer.Start(ctx, "ProcessStockTransaction")
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logger.ContextWith(ctx,
+		slog.String(logger.KeyTopic, message.Topic),
+		slog.Int64(logger.KeyPartition, int64(message.Partition)),
+		slog.Int64(logger.KeyOffset, message.Offset),
+	)
+
+	input, err := h.getDecoder(message.Topic)(message.Value)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to decode transaction event", logger.Err(err))
 		return err
 	}
 
-	transaction, err := input.ToTransaction()
+	ctx = logger.ContextWith(ctx,
+		slog.String(logger.KeyStockCode, input.StockCode),
+		slog.String(logger.KeyOrderNumber, input.OrderNumber),
+	)
+	span.SetAttributes(
+		attribute.String(logger.KeyStockCode, input.StockCode),
+		attribute.String(logger.KeyOrderNumber, input.OrderNumber),
+	)
+
+	transaction, err := input.ToTransaction(h.schedule, h.instruments.Get(input.StockCode))
 	if err != nil {
-		log.Printf("[Error][ProcessStockTransaction] error converting Transaction data: %v", err)
+		slog.ErrorContext(ctx, "Failed to convert transaction event", logger.Err(err))
 		return err
 	}
 
-	err = h.stockUsecase.UpdateStockSummary(context.Background(), transaction)
+	// An order may be filled several times, so each fill is identified by its message, which is the same when the
+	// message is delivered again
+	transaction.Source = fmt.Sprintf("%s-%d-%d", message.Topic, message.Partition, message.Offset)
+
+	err = h.stockUsecase.UpdateStockSummary(ctx, transaction)
+	var rejection *model.RejectionError
+	if errors.As(err, &rejection) {
+		slog.WarnContext(ctx, "Rejected transaction", slog.String(logger.KeyReason, string(rejection.Reason)), logger.Err(err))
+		return err
+	}
 	if err != nil {
-		log.Printf("[Error][UpdateStockSummary] %v", err)
+		slog.ErrorContext(ctx, "Failed to update stock summary", logger.Err(err))
 		return err
 	}
 
+	slog.DebugContext(ctx, "Processed transaction")
 	return nil
 }
@@ -6,6 +6,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -13,15 +14,18 @@ import (
 	mock "stock/handler/_mock"
 	"stock/model"
 
+	"github.com/IBM/sarama"
 	"github.com/golang/mock/gomock"
 )
 
 func Test_Handler_ProcessStockTransaction(t *testing.T) {
 	type args struct {
-		data []byte
+		topic string
+		data  []byte
 	}
 	type fields struct {
 		stockUsecase func(ctrl *gomock.Controller) StockUsecase
+		schedule     model.TradingSchedule
 	}
 	tests := []struct {
 		name   string
@@ -46,17 +50,60 @@ func Test_Handler_ProcessStockTransaction(t *testing.T) {
 					m := mock.NewMockStockUsecase(ctrl)
 
 					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
-						StockCode: "BBCA",
-						Price:     8200,
-						Quantity:  100,
-						Type:      model.TransactionTypeA,
-						Date:      time.Time{}.AddDate(0, 0, 1),
+						StockCode:   "BBCA",
+						OrderNumber: "000101020000073390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeA,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(7 * time.Second),
+						Sequence:    3390,
+						Source:      "stock-0-1",
 					}).Return(nil)
 
 					return m
 				},
 			},
 		},
+		{
+			name: "success-csv",
+			args: args{
+				topic: "stock-csv",
+				data:  []byte("A,,000101020000073390,,100,8200,BBCA,,\n"),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
+						StockCode:   "BBCA",
+						OrderNumber: "000101020000073390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeA,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(7 * time.Second),
+						Sequence:    3390,
+						Source:      "stock-csv-0-1",
+					}).Return(nil)
+
+					return m
+				},
+			},
+		},
+		{
+			name: "error-csv-fields",
+			args: args{
+				topic: "stock-csv",
+				data:  []byte("A,100,8200,BBCA\n"),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					return mock.NewMockStockUsecase(ctrl)
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "success-type-e-executed-qty-execution-price",
 			args: args{
@@ -73,11 +120,86 @@ func Test_Handler_ProcessStockTransaction(t *testing.T) {
 					m := mock.NewMockStockUsecase(ctrl)
 
 					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
-						StockCode: "BBCA",
-						Price:     8200,
-						Quantity:  100,
-						Type:      model.TransactionTypeA,
-						Date:      time.Time{}.AddDate(0, 0, 1),
+						StockCode:   "BBCA",
+						OrderNumber: "000101020000073390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeA,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(7 * time.Second),
+						Sequence:    3390,
+						Source:      "stock-0-1",
+					}).Return(nil)
+
+					return m
+				},
+			},
+		},
+		{
+			name: "success-session-from-schedule",
+			args: args{
+				data: []byte(`{
+					"type": "E",
+					"executed_quantity": "100",
+					"execution_price": "8200",
+					"stock_code": "BBCA",
+					"order_number": "000101020855003390"
+				}`),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
+						StockCode:   "BBCA",
+						OrderNumber: "000101020855003390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeE,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(8*time.Hour + 55*time.Minute),
+						Sequence:    3390,
+						Session:     model.SessionPreOpening,
+						Source:      "stock-0-1",
+					}).Return(nil)
+
+					return m
+				},
+				schedule: model.TradingSchedule{
+					PreOpening: model.SessionHours{Start: "08:45:00", End: "09:00:00"},
+					SessionOne: model.SessionHours{Start: "09:00:00", End: "12:00:00"},
+				},
+			},
+		},
+		{
+			name: "success-board-and-side",
+			args: args{
+				data: []byte(`{
+					"type": "E",
+					"order_book": "NG",
+					"order_verb": "S",
+					"executed_quantity": "100",
+					"execution_price": "8200",
+					"stock_code": "BBCA",
+					"order_number": "000101020000073390"
+				}`),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
+						StockCode:   "BBCA",
+						OrderNumber: "000101020000073390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeE,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(7 * time.Second),
+						Sequence:    3390,
+						Board:       model.BoardNegotiated,
+						Side:        model.SideSell,
+						Source:      "stock-0-1",
 					}).Return(nil)
 
 					return m
@@ -100,11 +222,15 @@ func Test_Handler_ProcessStockTransaction(t *testing.T) {
 					m := mock.NewMockStockUsecase(ctrl)
 
 					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Transaction{
-						StockCode: "BBCA",
-						Price:     8200,
-						Quantity:  100,
-						Type:      model.TransactionTypeA,
-						Date:      time.Time{}.AddDate(0, 0, 1),
+						StockCode:   "BBCA",
+						OrderNumber: "000101020000073390",
+						Price:       8200,
+						Quantity:    100,
+						Type:        model.TransactionTypeA,
+						Date:        time.Time{}.AddDate(0, 0, 1),
+						Timestamp:   time.Time{}.AddDate(0, 0, 1).Add(7 * time.Second),
+						Sequence:    3390,
+						Source:      "stock-0-1",
 					}).Return(errors.New("error-update-stock-summary"))
 
 					return m
@@ -112,6 +238,29 @@ func Test_Handler_ProcessStockTransaction(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error-rejected",
+			args: args{
+				data: []byte(`{
+					"type": "E",
+					"executed_quantity": "100",
+					"execution_price": "-8200",
+					"stock_code": "BBCA",
+					"order_number": "000101020000073390"
+				}`),
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+
+					m.EXPECT().UpdateStockSummary(gomock.Any(), gomock.Any()).
+						Return(&model.RejectionError{Reason: model.RejectionNonPositivePrice, Message: "price -8200 is not positive"})
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -119,9 +268,24 @@ func Test_Handler_ProcessStockTransaction(t *testing.T) {
 
 			handler := &Handler{
 				stockUsecase: tt.fields.stockUsecase(ctrl),
+				schedule:     tt.fields.schedule,
+				decoders: newTransactionDecoders([]model.KafkaTopic{
+					{Name: "stock", Decoder: model.KafkaDecoderJSON},
+					{Name: "stock-csv", Decoder: model.KafkaDecoderCSV},
+				}),
+			}
+
+			topic := tt.args.topic
+			if topic == "" {
+				topic = "stock"
 			}
 
-			err := handler.ProcessStockTransaction(tt.args.data)
+			err := handler.ProcessStockTransaction(context.Background(), &sarama.ConsumerMessage{
+				Topic:     topic,
+				Partition: 0,
+				Offset:    1,
+				Value:     tt.args.data,
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handler.ProcessStockTransaction() err = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,132 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"stock/model"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of the contextual fields carried by log records
+const (
+	KeyComponent   = "component"
+	KeyError       = "error"
+	KeyTopic       = "topic"
+	KeyPartition   = "partition"
+	KeyOffset      = "offset"
+	KeyStockCode   = "stock_code"
+	KeyOrderNumber = "order_number"
+	KeyRequestID   = "request_id"
+	KeyMethod      = "method"
+	KeyTraceID     = "trace_id"
+	KeySpanID      = "span_id"
+	KeyReason      = "reason"
+	KeyClientID    = "client_id"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type attrsContextKey struct{}
+
+// Init replaces the default logger with one configured from cfg
+func Init(cfg model.Log) error {
+	logger, err := New(cfg, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	slog.SetDefault(logger)
+	return nil
+}
+
+// New returns a logger writing records at or above cfg.Level to w, in the JSON or text format.
+// Records are annotated with the fields added to their context by ContextWith.
+func New(cfg model.Log, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %s: %w", cfg.Level, err)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %s", cfg.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// ContextWith returns a copy of ctx carrying attrs in addition to the fields already carried by ctx
+func ContextWith(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsContextKey{}).([]slog.Attr)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, attrsContextKey{}, merged)
+}
+
+// For returns the default logger annotated with the component, e.g. "kafka" or "grpc"
+func For(component string) *slog.Logger {
+	return slog.Default().With(KeyComponent, component)
+}
+
+// Err is the attribute of an error
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
+
+// Fatal logs msg at the error level and exits
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+// contextHandler adds the fields carried by a record's context to the record, along with the span it was logged in
+type contextHandler struct {
+	slog.Handler
+}
+
+func (handler *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(attrsContextKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String(KeyTraceID, spanContext.TraceID().String()),
+			slog.String(KeySpanID, spanContext.SpanID().String()),
+		)
+	}
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: handler.Handler.WithAttrs(attrs)}
+}
+
+func (handler *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: handler.Handler.WithGroup(name)}
+}
@@ -0,0 +1,136 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"stock/model"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     model.Log
+		log     func(logger *slog.Logger)
+		want    []map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "success-context-fields",
+			cfg:  model.Log{Level: "info", Format: "json"},
+			log: func(logger *slog.Logger) {
+				ctx := ContextWith(context.Background(), slog.String(KeyTopic, "stock"), slog.Int64(KeyOffset, 42))
+				ctx = ContextWith(ctx, slog.String(KeyStockCode, "BBCA"))
+				logger.ErrorContext(ctx, "Failed to update stock summary", Err(errors.New("error-update")))
+			},
+			want: []map[string]interface{}{
+				{
+					"level":      "ERROR",
+					"msg":        "Failed to update stock summary",
+					"error":      "error-update",
+					"topic":      "stock",
+					"offset":     float64(42),
+					"stock_code": "BBCA",
+				},
+			},
+		},
+		{
+			name: "success-span-fields",
+			cfg:  model.Log{Level: "info", Format: "json"},
+			log: func(logger *slog.Logger) {
+				ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+					SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				}))
+				logger.InfoContext(ctx, "Processed transaction")
+			},
+			want: []map[string]interface{}{
+				{
+					"level":    "INFO",
+					"msg":      "Processed transaction",
+					"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+					"span_id":  "00f067aa0ba902b7",
+				},
+			},
+		},
+		{
+			name: "success-level-filtered",
+			cfg:  model.Log{Level: "warn"},
+			log: func(logger *slog.Logger) {
+				logger.Info("Serving")
+				logger.Warn("Slow call")
+			},
+			want: []map[string]interface{}{
+				{"level": "WARN", "msg": "Slow call"},
+			},
+		},
+		{
+			name:    "error-invalid-level",
+			cfg:     model.Log{Level: "verbose"},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-format",
+			cfg:     model.Log{Format: "xml"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			logger, err := New(tt.cfg, &output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			tt.log(logger)
+
+			var got []map[string]interface{}
+			for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+				record := map[string]interface{}{}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("json.Unmarshal() err = %v", err)
+				}
+				delete(record, "time")
+				got = append(got, record)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_New_Text(t *testing.T) {
+	var output bytes.Buffer
+	logger, err := New(model.Log{Format: "text"}, &output)
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+
+	logger.With(KeyComponent, "kafka").InfoContext(ContextWith(context.Background(), slog.String(KeyOrderNumber, "1")), "Processed")
+
+	for _, want := range []string{"msg=Processed", "component=kafka", "order_number=1"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("New() output = %q, want it to contain %q", output.String(), want)
+		}
+	}
+}
@@ -6,14 +6,22 @@
 package main
 
 import (
+	"context"
+	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
+
+	// Embeds the timezone database for hosts and images without one
+	_ "time/tzdata"
 
 	"stock/handler"
+	"stock/logger"
 	"stock/model"
 	"stock/repo"
 	"stock/server"
+	"stock/tracing"
 	"stock/usecase"
 
 	"gopkg.in/yaml.v2"
@@ -26,15 +34,57 @@ const (
 func main() {
 	cfg := getConfig()
 
+	if err := logger.Init(cfg.Log); err != nil {
+		log.Fatalf("[Log] Invalid config: %v", err)
+	}
+
+	if err := cfg.Schedule.Validate(); err != nil {
+		logger.Fatal(logger.For("trading_schedule"), "Invalid config", logger.Err(err))
+	}
+
+	if err := cfg.Instruments.Validate(); err != nil {
+		logger.Fatal(logger.For("instruments"), "Invalid config", logger.Err(err))
+	}
+
+	if err := cfg.VWAP.Validate(); err != nil {
+		logger.Fatal(logger.For("vwap"), "Invalid config", logger.Err(err))
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Fatal(logger.For("tracing"), "Invalid config", logger.Err(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.For("tracing").Error("Failed to flush spans", logger.Err(err))
+		}
+	}()
+
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1], os.Args[2:]); err != nil {
+			logger.Fatal(logger.For("command"), "Command failed", "command", os.Args[1], logger.Err(err))
+		}
+		return
+	}
+
 	stockRepo := repo.New(cfg)
-	stockUsecase := usecase.New(stockRepo)
-	stockHandler := handler.New(stockUsecase)
+	stockUsecase := usecase.New(cfg, stockRepo)
+	stockHandler := handler.New(cfg, stockUsecase)
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt)
 
-	go server.ServeGRPC(cfg, stockHandler)
+	// The GRPC server and the gateway share the interceptors, and so the rate limits of each client
+	interceptors, err := server.NewInterceptors(cfg)
+	if err != nil {
+		logger.Fatal(logger.For("grpc"), "Invalid config", logger.Err(err))
+	}
+
+	go server.ServeGRPC(cfg, stockHandler, interceptors)
+	go server.ServeGateway(cfg, stockHandler, interceptors)
 	go server.ServeKafka(cfg, stockHandler)
+	go server.ServeRetention(cfg, stockHandler)
+	go server.ServeMetrics(cfg)
 
 	<-signals
 }
@@ -63,7 +113,7 @@ func getConfig() model.Config {
 		return cfg, nil
 	}()
 
-	if err != nil || cfg == (model.Config{}) {
+	if err != nil || reflect.DeepEqual(cfg, model.Config{}) {
 		return model.DefaultConfigLocal
 	}
 
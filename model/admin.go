@@ -0,0 +1,74 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSummaryNotFound is returned when a stock has no summary on the requested date
+	ErrSummaryNotFound = errors.New("stock summary not found")
+
+	// ErrSummaryChanged is returned when a summary kept being updated while it was overridden
+	ErrSummaryChanged = errors.New("stock summary changed while it was overridden")
+)
+
+// SummaryOverride holds the fields of a stock summary corrected by an administrator; nil fields are left unchanged
+type SummaryOverride struct {
+	Prev       *int64
+	Open       *int64
+	High       *int64
+	Low        *int64
+	Close      *int64
+	Volume     *int64
+	Value      *int64
+	BuyVolume  *int64
+	SellVolume *int64
+}
+
+// OverrideStockSummaryRequest corrects the stock's summary of Date
+type OverrideStockSummaryRequest struct {
+	StockCode string
+	Date      time.Time
+	Override  SummaryOverride
+}
+
+// IsEmpty reports whether override sets no field
+func (override SummaryOverride) IsEmpty() bool {
+	return override == SummaryOverride{}
+}
+
+// Apply returns summary with the fields of override set and its Average recomputed from Value and Volume.
+// The VWAP still has to be recomputed with WithVWAP.
+func (override SummaryOverride) Apply(summary Summary) Summary {
+	for _, field := range []struct {
+		value  *int64
+		target *int64
+	}{
+		{override.Prev, &summary.Prev},
+		{override.Open, &summary.Open},
+		{override.High, &summary.High},
+		{override.Low, &summary.Low},
+		{override.Close, &summary.Close},
+		{override.Volume, &summary.Volume},
+		{override.Value, &summary.Value},
+		{override.BuyVolume, &summary.BuyVolume},
+		{override.SellVolume, &summary.SellVolume},
+	} {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
+
+	summary.Average = 0
+	if summary.Volume != 0 {
+		summary.Average = summary.Value / summary.Volume
+	}
+
+	return summary
+}
@@ -0,0 +1,84 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+// Board is the market a trade is executed on
+type Board string
+
+const (
+	BoardRegular    Board = "regular"
+	BoardNegotiated Board = "negotiated"
+	BoardCash       Board = "cash"
+	BoardUndefined  Board = ""
+)
+
+// Side is the side of the order that initiated a trade, i.e. the aggressor
+type Side string
+
+const (
+	SideBuy       Side = "buy"
+	SideSell      Side = "sell"
+	SideUndefined Side = ""
+)
+
+// BoardSummary represents a stock's turnover on a single board
+type BoardSummary struct {
+	Volume int64 `json:"volume"`
+	Value  int64 `json:"value"`
+	Trades int64 `json:"trades"`
+}
+
+// apply returns boardSummary with the given trade added
+func (boardSummary BoardSummary) apply(price, quantity int64) BoardSummary {
+	boardSummary.Volume += quantity
+	boardSummary.Value += quantity * price
+	boardSummary.Trades++
+
+	return boardSummary
+}
+
+// merge returns boardSummary combined with the turnover of another period at the same scales, or ErrOverflow when the
+// sums don't fit an int64
+func (boardSummary BoardSummary) merge(other BoardSummary) (BoardSummary, error) {
+	var err error
+	if boardSummary.Volume, err = addInt64(boardSummary.Volume, other.Volume); err != nil {
+		return BoardSummary{}, err
+	}
+	if boardSummary.Value, err = addInt64(boardSummary.Value, other.Value); err != nil {
+		return BoardSummary{}, err
+	}
+	if boardSummary.Trades, err = addInt64(boardSummary.Trades, other.Trades); err != nil {
+		return BoardSummary{}, err
+	}
+
+	return boardSummary, nil
+}
+
+// convertToBoard returns the board of an order book code, e.g. "RG" for the regular board
+func convertToBoard(orderBook string) Board {
+	switch orderBook {
+	case "RG":
+		return BoardRegular
+	case "NG":
+		return BoardNegotiated
+	case "TN":
+		return BoardCash
+	default:
+		return BoardUndefined
+	}
+}
+
+// convertToSide returns the side of an order verb, "B" for buy or "S" for sell
+func convertToSide(orderVerb string) Side {
+	switch orderVerb {
+	case "B":
+		return SideBuy
+	case "S":
+		return SideSell
+	default:
+		return SideUndefined
+	}
+}
@@ -5,29 +5,417 @@
 
 package model
 
+import (
+	"fmt"
+	"time"
+)
+
 type Config struct {
-	GRPC  GRPC          `yaml:"grpc"`
-	Kafka KafkaConsumer `yaml:"kafka_consumer"`
-	Redis Redis         `yaml:"redis"`
+	GRPC        GRPC            `yaml:"grpc"`
+	HTTP        HTTP            `yaml:"http"`
+	Kafka       KafkaConsumer   `yaml:"kafka_consumer"`
+	Redis       Redis           `yaml:"redis"`
+	Schedule    TradingSchedule `yaml:"trading_schedule"`
+	Retention   Retention       `yaml:"retention"`
+	Validation  Validation      `yaml:"validation"`
+	Lateness    Lateness        `yaml:"lateness"`
+	Instruments Instruments     `yaml:"instruments"`
+	VWAP        VWAP            `yaml:"vwap"`
+	Journal     Journal         `yaml:"journal"`
+	Metrics     Metrics         `yaml:"metrics"`
+	Cache       Cache           `yaml:"cache"`
+	Auth        Auth            `yaml:"auth"`
+	RateLimit   RateLimit       `yaml:"rate_limit"`
+	Log         Log             `yaml:"log"`
+	Tracing     Tracing         `yaml:"tracing"`
 }
 
 type GRPC struct {
 	Network string `yaml:"network"`
 	Port    string `yaml:"port"`
+	TLS     TLS    `yaml:"tls"`
 }
 
-type KafkaConsumer struct {
-	Host    string `yaml:"host"`
+// HTTP holds the settings of the HTTP/JSON gateway to the GRPC handlers, which is served over the TLS of GRPC when
+// enabled there
+type HTTP struct {
+	Enabled bool   `yaml:"enabled"`
+	Network string `yaml:"network"`
 	Port    string `yaml:"port"`
-	GroupID string `yaml:"group_id"`
-	Topic   string `yaml:"topic"`
+	CORS    CORS   `yaml:"cors"`
+}
+
+// CORS holds the cross-origin requests allowed by the HTTP gateway; "*" in AllowedOrigins allows any origin
+type CORS struct {
+	AllowedOrigins []string      `yaml:"allowed_origins"`
+	AllowedHeaders []string      `yaml:"allowed_headers"`
+	MaxAge         time.Duration `yaml:"max_age"`
+}
+
+// TLS holds the paths of PEM encoded certificate files. Setting ClientCAFile enables verification of
+// client certificates, which are mandatory (mutual TLS) when RequireClientCert is set.
+type TLS struct {
+	Enabled           bool   `yaml:"enabled"`
+	CertFile          string `yaml:"cert_file"`
+	KeyFile           string `yaml:"key_file"`
+	ClientCAFile      string `yaml:"client_ca_file"`
+	RequireClientCert bool   `yaml:"require_client_cert"`
+}
+
+// KafkaConsumer configures the consumer group. Brokers and Topics take precedence over the single Host:Port broker
+// and Topic, which are kept for existing configs. Zero timeouts and fetch sizes keep the sarama defaults.
+type KafkaConsumer struct {
+	Host    string       `yaml:"host"`
+	Port    string       `yaml:"port"`
+	Brokers []string     `yaml:"brokers"`
+	GroupID string       `yaml:"group_id"`
+	Topic   string       `yaml:"topic"`
+	Topics  []KafkaTopic `yaml:"topics"`
+	// ClientID identifies the consumers in the brokers' logs and quotas
+	ClientID string `yaml:"client_id"`
+	// Version is the oldest Kafka version of the brokers, e.g. 2.8.0; the sarama default when empty
+	Version string `yaml:"version"`
+	// RebalanceStrategy is range, roundrobin, sticky or cooperative-sticky
+	RebalanceStrategy string        `yaml:"rebalance_strategy"`
+	SessionTimeout    time.Duration `yaml:"session_timeout"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	FetchMinBytes     int32         `yaml:"fetch_min_bytes"`
+	FetchDefaultBytes int32         `yaml:"fetch_default_bytes"`
+	FetchMaxBytes     int32         `yaml:"fetch_max_bytes"`
+	MaxWaitTime       time.Duration `yaml:"max_wait_time"`
+	SASL              KafkaSASL     `yaml:"sasl"`
+	TLS               KafkaTLS      `yaml:"tls"`
+	// InitialOffset is where a consumer group without committed offsets starts consuming, newest or oldest
+	InitialOffset string `yaml:"initial_offset"`
+	Replay        Replay `yaml:"replay"`
+}
+
+// KafkaTopic is a consumed topic, whose messages are decoded by Decoder:
+// - json: a JSON object with the fields of KafkaTransaction (the default)
+// - csv: a CSV record with the fields of KafkaTransaction, in the order they are declared
+type KafkaTopic struct {
+	Name    string `yaml:"name"`
+	Decoder string `yaml:"decoder"`
+}
+
+const (
+	KafkaDecoderJSON = "json"
+	KafkaDecoderCSV  = "csv"
+)
+
+const (
+	KafkaRebalanceRange             = "range"
+	KafkaRebalanceRoundRobin        = "roundrobin"
+	KafkaRebalanceSticky            = "sticky"
+	KafkaRebalanceCooperativeSticky = "cooperative-sticky"
+)
+
+// KafkaSASL authenticates the consumers with Mechanism, one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512
+type KafkaSASL struct {
+	Enabled   bool   `yaml:"enabled"`
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// KafkaTLS encrypts the connections to the brokers, which are verified against CAFile (the system pool when empty).
+// CertFile and KeyFile are the client certificate, for brokers that authenticate clients with TLS.
+type KafkaTLS struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// GetBrokers returns the addresses of the brokers, falling back to Host:Port
+func (cfg KafkaConsumer) GetBrokers() []string {
+	if len(cfg.Brokers) > 0 {
+		return cfg.Brokers
+	}
+	return []string{fmt.Sprintf("%s%s", cfg.Host, cfg.Port)}
+}
+
+// GetTopics returns the consumed topics, falling back to Topic decoded as JSON
+func (cfg KafkaConsumer) GetTopics() []KafkaTopic {
+	if len(cfg.Topics) > 0 {
+		return cfg.Topics
+	}
+	return []KafkaTopic{{Name: cfg.Topic, Decoder: KafkaDecoderJSON}}
+}
+
+func (cfg KafkaConsumer) GetTopicNames() []string {
+	var names []string
+	for _, topic := range cfg.GetTopics() {
+		names = append(names, topic.Name)
+	}
+	return names
 }
 
+const (
+	KafkaOffsetNewest = "newest"
+	KafkaOffsetOldest = "oldest"
+)
+
+// Replay holds the defaults of the replay command, which moves the consumer group's committed offsets back so that
+// transactions are consumed again. From is one of:
+//   - earliest: the oldest retained message of every partition of every topic
+//   - offsets: the offset of each partition of Topic given in Offsets; other partitions are left as they are.
+//     Topic may be omitted when a single topic is consumed.
+//   - timestamp: the first message of every partition produced at or after Timestamp, in the RFC 3339 format
+//
+// Stock summaries dated ClearFromDate (yyyy-mm-dd) or later are cleared first, so that they are recomputed from
+// scratch. It defaults to the date of Timestamp, and to every date when replaying from the earliest offsets.
+type Replay struct {
+	From          string          `yaml:"from"`
+	Topic         string          `yaml:"topic"`
+	Offsets       map[int32]int64 `yaml:"offsets"`
+	Timestamp     string          `yaml:"timestamp"`
+	ClearFromDate string          `yaml:"clear_from_date"`
+}
+
+const (
+	ReplayFromEarliest  = "earliest"
+	ReplayFromOffsets   = "offsets"
+	ReplayFromTimestamp = "timestamp"
+)
+
 type Redis struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	Password string `yaml:"password"`
-	DB       int    `yaml:"db" default:"0"`
+	Mode             string   `yaml:"mode"`
+	Host             string   `yaml:"host"`
+	Port             string   `yaml:"port"`
+	Password         string   `yaml:"password"`
+	DB               int      `yaml:"db" default:"0"`
+	MasterName       string   `yaml:"master_name"`
+	SentinelAddrs    []string `yaml:"sentinel_addrs"`
+	SentinelPassword string   `yaml:"sentinel_password"`
+	ClusterAddrs     []string `yaml:"cluster_addrs"`
+}
+
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// Auth holds the credentials accepted by the GRPC server and the access policy of each client.
+// Callers authenticate with either a bearer JWT, whose subject is the client ID, or a static API key.
+type Auth struct {
+	Enabled bool           `yaml:"enabled"`
+	JWT     JWT            `yaml:"jwt"`
+	APIKeys []APIKey       `yaml:"api_keys"`
+	Clients []ClientPolicy `yaml:"clients"`
+}
+
+// JWT holds the keys used to verify bearer tokens: an HS256 shared secret and/or a JWKS file of RS256 public keys
+type JWT struct {
+	HS256Secret string `yaml:"hs256_secret"`
+	JWKSFile    string `yaml:"jwks_file"`
+	Issuer      string `yaml:"issuer"`
+	Audience    string `yaml:"audience"`
+}
+
+type APIKey struct {
+	Key      string `yaml:"key"`
+	ClientID string `yaml:"client_id"`
+}
+
+// ClientPolicy restricts a client to the listed GRPC methods (e.g. "/proto.Stock/GetStockSummary", or "/proto.Stock/*"
+// for every method of a service) and stock codes. An empty list allows everything but the methods of the StockAdmin
+// service, which have to be listed; unlisted clients are denied.
+type ClientPolicy struct {
+	ClientID          string   `yaml:"client_id"`
+	AllowedMethods    []string `yaml:"allowed_methods"`
+	AllowedStockCodes []string `yaml:"allowed_stock_codes"`
+}
+
+// RateLimit holds the limits of GRPC calls. Clients are identified by their authenticated identity, else by the
+// ClientKeyHeader metadata value, else by their peer address. Methods without an entry in Methods use Default.
+type RateLimit struct {
+	Enabled         bool          `yaml:"enabled"`
+	ClientKeyHeader string        `yaml:"client_key_header"`
+	Default         MethodLimit   `yaml:"default"`
+	Methods         []MethodLimit `yaml:"methods"`
+}
+
+// MethodLimit gives each client a token bucket refilled at RequestsPerSecond up to Burst tokens, and caps the calls
+// in flight across all clients at MaxInFlight. A call costs one token plus one per DaysPerToken days of its requested
+// date range. Zero values disable the respective limit.
+type MethodLimit struct {
+	Method            string  `yaml:"method"`
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+	MaxInFlight       int     `yaml:"max_in_flight"`
+	DaysPerToken      int     `yaml:"days_per_token"`
+}
+
+// Log holds the level (debug, info, warn or error) and format (json or text) of the service's logs
+type Log struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Tracing holds the OpenTelemetry trace exporter: "otlp" sends spans to an OTLP/gRPC collector at Endpoint,
+// "stdout" prints them to stderr for local debugging. SampleRatio is the fraction of new traces that are sampled.
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// Validation holds the rules transactions must satisfy before they are applied to stock summaries:
+//   - the price is positive and the quantity isn't negative
+//   - the price is a multiple of the tick size of its price range; not checked when TickSizes is empty. Tick sizes
+//     are given in whole units, and scaled like the prices of instruments with fractional prices unless the
+//     instrument has its own
+//   - the price is within the auto-rejection band around the previous price; not checked when PriceBands is empty or
+//     the previous price is unknown
+//   - the date is at most MaxPastDays before and MaxFutureDays after today; a zero MaxPastDays allows any past date,
+//     e.g. when replaying
+type Validation struct {
+	Enabled       bool        `yaml:"enabled"`
+	TickSizes     []TickSize  `yaml:"tick_sizes"`
+	PriceBands    []PriceBand `yaml:"price_bands"`
+	MaxPastDays   int         `yaml:"max_past_days"`
+	MaxFutureDays int         `yaml:"max_future_days"`
+}
+
+// TickSize is the price increment of prices from MinPrice (inclusive) up to the MinPrice of the next tick size
+type TickSize struct {
+	MinPrice int64 `yaml:"min_price"`
+	Tick     int64 `yaml:"tick"`
+}
+
+// PriceBand allows prices within Percent of previous prices from MinPrev (inclusive) up to the MinPrev of the next band
+type PriceBand struct {
+	MinPrev int64 `yaml:"min_prev"`
+	Percent int64 `yaml:"percent"`
+}
+
+// Lateness holds how far a trade may trail the latest trade of its stock summary by event time. Trades within Window
+// are applied in event-time order; later ones are rejected and published to DeadLetterTopic, or only logged when
+// DeadLetterTopic is empty. A zero Window accepts trades of any lateness.
+type Lateness struct {
+	Window          time.Duration `yaml:"window"`
+	DeadLetterTopic string        `yaml:"dead_letter_topic"`
+}
+
+// Instrument holds the scales of a stock's prices and quantities, the number of fractional digits they are given
+// with. Prices, volumes and values are stored as integers scaled by 10^PriceScale, 10^QuantityScale and
+// 10^(PriceScale+QuantityScale) respectively, so both zero keeps integer prices and quantities as they are.
+// Timezone is the IANA timezone of a cross-listed stock's exchange, when it isn't the trading schedule's.
+// TickSizes replace the validation's tick sizes for the instrument and are given in its scaled prices, e.g. a tick of
+// 25 at a PriceScale of 2 is 0.25.
+type Instrument struct {
+	StockCode     string     `yaml:"stock_code"`
+	PriceScale    int32      `yaml:"price_scale"`
+	QuantityScale int32      `yaml:"quantity_scale"`
+	Timezone      string     `yaml:"timezone"`
+	TickSizes     []TickSize `yaml:"tick_sizes"`
+}
+
+type Instruments []Instrument
+
+// Get returns the instrument of stockCode, with zero scales when it has none
+func (instruments Instruments) Get(stockCode string) Instrument {
+	for _, instrument := range instruments {
+		if instrument.StockCode == stockCode {
+			return instrument
+		}
+	}
+
+	return Instrument{StockCode: stockCode}
+}
+
+// Validate returns an error if an instrument's values can't be represented, i.e. it has a negative scale or a value
+// scale above MaxScale, or if its timezone is unknown
+func (instruments Instruments) Validate() error {
+	for _, instrument := range instruments {
+		if instrument.PriceScale < 0 || instrument.QuantityScale < 0 || instrument.PriceScale+instrument.QuantityScale > MaxScale {
+			return fmt.Errorf("instrument %s: price scale %d and quantity scale %d must be non-negative and add up to at most %d",
+				instrument.StockCode, instrument.PriceScale, instrument.QuantityScale, MaxScale)
+		}
+
+		if _, err := LoadLocation(instrument.Timezone); err != nil {
+			return fmt.Errorf("instrument %s: %w", instrument.StockCode, err)
+		}
+	}
+
+	return nil
+}
+
+// VWAP holds the precision of volume-weighted average prices, the number of fractional digits they have beyond their
+// stock's price scale
+type VWAP struct {
+	Precision int32 `yaml:"precision"`
+}
+
+// Journal enables the transaction journal, which stores every accepted transaction so that summaries can be explained
+// and rebuilt
+type Journal struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// Validate returns an error if Precision is negative or above MaxScale
+func (vwap VWAP) Validate() error {
+	if vwap.Precision < 0 || vwap.Precision > MaxScale {
+		return fmt.Errorf("VWAP precision %d must be between 0 and %d", vwap.Precision, MaxScale)
+	}
+
+	return nil
+}
+
+// Retention holds the policy for downsampling old daily stock summaries into monthly aggregates.
+// Daily summaries older than DailyDays are archived every Interval; monthly aggregates are kept forever.
+type Retention struct {
+	Enabled   bool          `yaml:"enabled"`
+	DailyDays int           `yaml:"daily_days"`
+	Interval  time.Duration `yaml:"interval"`
+	DryRun    bool          `yaml:"dry_run"`
+}
+
+// Cache holds the limits of the in-process stock summary cache; a zero Size disables the cache.
+// Results with more than MaxRows summaries (e.g. multi-year ranges) are not cached.
+// Entries are only invalidated by writes of the same process, see the README.
+type Cache struct {
+	Size    int `yaml:"size"`
+	MaxRows int `yaml:"max_rows"`
+}
+
+type Metrics struct {
+	Network string `yaml:"network"`
+	Port    string `yaml:"port"`
+}
+
+// TradingSchedule holds the exchange's trading session hours, in the exchange's IANA Timezone, e.g. "Asia/Jakarta"
+// (UTC when empty). Trading days start at midnight in the timezone of their stock.
+type TradingSchedule struct {
+	Timezone   string       `yaml:"timezone"`
+	PreOpening SessionHours `yaml:"pre_opening"`
+	SessionOne SessionHours `yaml:"session_one"`
+	SessionTwo SessionHours `yaml:"session_two"`
+	PreClosing SessionHours `yaml:"pre_closing"`
+}
+
+// Validate returns an error if Timezone is unknown
+func (schedule TradingSchedule) Validate() error {
+	_, err := LoadLocation(schedule.Timezone)
+	return err
+}
+
+// SessionHours holds a session's start (inclusive) and end (exclusive) time of day in the hh:mm:ss format
+type SessionHours struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
 }
 
 var (
@@ -36,17 +424,102 @@ var (
 			Network: "tcp",
 			Port:    ":50051",
 		},
+		HTTP: HTTP{
+			Network: "tcp",
+			Port:    ":8080",
+			CORS: CORS{
+				AllowedHeaders: []string{"Authorization", "Content-Type", "X-Api-Key", "X-Client-Id"},
+				MaxAge:         10 * time.Minute,
+			},
+		},
 		Kafka: KafkaConsumer{
-			Host:    "localhost",
-			Port:    ":9092",
-			GroupID: "stock_consumer_group",
-			Topic:   "stock",
+			Host:              "localhost",
+			Port:              ":9092",
+			GroupID:           "stock_consumer_group",
+			Topic:             "stock",
+			ClientID:          "stock",
+			RebalanceStrategy: KafkaRebalanceRange,
+			InitialOffset:     KafkaOffsetNewest,
+			Replay: Replay{
+				From: ReplayFromEarliest,
+			},
 		},
 		Redis: Redis{
+			Mode:     RedisModeStandalone,
 			Host:     "localhost",
 			Port:     ":6379",
 			Password: "",
 			DB:       0,
 		},
+		Schedule: TradingSchedule{
+			Timezone:   "UTC",
+			PreOpening: SessionHours{Start: "08:45:00", End: "09:00:00"},
+			SessionOne: SessionHours{Start: "09:00:00", End: "12:00:00"},
+			SessionTwo: SessionHours{Start: "13:30:00", End: "15:50:00"},
+			PreClosing: SessionHours{Start: "15:50:00", End: "16:01:00"},
+		},
+		Retention: Retention{
+			Enabled:   false,
+			DailyDays: 5 * 365,
+			Interval:  24 * time.Hour,
+			DryRun:    true,
+		},
+		Validation: Validation{
+			Enabled: true,
+			TickSizes: []TickSize{
+				{MinPrice: 0, Tick: 1},
+				{MinPrice: 200, Tick: 2},
+				{MinPrice: 500, Tick: 5},
+				{MinPrice: 2000, Tick: 10},
+				{MinPrice: 5000, Tick: 25},
+			},
+			PriceBands: []PriceBand{
+				{MinPrev: 0, Percent: 35},
+				{MinPrev: 200, Percent: 25},
+				{MinPrev: 5000, Percent: 20},
+			},
+			MaxPastDays:   0,
+			MaxFutureDays: 1,
+		},
+		Lateness: Lateness{
+			Window:          15 * time.Minute,
+			DeadLetterTopic: "stock_dead_letter",
+		},
+		VWAP: VWAP{
+			Precision: 4,
+		},
+		Journal: Journal{
+			Enabled: true,
+		},
+		Metrics: Metrics{
+			Network: "tcp",
+			Port:    ":9090",
+		},
+		Cache: Cache{
+			Size:    1000,
+			MaxRows: 31,
+		},
+		RateLimit: RateLimit{
+			Enabled:         false,
+			ClientKeyHeader: "x-client-id",
+			Default: MethodLimit{
+				RequestsPerSecond: 20,
+				Burst:             40,
+				MaxInFlight:       200,
+				DaysPerToken:      31,
+			},
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: Tracing{
+			Enabled:     false,
+			Exporter:    TracingExporterStdout,
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			ServiceName: "stock",
+			SampleRatio: 1,
+		},
 	}
 )
@@ -0,0 +1,140 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxScale is the largest number of fractional digits of a price or quantity, the number of digits an int64 fits
+const MaxScale = 18
+
+// ErrOverflow is returned when a value doesn't fit an int64
+var ErrOverflow = errors.New("integer overflow")
+
+// ParseDecimal parses a decimal string such as "8025" or "-1.25" into an integer scaled by 10^scale, e.g. "1.25" at
+// scale 4 is 12500. Trailing zeros beyond scale are allowed, other fractional digits are not.
+func ParseDecimal(s string, scale int32) (int64, error) {
+	if scale < 0 || scale > MaxScale {
+		return 0, fmt.Errorf("invalid scale %d", scale)
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	if hasFraction {
+		if fraction == "" || strings.TrimLeft(fraction, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid decimal %q", s)
+		}
+
+		if trimmed := strings.TrimRight(fraction, "0"); len(trimmed) > int(scale) {
+			return 0, fmt.Errorf("decimal %q has more than %d fractional digits", s, scale)
+		}
+	}
+
+	if len(fraction) > int(scale) {
+		fraction = fraction[:scale]
+	}
+	fraction += strings.Repeat("0", int(scale)-len(fraction))
+
+	// A sign without digits, e.g. "-.5", is completed so that ParseInt accepts it
+	if integer == "" || integer == "-" || integer == "+" {
+		if !hasFraction {
+			return 0, fmt.Errorf("invalid decimal %q", s)
+		}
+		integer += "0"
+	}
+
+	value, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("decimal %q: %w", s, ErrOverflow)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	return value, nil
+}
+
+// FormatDecimal formats value scaled by 10^scale as a decimal string, e.g. 12500 at scale 4 is "1.2500"
+func FormatDecimal(value int64, scale int32) string {
+	if scale <= 0 {
+		return strconv.FormatInt(value, 10)
+	}
+
+	digits := strconv.FormatUint(absInt64(value), 10)
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+
+	point := len(digits) - int(scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// addInt64 returns a + b, or ErrOverflow when it doesn't fit an int64
+func addInt64(a, b int64) (int64, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, ErrOverflow
+	}
+
+	return sum, nil
+}
+
+// mulInt64 returns a * b, or ErrOverflow when it doesn't fit an int64
+func mulInt64(a, b int64) (int64, error) {
+	negative := (a < 0) != (b < 0)
+	hi, lo := bits.Mul64(absInt64(a), absInt64(b))
+	if hi != 0 || (!negative && lo > math.MaxInt64) || (negative && lo > 1<<63) {
+		return 0, ErrOverflow
+	}
+
+	if negative {
+		return -int64(lo), nil
+	}
+	return int64(lo), nil
+}
+
+// divRound returns numerator * 10^precision / denominator rounded half away from zero, or ErrOverflow when it
+// doesn't fit an int64
+func divRound(numerator, denominator int64, precision int32) (int64, error) {
+	scaled := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	scaled.Mul(scaled, big.NewInt(numerator))
+
+	divisor := big.NewInt(denominator)
+	quotient, remainder := new(big.Int).QuoRem(scaled, divisor, new(big.Int))
+
+	// The quotient moves away from zero when the remainder is at least half the divisor
+	if remainder.Lsh(remainder, 1).CmpAbs(divisor) >= 0 {
+		if scaled.Sign() == divisor.Sign() {
+			quotient.Add(quotient, big.NewInt(1))
+		} else {
+			quotient.Sub(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return quotient.Int64(), nil
+}
+
+func absInt64(value int64) uint64 {
+	if value < 0 {
+		return uint64(-value)
+	}
+	return uint64(value)
+}
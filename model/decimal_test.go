@@ -0,0 +1,208 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func Test_ParseDecimal(t *testing.T) {
+	type args struct {
+		s     string
+		scale int32
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantResponse int64
+		wantErr      error
+	}{
+		{name: "success-integer", args: args{s: "8025", scale: 0}, wantResponse: 8025},
+		{name: "success-integer-trailing-zeros", args: args{s: "8025.00", scale: 0}, wantResponse: 8025},
+		{name: "success-negative", args: args{s: "-1", scale: 0}, wantResponse: -1},
+		{name: "success-scaled", args: args{s: "1.25", scale: 4}, wantResponse: 12500},
+		{name: "success-scaled-integer", args: args{s: "15234", scale: 2}, wantResponse: 1523400},
+		{name: "success-fraction-only", args: args{s: "-.5", scale: 1}, wantResponse: -5},
+		{name: "success-max", args: args{s: "9.223372036854775807", scale: 18}, wantResponse: math.MaxInt64},
+		{name: "success-min", args: args{s: "-9223372036854775808", scale: 0}, wantResponse: math.MinInt64},
+		{name: "error-too-many-fractional-digits", args: args{s: "1.255", scale: 2}, wantErr: errors.New("")},
+		{name: "error-overflow", args: args{s: "92233720368547758.08", scale: 2}, wantErr: ErrOverflow},
+		{name: "error-empty", args: args{s: "", scale: 2}, wantErr: errors.New("")},
+		{name: "error-empty-fraction", args: args{s: "1.", scale: 2}, wantErr: errors.New("")},
+		{name: "error-invalid-fraction", args: args{s: "1.-5", scale: 2}, wantErr: errors.New("")},
+		{name: "error-invalid-integer", args: args{s: "1e3", scale: 0}, wantErr: errors.New("")},
+		{name: "error-scale", args: args{s: "1", scale: MaxScale + 1}, wantErr: errors.New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := ParseDecimal(tt.args.s, tt.args.scale)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("ParseDecimal() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(tt.wantErr, ErrOverflow) && !errors.Is(err, ErrOverflow) {
+				t.Errorf("ParseDecimal() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("ParseDecimal() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_FormatDecimal(t *testing.T) {
+	type args struct {
+		value int64
+		scale int32
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantResponse string
+	}{
+		{name: "success-integer", args: args{value: 8025, scale: 0}, wantResponse: "8025"},
+		{name: "success-scaled", args: args{value: 12500, scale: 4}, wantResponse: "1.2500"},
+		{name: "success-fraction-only", args: args{value: -5, scale: 3}, wantResponse: "-0.005"},
+		{name: "success-zero", args: args{value: 0, scale: 2}, wantResponse: "0.00"},
+		{name: "success-min", args: args{value: math.MinInt64, scale: 18}, wantResponse: "-9.223372036854775808"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotResponse := FormatDecimal(tt.args.value, tt.args.scale); gotResponse != tt.wantResponse {
+				t.Errorf("FormatDecimal() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_mulInt64(t *testing.T) {
+	type args struct {
+		a int64
+		b int64
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantResponse int64
+		wantErr      bool
+	}{
+		{name: "success", args: args{a: 100, b: 8025}, wantResponse: 802500},
+		{name: "success-negative", args: args{a: -3, b: 4}, wantResponse: -12},
+		{name: "success-min", args: args{a: math.MinInt64 / 2, b: 2}, wantResponse: math.MinInt64},
+		{name: "error-overflow", args: args{a: math.MaxInt64/2 + 1, b: 2}, wantErr: true},
+		{name: "error-overflow-negative", args: args{a: math.MinInt64, b: -1}, wantErr: true},
+		{name: "error-overflow-high-bits", args: args{a: 1 << 40, b: 1 << 40}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := mulInt64(tt.args.a, tt.args.b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mulInt64() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("mulInt64() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Summary_CheckArithmetic(t *testing.T) {
+	summary := Summary{StockCode: "USDIDR", Volume: 1000, Value: 1523425000, PriceScale: 2, QuantityScale: 1}
+
+	type args struct {
+		transaction Transaction
+	}
+	tests := []struct {
+		name    string
+		summary Summary
+		args    args
+
+		wantReason RejectionReason
+	}{
+		{
+			name:    "success",
+			summary: summary,
+			args:    args{transaction: Transaction{Type: TransactionTypeE, Price: 1523450, Quantity: 5, PriceScale: 2, QuantityScale: 1}},
+		},
+		{
+			name:    "success-new-summary",
+			summary: Summary{},
+			args:    args{transaction: Transaction{Type: TransactionTypeE, Price: 1523450, Quantity: 5, PriceScale: 2, QuantityScale: 1}},
+		},
+		{
+			name:       "error-scale",
+			summary:    summary,
+			args:       args{transaction: Transaction{Type: TransactionTypeE, Price: 15234, Quantity: 5}},
+			wantReason: RejectionScale,
+		},
+		{
+			name:       "error-overflow-value",
+			summary:    summary,
+			args:       args{transaction: Transaction{Type: TransactionTypeE, Price: math.MaxInt64 / 2, Quantity: 3, PriceScale: 2, QuantityScale: 1}},
+			wantReason: RejectionOverflow,
+		},
+		{
+			name:       "error-overflow-total-value",
+			summary:    Summary{Value: math.MaxInt64 - 10, PriceScale: 2, QuantityScale: 1},
+			args:       args{transaction: Transaction{Type: TransactionTypeP, Price: 11, Quantity: 1, PriceScale: 2, QuantityScale: 1}},
+			wantReason: RejectionOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.summary.CheckArithmetic(tt.args.transaction)
+
+			var rejection *RejectionError
+			if errors.As(err, &rejection) != (tt.wantReason != "") || (rejection != nil && rejection.Reason != tt.wantReason) {
+				t.Errorf("Summary.CheckArithmetic() err = %v, wantReason %v", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_divRound(t *testing.T) {
+	type args struct {
+		numerator   int64
+		denominator int64
+		precision   int32
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantResponse int64
+		wantErr      bool
+	}{
+		{name: "success-exact", args: args{numerator: 805000, denominator: 100, precision: 2}, wantResponse: 805000},
+		{name: "success-round-down", args: args{numerator: 7210000, denominator: 900, precision: 4}, wantResponse: 80111111},
+		{name: "success-round-up", args: args{numerator: 4780000, denominator: 600, precision: 2}, wantResponse: 796667},
+		{name: "success-half-up", args: args{numerator: 3265000, denominator: 400, precision: 0}, wantResponse: 8163},
+		{name: "success-half-negative", args: args{numerator: -3265000, denominator: 400, precision: 0}, wantResponse: -8163},
+		{name: "success-large-value", args: args{numerator: math.MaxInt64, denominator: math.MaxInt64 / 100, precision: 4}, wantResponse: 1000000},
+		{name: "error-overflow", args: args{numerator: math.MaxInt64, denominator: 1, precision: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := divRound(tt.args.numerator, tt.args.denominator, tt.args.precision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("divRound() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("divRound() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@
 package model
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -19,29 +20,101 @@ const (
 )
 
 type Transaction struct {
-	Price     int64
-	Quantity  int64
-	StockCode string
-	Type      TransactionType
-	Date      time.Time // Only contains date; we assume Transactions come in chronological order
+	Price       int64           `json:"price"`    // Scaled by 10^PriceScale
+	Quantity    int64           `json:"quantity"` // Scaled by 10^QuantityScale
+	StockCode   string          `json:"stock_code"`
+	Type        TransactionType `json:"type"`
+	OrderNumber string          `json:"order_number"`
+	Date        time.Time       `json:"date"`      // Only contains date
+	Timestamp   time.Time       `json:"timestamp"` // Full event time; zero when unknown, in which case arrival order is assumed
+	Sequence    int64           `json:"sequence"`  // Orders transactions sharing the same Timestamp
+	Session     Session         `json:"session"`
+	Board       Board           `json:"board"` // Undefined when the event has no known order book
+	Side        Side            `json:"side"`  // Of the order that initiated the trade; undefined when unknown
+
+	PriceScale    int32 `json:"price_scale"`
+	QuantityScale int32 `json:"quantity_scale"`
+
+	// Source identifies the Kafka message the transaction was read from by its topic, partition and offset, e.g.
+	// "stock-0-42"; empty when unknown
+	Source string `json:"source,omitempty"`
+}
+
+// before reports whether transaction happened before the trade at the given Unix milliseconds and sequence.
+// It is false whenever either time is unknown, so callers fall back to arrival order.
+func (transaction Transaction) before(unixMilli, sequence int64) bool {
+	if transaction.Timestamp.IsZero() || unixMilli == 0 {
+		return false
+	}
+
+	if millis := transaction.Timestamp.UnixMilli(); millis != unixMilli {
+		return millis < unixMilli
+	}
+
+	return transaction.Sequence < sequence
+}
+
+// unixMilli returns the transaction's Timestamp in Unix milliseconds, or 0 when unknown
+func (transaction Transaction) unixMilli() int64 {
+	if transaction.Timestamp.IsZero() {
+		return 0
+	}
+
+	return transaction.Timestamp.UnixMilli()
 }
 
-// Summary represents a stock's OHLC and previous price data
+// Summary represents a stock's OHLC and previous price data, along with the OHLCV data of each trading session.
+// Prices are scaled by 10^PriceScale, volumes by 10^QuantityScale and values by 10^(PriceScale+QuantityScale),
+// as given by the stock's Instrument.
 type Summary struct {
-	StockCode string    `json:"stock_code"`
-	Date      time.Time `json:"date"`
-	Prev      int64     `json:"prev"`
-	Open      int64     `json:"open"`
-	High      int64     `json:"high"`
-	Low       int64     `json:"low"`
-	Close     int64     `json:"close"`
-	Volume    int64     `json:"volume"`
-	Value     int64     `json:"value"`
-	Average   int64     `json:"average"`
+	StockCode  string         `json:"stock_code"`
+	Date       time.Time      `json:"date"`
+	Prev       int64          `json:"prev"`
+	Open       int64          `json:"open"`
+	High       int64          `json:"high"`
+	Low        int64          `json:"low"`
+	Close      int64          `json:"close"`
+	Volume     int64          `json:"volume"`
+	Value      int64          `json:"value"`
+	Average    int64          `json:"average"`
+	Trades     int64          `json:"trades"`
+	PreOpening SessionSummary `json:"pre_opening"`
+	SessionOne SessionSummary `json:"session_one"`
+	SessionTwo SessionSummary `json:"session_two"`
+	PreClosing SessionSummary `json:"pre_closing"`
+
+	// Event time (Unix milliseconds, 0 when unknown) and sequence of the trades that set Open and Close
+	FirstTradeTime     int64 `json:"first_trade_time"`
+	FirstTradeSequence int64 `json:"first_trade_sequence"`
+	LastTradeTime      int64 `json:"last_trade_time"`
+	LastTradeSequence  int64 `json:"last_trade_sequence"`
+
+	PriceScale    int32 `json:"price_scale"`
+	QuantityScale int32 `json:"quantity_scale"`
+
+	// Volume-weighted average price scaled by 10^VWAPScale, unlike Average, which is rounded down to the price scale
+	VWAP      int64 `json:"vwap"`
+	VWAPScale int32 `json:"vwap_scale"`
+
+	// Turnover of each board and volume by aggressor side; trades with an unknown board or side only count in the
+	// totals
+	Regular    BoardSummary `json:"regular"`
+	Negotiated BoardSummary `json:"negotiated"`
+	Cash       BoardSummary `json:"cash"`
+	BuyVolume  int64        `json:"buy_volume"`
+	SellVolume int64        `json:"sell_volume"`
+
+	// Monthly is set on the monthly summaries archived by the retention policy, which are dated the first day of their
+	// month. It isn't stored, as it follows from the key a summary is read from.
+	Monthly bool `json:"-"`
 }
 
-// ApplyTransaction returns stockSummary with updated data based on given transaction
+// ApplyTransaction returns stockSummary with updated data based on given transaction, which must pass
+// CheckArithmetic first
 // Assumption: TypeA is only used to set Prev price when the Quantity is 0
+// Open and Close are taken from the pre-opening and pre-closing auction prices when those auctions have trades,
+// otherwise from the earliest and latest trade by event time so out-of-order arrivals don't shift them.
+// Session OHLC still follows arrival order.
 func (summary Summary) ApplyTransaction(transaction Transaction) (bool, Summary) {
 	var (
 		updatedSummary = summary
@@ -51,6 +124,8 @@ func (summary Summary) ApplyTransaction(transaction Transaction) (bool, Summary)
 	if updatedSummary == (Summary{}) {
 		updatedSummary.StockCode = transaction.StockCode
 		updatedSummary.Date = transaction.Date
+		updatedSummary.PriceScale = transaction.PriceScale
+		updatedSummary.QuantityScale = transaction.QuantityScale
 	}
 
 	switch transaction.Type {
@@ -61,28 +136,291 @@ func (summary Summary) ApplyTransaction(transaction Transaction) (bool, Summary)
 	case TransactionTypeE, TransactionTypeP:
 		updatedSummary.Value = summary.Value + (transaction.Quantity * transaction.Price)
 		updatedSummary.Volume = summary.Volume + transaction.Quantity
-		updatedSummary.Average = (updatedSummary.Value / updatedSummary.Volume)
+		if updatedSummary.Volume != 0 {
+			updatedSummary.Average = (updatedSummary.Value / updatedSummary.Volume)
+		}
+
+		// Session OHLCV
+		if sessionSummary := updatedSummary.GetSessionSummary(transaction.Session); sessionSummary != nil {
+			*sessionSummary = sessionSummary.apply(transaction.Price, transaction.Quantity)
+		}
+
+		// Board turnover and aggressor volume
+		if boardSummary := updatedSummary.GetBoardSummary(transaction.Board); boardSummary != nil {
+			*boardSummary = boardSummary.apply(transaction.Price, transaction.Quantity)
+		}
+
+		switch transaction.Side {
+		case SideBuy:
+			updatedSummary.BuyVolume += transaction.Quantity
+		case SideSell:
+			updatedSummary.SellVolume += transaction.Quantity
+		}
 		fallthrough
 	default:
-		// Open
-		if summary.Open == 0 && transaction.Quantity > 0 {
+		// Open; the pre-opening auction price takes precedence over the first regular trade
+		if transaction.Quantity > 0 && summary.opensWith(transaction) {
 			updatedSummary.Open = transaction.Price
+			updatedSummary.FirstTradeTime = transaction.unixMilli()
+			updatedSummary.FirstTradeSequence = transaction.Sequence
 		}
 
-		// High
-		if summary.High == 0 || summary.High < transaction.Price {
+		// High and Low; the first trade sets both, whatever its price
+		hasTrades := summary.hasTrades()
+		if !hasTrades || summary.High < transaction.Price {
 			updatedSummary.High = transaction.Price
 		}
 
-		// Low
-		if summary.Low == 0 || summary.Low > transaction.Price {
+		if !hasTrades || summary.Low > transaction.Price {
 			updatedSummary.Low = transaction.Price
 		}
+		updatedSummary.Trades = summary.Trades + 1
 
-		// Close
-		updatedSummary.Close = transaction.Price
+		// Close; the pre-closing auction price takes precedence over any trade after it
+		if summary.closesWith(transaction) {
+			updatedSummary.Close = transaction.Price
+			updatedSummary.LastTradeTime = transaction.unixMilli()
+			updatedSummary.LastTradeSequence = transaction.Sequence
+		}
 	}
 
 	isUpdated = summary != updatedSummary
 	return isUpdated, updatedSummary
 }
+
+// WithVWAP returns summary with its VWAP computed from Value and Volume with precision fractional digits beyond its
+// price scale, rounded half away from zero. VWAP and VWAPScale are zero without volume.
+func (summary Summary) WithVWAP(precision int32) (Summary, error) {
+	summary.VWAP, summary.VWAPScale = 0, 0
+	if summary.Volume == 0 {
+		return summary, nil
+	}
+
+	vwap, err := divRound(summary.Value, summary.Volume, precision)
+	if err != nil {
+		return Summary{}, fmt.Errorf("VWAP of value %d and volume %d: %w", summary.Value, summary.Volume, err)
+	}
+
+	summary.VWAP, summary.VWAPScale = vwap, summary.PriceScale+precision
+	return summary, nil
+}
+
+// hasTrades reports whether summary has trades. Summaries stored before trades were counted have a zero Trades,
+// so their volume and prices are checked too.
+func (summary Summary) hasTrades() bool {
+	return summary.Trades > 0 || summary.Volume > 0 || summary.High != 0 || summary.Low != 0
+}
+
+// opensWith reports whether transaction replaces summary's Open
+func (summary Summary) opensWith(transaction Transaction) bool {
+	if summary.Open == 0 {
+		return true
+	}
+
+	isPreOpening := transaction.Session == SessionPreOpening
+	if summary.PreOpening.Volume > 0 && !isPreOpening {
+		return false
+	}
+
+	// The first pre-opening trade replaces a regular trade regardless of time
+	if isPreOpening && summary.PreOpening.Volume == 0 {
+		return true
+	}
+
+	return transaction.before(summary.FirstTradeTime, summary.FirstTradeSequence)
+}
+
+// closesWith reports whether transaction replaces summary's Close
+func (summary Summary) closesWith(transaction Transaction) bool {
+	isPreClosing := transaction.Session == SessionPreClosing
+	if summary.PreClosing.Volume > 0 && !isPreClosing {
+		return false
+	}
+
+	// The first pre-closing trade replaces a regular trade regardless of time
+	if isPreClosing && summary.PreClosing.Volume == 0 {
+		return true
+	}
+
+	return summary.Close == 0 || !transaction.before(summary.LastTradeTime, summary.LastTradeSequence)
+}
+
+// GetSessionSummary returns a pointer to summary's OHLCV data of the given session, or nil for an undefined session
+func (summary *Summary) GetSessionSummary(session Session) *SessionSummary {
+	switch session {
+	case SessionPreOpening:
+		return &summary.PreOpening
+	case SessionOne:
+		return &summary.SessionOne
+	case SessionTwo:
+		return &summary.SessionTwo
+	case SessionPreClosing:
+		return &summary.PreClosing
+	default:
+		return nil
+	}
+}
+
+// GetBoardSummary returns a pointer to summary's turnover on the given board, or nil for an undefined board
+func (summary *Summary) GetBoardSummary(board Board) *BoardSummary {
+	switch board {
+	case BoardRegular:
+		return &summary.Regular
+	case BoardNegotiated:
+		return &summary.Negotiated
+	case BoardCash:
+		return &summary.Cash
+	default:
+		return nil
+	}
+}
+
+// AggregateSummaries downsamples chronologically ordered summaries into a single summary dated on date,
+// e.g. daily summaries of a month into a monthly summary. Summaries stored at different scales, e.g. before their
+// instrument's scales were raised, are rescaled to the finest of them first. It returns ErrOverflow when the
+// aggregated volumes or values don't fit an int64.
+func AggregateSummaries(date time.Time, summaries []Summary) (Summary, error) {
+	if len(summaries) == 0 {
+		return Summary{}, nil
+	}
+
+	var priceScale, quantityScale int32
+	for _, summary := range summaries {
+		priceScale = max(priceScale, summary.PriceScale)
+		quantityScale = max(quantityScale, summary.QuantityScale)
+	}
+
+	first, err := summaries[0].rescale(priceScale, quantityScale)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	aggregated := Summary{
+		StockCode:     first.StockCode,
+		Date:          date,
+		Prev:          first.Prev,
+		PriceScale:    priceScale,
+		QuantityScale: quantityScale,
+	}
+
+	var (
+		total         = SessionSummary{}
+		hasFirstTrade bool
+		vwapPrecision int32
+	)
+	for _, summary := range summaries {
+		summary, err = summary.rescale(priceScale, quantityScale)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		total, err = total.merge(SessionSummary{
+			Open:   summary.Open,
+			High:   summary.High,
+			Low:    summary.Low,
+			Close:  summary.Close,
+			Volume: summary.Volume,
+			Value:  summary.Value,
+			Trades: summary.Trades,
+		})
+		if err != nil {
+			return Summary{}, err
+		}
+
+		for _, session := range []Session{SessionPreOpening, SessionOne, SessionTwo, SessionPreClosing} {
+			aggregatedSession := aggregated.GetSessionSummary(session)
+			if *aggregatedSession, err = aggregatedSession.merge(*summary.GetSessionSummary(session)); err != nil {
+				return Summary{}, err
+			}
+		}
+
+		for _, board := range []Board{BoardRegular, BoardNegotiated, BoardCash} {
+			aggregatedBoard := aggregated.GetBoardSummary(board)
+			if *aggregatedBoard, err = aggregatedBoard.merge(*summary.GetBoardSummary(board)); err != nil {
+				return Summary{}, err
+			}
+		}
+		if aggregated.BuyVolume, err = addInt64(aggregated.BuyVolume, summary.BuyVolume); err != nil {
+			return Summary{}, err
+		}
+		if aggregated.SellVolume, err = addInt64(aggregated.SellVolume, summary.SellVolume); err != nil {
+			return Summary{}, err
+		}
+
+		if summary.Volume > 0 {
+			vwapPrecision = max(vwapPrecision, summary.VWAPScale-summary.PriceScale)
+			if !hasFirstTrade {
+				aggregated.FirstTradeTime, aggregated.FirstTradeSequence = summary.FirstTradeTime, summary.FirstTradeSequence
+				hasFirstTrade = true
+			}
+			aggregated.LastTradeTime, aggregated.LastTradeSequence = summary.LastTradeTime, summary.LastTradeSequence
+		}
+	}
+
+	aggregated.Open = total.Open
+	aggregated.High = total.High
+	aggregated.Low = total.Low
+	aggregated.Close = total.Close
+	aggregated.Volume = total.Volume
+	aggregated.Value = total.Value
+	aggregated.Trades = total.Trades
+	if aggregated.Volume > 0 {
+		aggregated.Average = aggregated.Value / aggregated.Volume
+	}
+
+	// At the finest precision of the summaries; left zero in the unlikely case it overflows
+	if withVWAP, err := aggregated.WithVWAP(vwapPrecision); err == nil {
+		aggregated = withVWAP
+	}
+
+	return aggregated, nil
+}
+
+// rescale returns summary with its prices, quantities and values scaled up to priceScale and quantityScale, which
+// can't be below its own scales, or ErrOverflow when one of them doesn't fit an int64
+func (summary Summary) rescale(priceScale, quantityScale int32) (Summary, error) {
+	priceDigits := priceScale - summary.PriceScale
+	quantityDigits := quantityScale - summary.QuantityScale
+	if priceDigits == 0 && quantityDigits == 0 {
+		return summary, nil
+	}
+
+	prices := []*int64{&summary.Prev, &summary.Open, &summary.High, &summary.Low, &summary.Close, &summary.Average, &summary.VWAP}
+	quantities := []*int64{&summary.Volume, &summary.BuyVolume, &summary.SellVolume}
+	values := []*int64{&summary.Value}
+	for _, session := range []Session{SessionPreOpening, SessionOne, SessionTwo, SessionPreClosing} {
+		sessionSummary := summary.GetSessionSummary(session)
+		prices = append(prices, &sessionSummary.Open, &sessionSummary.High, &sessionSummary.Low, &sessionSummary.Close)
+		quantities = append(quantities, &sessionSummary.Volume)
+		values = append(values, &sessionSummary.Value)
+	}
+	for _, board := range []Board{BoardRegular, BoardNegotiated, BoardCash} {
+		boardSummary := summary.GetBoardSummary(board)
+		quantities = append(quantities, &boardSummary.Volume)
+		values = append(values, &boardSummary.Value)
+	}
+
+	for _, fields := range []struct {
+		values []*int64
+		digits int32
+	}{
+		{prices, priceDigits},
+		{quantities, quantityDigits},
+		{values, priceDigits + quantityDigits},
+	} {
+		for _, value := range fields.values {
+			scaled, err := divRound(*value, 1, fields.digits)
+			if err != nil {
+				return Summary{}, err
+			}
+			*value = scaled
+		}
+	}
+
+	summary.PriceScale, summary.QuantityScale = priceScale, quantityScale
+	if summary.VWAPScale > 0 {
+		summary.VWAPScale += priceDigits
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,374 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// randomTransactions returns n transactions of a stock with distinct timestamps in a random order. Trades have a
+// positive quantity when positive is set, and may have a zero quantity otherwise.
+func randomTransactions(rng *rand.Rand, n int, positive bool) []Transaction {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	sessions := []Session{SessionUndefined, SessionPreOpening, SessionOne, SessionTwo, SessionPreClosing}
+	types := []TransactionType{TransactionTypeA, TransactionTypeE, TransactionTypeP}
+	boards := []Board{BoardUndefined, BoardRegular, BoardNegotiated, BoardCash}
+	sides := []Side{SideUndefined, SideBuy, SideSell}
+	offsets := rng.Perm(n)
+
+	transactions := make([]Transaction, n)
+	for i := range transactions {
+		quantity := rng.Int63n(10) * 100
+		if positive {
+			quantity += 100
+		}
+
+		transactions[i] = Transaction{
+			Price:     rng.Int63n(20000),
+			Quantity:  quantity,
+			StockCode: "BBCA",
+			Type:      types[rng.Intn(len(types))],
+			Date:      date,
+			Timestamp: date.Add(9*time.Hour + time.Duration(offsets[i])*time.Second),
+			Sequence:  int64(i),
+			Session:   sessions[rng.Intn(len(sessions))],
+			Board:     boards[rng.Intn(len(boards))],
+			Side:      sides[rng.Intn(len(sides))],
+		}
+	}
+
+	return transactions
+}
+
+func Test_Summary_ApplyTransaction_Invariants(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+
+		var (
+			summary        Summary
+			volume, value  int64
+			trades         int64
+			sessionVolumes = map[Session]int64{}
+			boardSummaries = map[Board]BoardSummary{}
+			sideVolumes    = map[Side]int64{}
+		)
+		for _, transaction := range randomTransactions(rng, 1+rng.Intn(200), false) {
+			previous := summary
+			_, summary = summary.ApplyTransaction(transaction)
+
+			if transaction.Type != TransactionTypeA {
+				volume += transaction.Quantity
+				value += transaction.Quantity * transaction.Price
+				trades++
+				sessionVolumes[transaction.Session] += transaction.Quantity
+				boardSummaries[transaction.Board] = boardSummaries[transaction.Board].apply(transaction.Price, transaction.Quantity)
+				sideVolumes[transaction.Side] += transaction.Quantity
+			}
+
+			if summary.Volume < previous.Volume {
+				t.Fatalf("seed %d: Volume decreased from %d to %d", seed, previous.Volume, summary.Volume)
+			}
+			if summary.Volume != volume || summary.Value != value || summary.Trades != trades {
+				t.Fatalf("seed %d: Volume, Value, Trades = %d, %d, %d, want %d, %d, %d",
+					seed, summary.Volume, summary.Value, summary.Trades, volume, value, trades)
+			}
+			if summary.Volume > 0 && summary.Average != summary.Value/summary.Volume {
+				t.Fatalf("seed %d: Average = %d, want %d", seed, summary.Average, summary.Value/summary.Volume)
+			}
+
+			if summary.Trades == 0 {
+				continue
+			}
+			if summary.Low > summary.High {
+				t.Fatalf("seed %d: Low %d > High %d", seed, summary.Low, summary.High)
+			}
+			for name, price := range map[string]int64{"Open": summary.Open, "Close": summary.Close} {
+				if price != 0 && (price < summary.Low || price > summary.High) {
+					t.Fatalf("seed %d: %s %d outside [%d, %d]", seed, name, price, summary.Low, summary.High)
+				}
+			}
+		}
+
+		for _, session := range []Session{SessionPreOpening, SessionOne, SessionTwo, SessionPreClosing} {
+			sessionSummary := summary.GetSessionSummary(session)
+			if sessionSummary.Volume != sessionVolumes[session] {
+				t.Errorf("seed %d: %s Volume = %d, want %d", seed, session, sessionSummary.Volume, sessionVolumes[session])
+			}
+			if sessionSummary.Trades > 0 && sessionSummary.Low > sessionSummary.High {
+				t.Errorf("seed %d: %s Low %d > High %d", seed, session, sessionSummary.Low, sessionSummary.High)
+			}
+		}
+
+		// Trades of an unknown board or side only count in the totals
+		for _, board := range []Board{BoardRegular, BoardNegotiated, BoardCash} {
+			if boardSummary := *summary.GetBoardSummary(board); boardSummary != boardSummaries[board] {
+				t.Errorf("seed %d: %s = %+v, want %+v", seed, board, boardSummary, boardSummaries[board])
+			}
+		}
+		if summary.BuyVolume != sideVolumes[SideBuy] || summary.SellVolume != sideVolumes[SideSell] {
+			t.Errorf("seed %d: BuyVolume, SellVolume = %d, %d, want %d, %d",
+				seed, summary.BuyVolume, summary.SellVolume, sideVolumes[SideBuy], sideVolumes[SideSell])
+		}
+	}
+}
+
+func Test_Summary_ApplyTransaction_OrderIndependence(t *testing.T) {
+	apply := func(transactions []Transaction) Summary {
+		var summary Summary
+		for _, transaction := range transactions {
+			_, summary = summary.ApplyTransaction(transaction)
+		}
+		return summary
+	}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+
+		// Prev follows arrival order, so only trades are shuffled
+		var trades []Transaction
+		for _, transaction := range randomTransactions(rng, 1+rng.Intn(200), true) {
+			if transaction.Type != TransactionTypeA {
+				trades = append(trades, transaction)
+			}
+		}
+
+		want := apply(trades)
+		rng.Shuffle(len(trades), func(i, j int) { trades[i], trades[j] = trades[j], trades[i] })
+		got := apply(trades)
+
+		// Session Open and Close follow arrival order
+		for _, session := range []Session{SessionPreOpening, SessionOne, SessionTwo, SessionPreClosing} {
+			gotSession, wantSession := got.GetSessionSummary(session), want.GetSessionSummary(session)
+			gotSession.Open, gotSession.Close = wantSession.Open, wantSession.Close
+		}
+
+		if got != want {
+			t.Errorf("seed %d: shuffled = %+v, want %+v", seed, got, want)
+		}
+	}
+}
+
+func Test_Summary_ApplyTransaction(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		transaction Transaction
+	}
+	tests := []struct {
+		name    string
+		summary Summary
+		args    args
+
+		wantUpdated  bool
+		wantResponse Summary
+	}{
+		{
+			name:    "success-zero-price-trade-sets-low",
+			summary: Summary{StockCode: "BBCA", Date: date, High: 8200, Low: 8000, Trades: 2},
+			args: args{transaction: Transaction{
+				Price: 0, Quantity: 0, StockCode: "BBCA", Type: TransactionTypeE, Date: date,
+			}},
+			wantUpdated:  true,
+			wantResponse: Summary{StockCode: "BBCA", Date: date, High: 8200, Low: 0, Trades: 3},
+		},
+		{
+			name:    "success-zero-price-first-trade",
+			summary: Summary{},
+			args: args{transaction: Transaction{
+				Price: 0, Quantity: 100, StockCode: "BBCA", Type: TransactionTypeE, Date: date,
+			}},
+			wantUpdated:  true,
+			wantResponse: Summary{StockCode: "BBCA", Date: date, Volume: 100, Trades: 1},
+		},
+		{
+			name:    "success-zero-price-first-trade-keeps-low",
+			summary: Summary{StockCode: "BBCA", Date: date, Volume: 100, Trades: 1},
+			args: args{transaction: Transaction{
+				Price: 8000, Quantity: 100, StockCode: "BBCA", Type: TransactionTypeE, Date: date,
+			}},
+			wantUpdated: true,
+			wantResponse: Summary{
+				StockCode: "BBCA", Date: date, Open: 8000, High: 8000, Low: 0, Close: 8000,
+				Volume: 200, Value: 800000, Average: 4000, Trades: 2,
+			},
+		},
+		{
+			name:    "success-zero-quantity-first-trade",
+			summary: Summary{},
+			args: args{transaction: Transaction{
+				Price: 8000, Quantity: 0, StockCode: "BBCA", Type: TransactionTypeE, Date: date,
+			}},
+			wantUpdated:  true,
+			wantResponse: Summary{StockCode: "BBCA", Date: date, High: 8000, Low: 8000, Close: 8000, Trades: 1},
+		},
+		{
+			name:    "success-legacy-summary-without-trades",
+			summary: Summary{StockCode: "BBCA", Date: date, Open: 8000, High: 8200, Low: 8000, Close: 8200, Volume: 200, Value: 1620000, Average: 8100},
+			args: args{transaction: Transaction{
+				Price: 8100, Quantity: 100, StockCode: "BBCA", Type: TransactionTypeE, Date: date,
+			}},
+			wantUpdated: true,
+			wantResponse: Summary{
+				StockCode: "BBCA", Date: date, Open: 8000, High: 8200, Low: 8000, Close: 8100,
+				Volume: 300, Value: 2430000, Average: 8100, Trades: 1,
+			},
+		},
+		{
+			name:    "success-board-and-side",
+			summary: Summary{StockCode: "BBCA", Date: date, Open: 8000, High: 8000, Low: 8000, Close: 8000, Volume: 100, Value: 800000, Average: 8000, Trades: 1},
+			args: args{transaction: Transaction{
+				Price: 8100, Quantity: 200, StockCode: "BBCA", Type: TransactionTypeP, Date: date, Board: BoardNegotiated, Side: SideSell,
+			}},
+			wantUpdated: true,
+			wantResponse: Summary{
+				StockCode: "BBCA", Date: date, Open: 8000, High: 8100, Low: 8000, Close: 8100,
+				Volume: 300, Value: 2420000, Average: 8066, Trades: 2,
+				Negotiated: BoardSummary{Volume: 200, Value: 1620000, Trades: 1}, SellVolume: 200,
+			},
+		},
+		{
+			name:    "success-prev",
+			summary: Summary{},
+			args: args{transaction: Transaction{
+				Price: 8000, Quantity: 0, StockCode: "BBCA", Type: TransactionTypeA, Date: date,
+			}},
+			wantUpdated:  true,
+			wantResponse: Summary{StockCode: "BBCA", Date: date, Prev: 8000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUpdated, gotResponse := tt.summary.ApplyTransaction(tt.args.transaction)
+			if gotUpdated != tt.wantUpdated {
+				t.Errorf("Summary.ApplyTransaction() gotUpdated = %v, wantUpdated %v", gotUpdated, tt.wantUpdated)
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("Summary.ApplyTransaction() gotResponse = %+v, wantResponse %+v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Summary_WithVWAP(t *testing.T) {
+	type args struct {
+		precision int32
+	}
+	tests := []struct {
+		name    string
+		summary Summary
+		args    args
+
+		wantResponse Summary
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			summary:      Summary{Volume: 900, Value: 7210000, Average: 8011},
+			args:         args{precision: 4},
+			wantResponse: Summary{Volume: 900, Value: 7210000, Average: 8011, VWAP: 80111111, VWAPScale: 4},
+		},
+		{
+			name:         "success-decimal",
+			summary:      Summary{Volume: 15, Value: 22851400, Average: 1523426, PriceScale: 2, QuantityScale: 1},
+			args:         args{precision: 4},
+			wantResponse: Summary{Volume: 15, Value: 22851400, Average: 1523426, PriceScale: 2, QuantityScale: 1, VWAP: 15234266667, VWAPScale: 6},
+		},
+		{
+			name:         "success-no-volume",
+			summary:      Summary{Prev: 8000, VWAP: 8000, VWAPScale: 4},
+			args:         args{precision: 4},
+			wantResponse: Summary{Prev: 8000},
+		},
+		{
+			name:    "error-overflow",
+			summary: Summary{Volume: 1, Value: 1 << 62},
+			args:    args{precision: 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := tt.summary.WithVWAP(tt.args.precision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Summary.WithVWAP() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("Summary.WithVWAP() gotResponse = %+v, wantResponse %+v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_AggregateSummaries(t *testing.T) {
+	date := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		summaries []Summary
+
+		wantResponse Summary
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			summaries: []Summary{
+				{StockCode: "BBCA", Prev: 8000, Open: 8050, High: 8100, Low: 8000, Close: 8100, Volume: 200, Value: 1615000, Trades: 2},
+				{StockCode: "BBCA", Prev: 8100, Open: 8100, High: 8200, Low: 8050, Close: 8150, Volume: 100, Value: 815000, Trades: 1},
+			},
+			wantResponse: Summary{
+				StockCode: "BBCA", Date: date, Prev: 8000, Open: 8050, High: 8200, Low: 8000, Close: 8150,
+				Volume: 300, Value: 2430000, Average: 8100, Trades: 3, VWAP: 8100,
+			},
+		},
+		{
+			name: "success-rescale",
+			summaries: []Summary{
+				{StockCode: "USDIDR", Open: 100, High: 100, Low: 100, Close: 100, Volume: 10, Value: 1000, Trades: 1,
+					VWAP: 1000000, VWAPScale: 4},
+				{StockCode: "USDIDR", Prev: 10000, Open: 10100, High: 10200, Low: 10000, Close: 10150, Volume: 10, Value: 101500, Trades: 1,
+					PriceScale: 2, VWAP: 10150, VWAPScale: 2},
+			},
+			wantResponse: Summary{
+				StockCode: "USDIDR", Date: date, Open: 10000, High: 10200, Low: 10000, Close: 10150,
+				Volume: 20, Value: 201500, Average: 10075, Trades: 2, PriceScale: 2, VWAP: 100750000, VWAPScale: 6,
+			},
+		},
+		{
+			name: "error-overflow",
+			summaries: []Summary{
+				{StockCode: "BBCA", Close: 1, Volume: 1 << 62, Value: 1 << 62, Trades: 1},
+				{StockCode: "BBCA", Close: 1, Volume: 1 << 62, Value: 1 << 62, Trades: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-rescale-overflow",
+			summaries: []Summary{
+				{StockCode: "USDIDR", Close: 1, Volume: 1, Value: 1 << 62, Trades: 1},
+				{StockCode: "USDIDR", Close: 100, Volume: 1, Value: 100, Trades: 1, PriceScale: 2},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := AggregateSummaries(date, tt.summaries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AggregateSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("AggregateSummaries() gotResponse = %+v, wantResponse %+v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
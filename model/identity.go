@@ -0,0 +1,35 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"context"
+)
+
+type AuthMethod string
+
+const (
+	AuthMethodJWT    AuthMethod = "jwt"
+	AuthMethodAPIKey AuthMethod = "api_key"
+)
+
+// Identity represents an authenticated caller of the GRPC server
+type Identity struct {
+	ClientID string
+	Method   AuthMethod
+}
+
+type identityContextKey struct{}
+
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the caller's identity, if the request was authenticated
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityContextKey{}).(Identity)
+	return identity, ok
+}
@@ -23,7 +23,10 @@ type KafkaTransaction struct {
 	ExecutionPrice   string `json:"execution_price,omitempty"`
 }
 
-func (i *KafkaTransaction) ToTransaction() (Transaction, error) {
+// ToTransaction converts the event into a Transaction, assigning its trading session based on the given schedule.
+// Price and quantity are decimals scaled by the scales of instrument, and the order number's time is the local time
+// of the instrument's timezone.
+func (i *KafkaTransaction) ToTransaction(schedule TradingSchedule, instrument Instrument) (Transaction, error) {
 	inputType := convertToType(i.Type)
 	if inputType == TransactionTypeUndefined {
 		return Transaction{}, fmt.Errorf("invalid transaction type %s", i.Type)
@@ -39,41 +42,59 @@ func (i *KafkaTransaction) ToTransaction() (Transaction, error) {
 		quantity = i.ExecutedQuantity
 	}
 
-	inputPrice, err := strconv.ParseInt(price, 10, 64)
+	inputPrice, err := ParseDecimal(price, instrument.PriceScale)
 	if err != nil {
 		return Transaction{}, err
 	}
 
 	inputQuantity := int64(0)
 	if quantity != "" {
-		inputQuantity, err = strconv.ParseInt(quantity, 10, 64)
+		inputQuantity, err = ParseDecimal(quantity, instrument.QuantityScale)
 		if err != nil {
 			return Transaction{}, err
 		}
 	}
 
 	// Assume that OrderNumber contains timestamp in the format of yyyyMMddHHmmss
-	timestamp, err := getDateFromOrderNumber(i.OrderNumber)
+	location := schedule.GetLocation(instrument)
+	timestamp, err := getDateFromOrderNumber(i.OrderNumber, location)
 	if err != nil {
 		return Transaction{}, err
 	}
 
+	session := SessionUndefined
+	orderTime, ok := getTimeFromOrderNumber(i.OrderNumber, location)
+	if ok {
+		session = schedule.GetSession(orderTime)
+	}
+
 	return Transaction{
-		Type:      inputType,
-		Price:     inputPrice,
-		Quantity:  inputQuantity,
-		StockCode: i.StockCode,
-		Date:      timestamp,
+		Type:        inputType,
+		Price:       inputPrice,
+		Quantity:    inputQuantity,
+		StockCode:   i.StockCode,
+		OrderNumber: i.OrderNumber,
+		Date:        timestamp,
+		Timestamp:   orderTime,
+		Sequence:    getSequenceFromOrderNumber(i.OrderNumber),
+		Session:     session,
+		Board:       convertToBoard(i.OrderBook),
+		Side:        convertToSide(i.OrderVerb),
+
+		PriceScale:    instrument.PriceScale,
+		QuantityScale: instrument.QuantityScale,
 	}, nil
 }
 
-func getDateFromOrderNumber(orderNumber string) (time.Time, error) {
+// getDateFromOrderNumber returns the start of the trading day in location of an order number that contains date in
+// the "yyyyMMdd" format
+func getDateFromOrderNumber(orderNumber string, location *time.Location) (time.Time, error) {
 	timeFormat := "20060102"
 	if len(orderNumber) > len(timeFormat) {
 		orderNumber = orderNumber[:len(timeFormat)]
 	}
 
-	timestamp, err := time.Parse(timeFormat, orderNumber) // assume order number contains date in the "yyyyMMdd" format
+	timestamp, err := time.ParseInLocation(timeFormat, orderNumber, location)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -81,6 +102,39 @@ func getDateFromOrderNumber(orderNumber string) (time.Time, error) {
 	return timestamp, nil
 }
 
+// getTimeFromOrderNumber returns the full timestamp of an order number that contains time in the "yyyyMMddHHmmss"
+// format, in location
+func getTimeFromOrderNumber(orderNumber string, location *time.Location) (time.Time, bool) {
+	timeFormat := "20060102150405"
+	if len(orderNumber) < len(timeFormat) {
+		return time.Time{}, false
+	}
+
+	timestamp, err := time.ParseInLocation(timeFormat, orderNumber[:len(timeFormat)], location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return timestamp, true
+}
+
+// getSequenceFromOrderNumber returns the digits following the "yyyyMMddHHmmss" timestamp of an order number,
+// which order trades sharing the same timestamp; 0 when the order number has none
+func getSequenceFromOrderNumber(orderNumber string) int64 {
+	timeFormat := "20060102150405"
+	if len(orderNumber) <= len(timeFormat) {
+		return 0
+	}
+
+	// Unsigned, so that only digits are accepted
+	sequence, err := strconv.ParseUint(orderNumber[len(timeFormat):], 10, 63)
+	if err != nil {
+		return 0
+	}
+
+	return int64(sequence)
+}
+
 func convertToType(t string) TransactionType {
 	switch t {
 	case "A":
@@ -99,3 +153,18 @@ type GetStockSummaryRequest struct {
 	FromDate  time.Time
 	ToDate    time.Time
 }
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV     ExportFormat = "csv"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// ExportStockSummariesRequest requests the stock summaries of StockCodes, or of every stock when empty
+type ExportStockSummariesRequest struct {
+	StockCodes []string
+	FromDate   time.Time
+	ToDate     time.Time
+	Format     ExportFormat
+}
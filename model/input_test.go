@@ -0,0 +1,122 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"strconv"
+	"testing"
+)
+
+func FuzzKafkaTransaction_ToTransaction(f *testing.F) {
+	f.Add("E", "202308290930000001", "", "", "8200", "100", "BBCA", uint8(0))
+	f.Add("P", "202308290855003390", "8025", "200", "", "", "BBCA", uint8(0))
+	f.Add("A", "20230829", "8000", "", "", "", "BBCA", uint8(0))
+	f.Add("A", "000101020000073390", "8200", "100", "", "", "BBCA", uint8(0))
+	f.Add("E", "2023082915505", "-1", "0", "", "", "", uint8(0))
+	f.Add("E", "20230829093000-1", "8200", "100", "", "", "BBCA", uint8(0))
+	f.Add("E", "202308290930000001", "15234.25", "1000.5", "", "", "USDIDR", uint8(2))
+	f.Add("X", "", "", "", "", "", "", uint8(0))
+
+	schedule := DefaultConfigLocal.Schedule
+	timezones := []string{"", "Asia/Jakarta", "America/New_York"}
+
+	f.Fuzz(func(t *testing.T, typ, orderNumber, price, quantity, executionPrice, executedQuantity, stockCode string, scale uint8) {
+		instrument := Instrument{
+			StockCode:     stockCode,
+			PriceScale:    int32(scale % (MaxScale + 1)),
+			QuantityScale: int32(scale % 3),
+			Timezone:      timezones[int(scale)%len(timezones)],
+		}
+		location := schedule.GetLocation(instrument)
+
+		input := &KafkaTransaction{
+			Type:             typ,
+			OrderNumber:      orderNumber,
+			Price:            price,
+			Quantity:         quantity,
+			ExecutionPrice:   executionPrice,
+			ExecutedQuantity: executedQuantity,
+			StockCode:        stockCode,
+		}
+
+		transaction, err := input.ToTransaction(schedule, instrument)
+		if err != nil {
+			return
+		}
+
+		if transaction.Type != TransactionType(typ) || transaction.Type == TransactionTypeUndefined {
+			t.Errorf("ToTransaction() Type = %q, input %q", transaction.Type, typ)
+		}
+
+		if price == "" {
+			price = executionPrice
+		}
+		if wantPrice, err := strconv.ParseInt(price, 10, 64); instrument.PriceScale == 0 && err == nil && transaction.Price != wantPrice {
+			t.Errorf("ToTransaction() Price = %d, input %q", transaction.Price, price)
+		}
+
+		formatted := FormatDecimal(transaction.Price, instrument.PriceScale)
+		if parsed, err := ParseDecimal(formatted, instrument.PriceScale); err != nil || parsed != transaction.Price {
+			t.Errorf("ParseDecimal(%q) = %d, %v, want %d", formatted, parsed, err, transaction.Price)
+		}
+
+		if transaction.PriceScale != instrument.PriceScale || transaction.QuantityScale != instrument.QuantityScale {
+			t.Errorf("ToTransaction() scales = %d, %d, want %d, %d", transaction.PriceScale, transaction.QuantityScale,
+				instrument.PriceScale, instrument.QuantityScale)
+		}
+
+		if transaction.Date != TradingDate(transaction.Date, location) {
+			t.Errorf("ToTransaction() Date = %v, want the start of a day in %v", transaction.Date, location)
+		}
+
+		if transaction.Sequence < 0 {
+			t.Errorf("ToTransaction() Sequence = %d, want non-negative", transaction.Sequence)
+		}
+
+		if transaction.Timestamp.IsZero() {
+			if transaction.Session != SessionUndefined {
+				t.Errorf("ToTransaction() Session = %q without a Timestamp", transaction.Session)
+			}
+			return
+		}
+
+		if date := TradingDate(transaction.Timestamp, location); !date.Equal(transaction.Date) {
+			t.Errorf("ToTransaction() Timestamp = %v is not on Date %v", transaction.Timestamp, transaction.Date)
+		}
+
+		if session := schedule.GetSession(transaction.Timestamp); transaction.Session != session {
+			t.Errorf("ToTransaction() Session = %q, want %q", transaction.Session, session)
+		}
+	})
+}
+
+func Fuzz_getDateFromOrderNumber(f *testing.F) {
+	f.Add("202308290930000001")
+	f.Add("20230829")
+	f.Add("2023082")
+	f.Add("20231329")
+	f.Add("")
+
+	location, err := LoadLocation("Asia/Jakarta")
+	if err != nil {
+		f.Fatalf("LoadLocation() err = %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, orderNumber string) {
+		date, err := getDateFromOrderNumber(orderNumber, location)
+		if err != nil {
+			return
+		}
+
+		if date != TradingDate(date, location) {
+			t.Errorf("getDateFromOrderNumber(%q) = %v, want a date without time", orderNumber, date)
+		}
+
+		if formatted := date.Format("20060102"); formatted != orderNumber[:len(formatted)] {
+			t.Errorf("getDateFromOrderNumber(%q) = %v, formatted %q", orderNumber, date, formatted)
+		}
+	})
+}
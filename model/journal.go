@@ -0,0 +1,36 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"errors"
+)
+
+var (
+	// ErrJournalDisabled is returned when transactions are requested while the journal is disabled
+	ErrJournalDisabled = errors.New("transaction journal is disabled")
+
+	// ErrJournalNotFound is returned when a stock has no journaled transactions on the requested date
+	ErrJournalNotFound = errors.New("no journaled transactions")
+)
+
+// JournalEntry is a transaction stored in the journal with the ID of its entry, e.g. "1693301400000-0".
+// Entries are ordered by ID, which is the order their transactions were applied in.
+type JournalEntry struct {
+	ID          string
+	Transaction Transaction
+}
+
+// RebuildSummary returns the summary of a day's journal by applying its transactions in order, as they were applied
+// when consumed. The VWAP still has to be computed with WithVWAP.
+func RebuildSummary(entries []JournalEntry) Summary {
+	summary := Summary{}
+	for _, entry := range entries {
+		_, summary = summary.ApplyTransaction(entry.Transaction)
+	}
+
+	return summary
+}
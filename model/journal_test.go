@@ -0,0 +1,44 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// Test_RebuildSummary checks that a summary rebuilt from a journal, whose transactions went through the journal's JSON
+// encoding, equals the summary the transactions were applied to one at a time
+func Test_RebuildSummary(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+
+		var (
+			summary Summary
+			entries []JournalEntry
+		)
+		for i, transaction := range randomTransactions(rng, 1+rng.Intn(200), false) {
+			_, summary = summary.ApplyTransaction(transaction)
+
+			encoded, err := json.Marshal(transaction)
+			if err != nil {
+				t.Fatalf("seed %d: json.Marshal() err = %v", seed, err)
+			}
+
+			var journaled Transaction
+			if err := json.Unmarshal(encoded, &journaled); err != nil {
+				t.Fatalf("seed %d: json.Unmarshal() err = %v", seed, err)
+			}
+			entries = append(entries, JournalEntry{ID: strconv.Itoa(i), Transaction: journaled})
+		}
+
+		if rebuilt := RebuildSummary(entries); rebuilt != summary {
+			t.Fatalf("seed %d: RebuildSummary() = %+v, want %+v", seed, rebuilt, summary)
+		}
+	}
+}
@@ -6,11 +6,28 @@
 package model
 
 import (
+	"context"
+	"errors"
+	"strconv"
+
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	tracer = otel.Tracer("stock/model")
+
+	// ErrRedeliver is wrapped by the errors of messages that have to be consumed again, e.g. late trades that
+	// couldn't be dead-lettered. Other errors are final, so their messages are marked as consumed.
+	ErrRedeliver = errors.New("message is to be consumed again")
 )
 
 type Consumer struct {
-	Handler func(message []byte) error
+	Handler func(ctx context.Context, message *sarama.ConsumerMessage) error
 }
 
 func (consumer *Consumer) Setup(sarama.ConsumerGroupSession) error {
@@ -23,15 +40,68 @@ func (consumer *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// ConsumeClaim marks each message of claim once it's handled. A message whose error wraps ErrRedeliver isn't marked:
+// the claim ends with the error, which ends the session, and the message is consumed again once the group rejoins.
 func (consumer *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
 		if message == nil {
 			return nil
 		}
 
-		_ = consumer.Handler(message.Value)
+		if err := consumer.handle(session.Context(), message); errors.Is(err, ErrRedeliver) {
+			return err
+		}
 
 		session.MarkMessage(message, "")
 	}
 	return nil
 }
+
+// handle processes a message in a span that continues the trace of the message's producer, if any
+func (consumer *Consumer) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, KafkaHeaderCarrier(message.Headers))
+
+	ctx, span := tracer.Start(ctx, message.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(message.Topic),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(message.Partition))),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+			attribute.String("messaging.kafka.message.key", string(message.Key)),
+		),
+	)
+	defer span.End()
+
+	err := consumer.Handler(ctx, message)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// KafkaHeaderCarrier adapts the headers of a Kafka message to carry trace context
+type KafkaHeaderCarrier []*sarama.RecordHeader
+
+func (carrier KafkaHeaderCarrier) Get(key string) string {
+	for _, header := range carrier {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set is not supported, as consumed messages are read-only
+func (carrier KafkaHeaderCarrier) Set(string, string) {}
+
+func (carrier KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for _, header := range carrier {
+		if header != nil {
+			keys = append(keys, string(header.Key))
+		}
+	}
+	return keys
+}
@@ -0,0 +1,26 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"time"
+)
+
+// ReplayPlan describes where a replay moves the consumer group's committed offsets to, and the stock summaries it
+// clears so that they are recomputed from the replayed transactions
+type ReplayPlan struct {
+	Offsets       map[string]map[int32]int64 // Offset of the first replayed message of each partition of each topic
+	ClearFromDate time.Time                  // Summaries dated ClearFromDate or later are cleared; a zero date clears every summary
+	DryRun        bool
+}
+
+// ClearReport describes the stock summaries and transaction journals cleared before a replay
+type ClearReport struct {
+	FromDate   time.Time
+	Rows       int64
+	StockCodes []string
+	Journals   int64 // Journals of a stock's day
+}
@@ -0,0 +1,43 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"time"
+)
+
+// Periods of stock summaries: days, and the months of the summaries archived by the retention policy
+const (
+	PeriodDay   = "day"
+	PeriodMonth = "month"
+)
+
+// Period returns the period summary covers, PeriodDay or PeriodMonth
+func (summary Summary) Period() string {
+	if summary.Monthly {
+		return PeriodMonth
+	}
+
+	return PeriodDay
+}
+
+// RetentionReport describes the daily stock summaries archived by a compaction run, or that would be archived on a dry run
+type RetentionReport struct {
+	Cutoff      time.Time // Daily summaries dated before Cutoff are archived
+	DryRun      bool
+	DailyRows   int
+	MonthlyRows int
+	Journals    int64 // Transaction journals dated before Cutoff that were removed
+	Stocks      []StockRetentionReport
+}
+
+type StockRetentionReport struct {
+	StockCode   string
+	FromDate    time.Time
+	ToDate      time.Time
+	DailyRows   int
+	MonthlyRows int
+}
@@ -0,0 +1,136 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"time"
+)
+
+const (
+	sessionClockFmt = "15:04:05"
+)
+
+type Session string
+
+const (
+	SessionPreOpening Session = "pre_opening"
+	SessionOne        Session = "session_one"
+	SessionTwo        Session = "session_two"
+	SessionPreClosing Session = "pre_closing"
+	SessionUndefined  Session = ""
+)
+
+// SessionSummary represents a stock's OHLCV data within a single trading session
+type SessionSummary struct {
+	Open   int64 `json:"open"`
+	High   int64 `json:"high"`
+	Low    int64 `json:"low"`
+	Close  int64 `json:"close"`
+	Volume int64 `json:"volume"`
+	Value  int64 `json:"value"`
+	Trades int64 `json:"trades"`
+}
+
+// GetSession returns the trading session the given timestamp falls into based on its time of day.
+// Timestamps outside of every configured session (e.g. lunch break, post-trading) return SessionUndefined.
+func (schedule TradingSchedule) GetSession(timestamp time.Time) Session {
+	clock := timestamp.Format(sessionClockFmt)
+
+	for _, session := range []struct {
+		name  Session
+		hours SessionHours
+	}{
+		{name: SessionPreOpening, hours: schedule.PreOpening},
+		{name: SessionOne, hours: schedule.SessionOne},
+		{name: SessionTwo, hours: schedule.SessionTwo},
+		{name: SessionPreClosing, hours: schedule.PreClosing},
+	} {
+		if session.hours.contains(clock) {
+			return session.name
+		}
+	}
+
+	return SessionUndefined
+}
+
+// contains checks whether clock (formatted as hh:mm:ss) is within [Start, End).
+// Zero-padded clock strings sort chronologically, so they can be compared directly.
+func (hours SessionHours) contains(clock string) bool {
+	if hours.Start == "" || hours.End == "" {
+		return false
+	}
+
+	return hours.Start <= clock && clock < hours.End
+}
+
+// hasTrades reports whether sessionSummary has trades. Summaries stored before trades were counted have a zero
+// Trades, so their volume and prices are checked too.
+func (sessionSummary SessionSummary) hasTrades() bool {
+	return sessionSummary.Trades > 0 || sessionSummary.Volume > 0 || sessionSummary.High != 0 || sessionSummary.Low != 0
+}
+
+// apply returns sessionSummary with updated OHLCV data based on the given trade
+func (sessionSummary SessionSummary) apply(price, quantity int64) SessionSummary {
+	if sessionSummary.Open == 0 && quantity > 0 {
+		sessionSummary.Open = price
+	}
+
+	// The first trade sets High and Low, whatever its price
+	hasTrades := sessionSummary.hasTrades()
+	if !hasTrades || sessionSummary.High < price {
+		sessionSummary.High = price
+	}
+
+	if !hasTrades || sessionSummary.Low > price {
+		sessionSummary.Low = price
+	}
+
+	sessionSummary.Close = price
+	sessionSummary.Volume += quantity
+	sessionSummary.Value += quantity * price
+	sessionSummary.Trades++
+
+	return sessionSummary
+}
+
+// merge returns sessionSummary combined with the OHLCV data of a later period at the same scales; periods without
+// trades have no data. It returns ErrOverflow when the sums don't fit an int64.
+func (sessionSummary SessionSummary) merge(later SessionSummary) (SessionSummary, error) {
+	if !later.hasTrades() {
+		return sessionSummary, nil
+	}
+
+	if !sessionSummary.hasTrades() {
+		return later, nil
+	}
+
+	if sessionSummary.Open == 0 {
+		sessionSummary.Open = later.Open
+	}
+
+	if sessionSummary.High < later.High {
+		sessionSummary.High = later.High
+	}
+
+	if sessionSummary.Low > later.Low {
+		sessionSummary.Low = later.Low
+	}
+
+	sessionSummary.Close = later.Close
+
+	var err error
+	if sessionSummary.Volume, err = addInt64(sessionSummary.Volume, later.Volume); err != nil {
+		return SessionSummary{}, err
+	}
+	if sessionSummary.Value, err = addInt64(sessionSummary.Value, later.Value); err != nil {
+		return SessionSummary{}, err
+	}
+	if sessionSummary.Trades, err = addInt64(sessionSummary.Trades, later.Trades); err != nil {
+		return SessionSummary{}, err
+	}
+
+	return sessionSummary, nil
+}
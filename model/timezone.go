@@ -0,0 +1,58 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// locations caches loaded timezones by name, as time.LoadLocation reads the timezone database on every call
+	locations sync.Map
+)
+
+// LoadLocation returns the timezone of an IANA name such as "Asia/Jakarta", UTC when name is empty
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+
+	locations.Store(name, location)
+	return location, nil
+}
+
+// GetLocation returns the timezone of instrument's trading days: its own, else the exchange's, else UTC.
+// Timezones are validated on startup, so an invalid one falls back to UTC.
+func (schedule TradingSchedule) GetLocation(instrument Instrument) *time.Location {
+	name := instrument.Timezone
+	if name == "" {
+		name = schedule.Timezone
+	}
+
+	location, err := LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// TradingDate returns the start of the trading day in location of date's year, month and day, as seen in date's own
+// location. Stock summaries are dated, and scored, at the start of their trading day.
+func TradingDate(date time.Time, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
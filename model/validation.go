@@ -0,0 +1,237 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RejectionReason identifies the validation rule a rejected transaction breaks
+type RejectionReason string
+
+const (
+	RejectionNonPositivePrice  RejectionReason = "non_positive_price"
+	RejectionNegativeQuantity  RejectionReason = "negative_quantity"
+	RejectionTickSize          RejectionReason = "tick_size"
+	RejectionPriceBand         RejectionReason = "price_band"
+	RejectionDateOutsideWindow RejectionReason = "date_outside_window"
+	RejectionLate              RejectionReason = "late"
+	RejectionScale             RejectionReason = "scale"
+	RejectionOverflow          RejectionReason = "overflow"
+)
+
+// RejectionError is returned for a transaction that breaks a validation rule
+type RejectionError struct {
+	Reason  RejectionReason
+	Message string
+}
+
+func (err *RejectionError) Error() string {
+	return fmt.Sprintf("transaction rejected (%s): %s", err.Reason, err.Message)
+}
+
+func newRejectionError(reason RejectionReason, format string, args ...interface{}) *RejectionError {
+	return &RejectionError{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ForInstrument returns the rules of instrument's transactions, whose prices are scaled by 10^PriceScale. The
+// instrument's own tick sizes, given in its scaled prices, replace TickSizes, which are given in whole units and are
+// scaled like its prices; tick sizes that overflow once scaled never apply.
+func (validation Validation) ForInstrument(instrument Instrument) Validation {
+	if len(instrument.TickSizes) > 0 {
+		validation.TickSizes = instrument.TickSizes
+		return validation
+	}
+
+	if instrument.PriceScale == 0 {
+		return validation
+	}
+
+	tickSizes := make([]TickSize, 0, len(validation.TickSizes))
+	for _, tickSize := range validation.TickSizes {
+		minPrice, err := divRound(tickSize.MinPrice, 1, instrument.PriceScale)
+		if err != nil {
+			continue
+		}
+
+		tick, err := divRound(tickSize.Tick, 1, instrument.PriceScale)
+		if err != nil {
+			continue
+		}
+
+		tickSizes = append(tickSizes, TickSize{MinPrice: minPrice, Tick: tick})
+	}
+
+	validation.TickSizes = tickSizes
+	return validation
+}
+
+// Validate returns a RejectionError if transaction breaks one of the rules, prev being the stock's previous price
+// (zero when unknown) and today the current date
+func (validation Validation) Validate(transaction Transaction, prev int64, today time.Time) error {
+	if !validation.Enabled {
+		return nil
+	}
+
+	if transaction.Price <= 0 {
+		return newRejectionError(RejectionNonPositivePrice, "price %d is not positive", transaction.Price)
+	}
+
+	if transaction.Quantity < 0 {
+		return newRejectionError(RejectionNegativeQuantity, "quantity %d is negative", transaction.Quantity)
+	}
+
+	if err := validation.validateDate(transaction.Date, today); err != nil {
+		return err
+	}
+
+	// The previous price set by a type A transaction is the reference of the bands, rather than a trade
+	if transaction.Type == TransactionTypeA && transaction.Quantity == 0 {
+		return nil
+	}
+
+	if tick := validation.getTickSize(transaction.Price); tick > 0 && transaction.Price%tick != 0 {
+		return newRejectionError(RejectionTickSize, "price %s is not a multiple of tick size %s",
+			FormatDecimal(transaction.Price, transaction.PriceScale), FormatDecimal(tick, transaction.PriceScale))
+	}
+
+	if percent, ok := validation.getPriceBand(prev); ok {
+		lower, upper := getPriceBandBounds(prev, percent)
+		if transaction.Price < lower || transaction.Price > upper {
+			return newRejectionError(RejectionPriceBand, "price %d is outside of [%d, %d], %d%% around previous price %d",
+				transaction.Price, lower, upper, percent, prev)
+		}
+	}
+
+	return nil
+}
+
+// validateDate checks date, the start of a trading day, against today's trading day in the same timezone
+func (validation Validation) validateDate(date, today time.Time) error {
+	today = TradingDate(today.In(date.Location()), date.Location())
+
+	if latest := today.AddDate(0, 0, validation.MaxFutureDays); date.After(latest) {
+		return newRejectionError(RejectionDateOutsideWindow, "date %s is after %s", date.Format(time.DateOnly), latest.Format(time.DateOnly))
+	}
+
+	if validation.MaxPastDays > 0 {
+		if earliest := today.AddDate(0, 0, -validation.MaxPastDays); date.Before(earliest) {
+			return newRejectionError(RejectionDateOutsideWindow, "date %s is before %s", date.Format(time.DateOnly), earliest.Format(time.DateOnly))
+		}
+	}
+
+	return nil
+}
+
+// CheckLateness returns a RejectionError if the trade happened more than window before the latest trade of summary.
+// Trades or summaries without event time, and non-trades, are never late.
+func (summary Summary) CheckLateness(transaction Transaction, window time.Duration) error {
+	if window <= 0 || transaction.Timestamp.IsZero() || summary.LastTradeTime == 0 {
+		return nil
+	}
+
+	if transaction.Type != TransactionTypeE && transaction.Type != TransactionTypeP {
+		return nil
+	}
+
+	lastTrade := time.UnixMilli(summary.LastTradeTime).In(transaction.Timestamp.Location())
+	if lateness := lastTrade.Sub(transaction.Timestamp); lateness > window {
+		return newRejectionError(RejectionLate, "trade at %s is %s behind the last trade at %s", transaction.Timestamp.Format(time.RFC3339),
+			lateness, lastTrade.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// CheckArithmetic returns a RejectionError if transaction is scaled differently from summary, e.g. after its
+// instrument's scales were changed, or if applying it would overflow summary's volume or value
+func (summary Summary) CheckArithmetic(transaction Transaction) error {
+	if summary != (Summary{}) && (summary.PriceScale != transaction.PriceScale || summary.QuantityScale != transaction.QuantityScale) {
+		return newRejectionError(RejectionScale, "price and quantity scales %d and %d differ from the summary's %d and %d",
+			transaction.PriceScale, transaction.QuantityScale, summary.PriceScale, summary.QuantityScale)
+	}
+
+	if transaction.Type != TransactionTypeE && transaction.Type != TransactionTypeP {
+		return nil
+	}
+
+	value, err := mulInt64(transaction.Quantity, transaction.Price)
+	if err == nil {
+		_, err = addInt64(summary.Value, value)
+	}
+	if err == nil {
+		_, err = addInt64(summary.Volume, transaction.Quantity)
+	}
+	if err != nil {
+		return newRejectionError(RejectionOverflow, "quantity %d at price %d overflows volume %d or value %d",
+			transaction.Quantity, transaction.Price, summary.Volume, summary.Value)
+	}
+
+	return nil
+}
+
+// getTickSize returns the tick size of price, or zero when no tick size applies
+func (validation Validation) getTickSize(price int64) int64 {
+	var (
+		tick     int64
+		minPrice int64 = -1
+	)
+	for _, tickSize := range validation.TickSizes {
+		if price >= tickSize.MinPrice && tickSize.MinPrice > minPrice {
+			tick, minPrice = tickSize.Tick, tickSize.MinPrice
+		}
+	}
+	return tick
+}
+
+// getPriceBandBounds returns the bounds of the band of percent around prev, rounded towards prev so that the band never
+// exceeds percent. Bounds that don't fit an int64 are clamped, as no price is beyond them.
+func getPriceBandBounds(prev, percent int64) (lower, upper int64) {
+	// prev * percent / 100, without computing prev * percent, which overflows for large scaled prices
+	band, err := mulInt64(prev/100, percent)
+	if err == nil {
+		var fraction int64
+		fraction, err = mulInt64(prev%100, percent)
+		if err == nil {
+			band, err = addInt64(band, fraction/100)
+		}
+	}
+	if err != nil {
+		return math.MinInt64, math.MaxInt64
+	}
+
+	if lower, err = addInt64(prev, -band); err != nil {
+		lower = math.MinInt64
+	}
+	if upper, err = addInt64(prev, band); err != nil {
+		upper = math.MaxInt64
+	}
+
+	return lower, upper
+}
+
+// getPriceBand returns the percentage of the price band around prev, if prices must be within one
+func (validation Validation) getPriceBand(prev int64) (int64, bool) {
+	if prev <= 0 {
+		return 0, false
+	}
+
+	var (
+		percent int64
+		minPrev int64 = -1
+	)
+	for _, priceBand := range validation.PriceBands {
+		if prev >= priceBand.MinPrev && priceBand.MinPrev > minPrev {
+			percent, minPrev = priceBand.Percent, priceBand.MinPrev
+		}
+	}
+	return percent, minPrev >= 0
+}
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Stock_GetStockSummary_FullMethodName = "/proto.Stock/GetStockSummary"
+	Stock_GetStockSummary_FullMethodName      = "/proto.Stock/GetStockSummary"
+	Stock_ExportStockSummaries_FullMethodName = "/proto.Stock/ExportStockSummaries"
+	Stock_GetTransactions_FullMethodName      = "/proto.Stock/GetTransactions"
 )
 
 // StockClient is the client API for Stock service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StockClient interface {
 	GetStockSummary(ctx context.Context, in *GetStockSummaryRequest, opts ...grpc.CallOption) (*GetStockSummaryResponse, error)
+	ExportStockSummaries(ctx context.Context, in *ExportStockSummariesRequest, opts ...grpc.CallOption) (Stock_ExportStockSummariesClient, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
 }
 
 type stockClient struct {
@@ -47,11 +51,56 @@ func (c *stockClient) GetStockSummary(ctx context.Context, in *GetStockSummaryRe
 	return out, nil
 }
 
+func (c *stockClient) ExportStockSummaries(ctx context.Context, in *ExportStockSummariesRequest, opts ...grpc.CallOption) (Stock_ExportStockSummariesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Stock_ServiceDesc.Streams[0], Stock_ExportStockSummaries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stockExportStockSummariesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Stock_ExportStockSummariesClient interface {
+	Recv() (*ExportStockSummariesResponse, error)
+	grpc.ClientStream
+}
+
+type stockExportStockSummariesClient struct {
+	grpc.ClientStream
+}
+
+func (x *stockExportStockSummariesClient) Recv() (*ExportStockSummariesResponse, error) {
+	m := new(ExportStockSummariesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stockClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, Stock_GetTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServer is the server API for Stock service.
 // All implementations must embed UnimplementedStockServer
 // for forward compatibility
 type StockServer interface {
 	GetStockSummary(context.Context, *GetStockSummaryRequest) (*GetStockSummaryResponse, error)
+	ExportStockSummaries(*ExportStockSummariesRequest, Stock_ExportStockSummariesServer) error
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
 	mustEmbedUnimplementedStockServer()
 }
 
@@ -62,6 +111,12 @@ type UnimplementedStockServer struct {
 func (UnimplementedStockServer) GetStockSummary(context.Context, *GetStockSummaryRequest) (*GetStockSummaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStockSummary not implemented")
 }
+func (UnimplementedStockServer) ExportStockSummaries(*ExportStockSummariesRequest, Stock_ExportStockSummariesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStockSummaries not implemented")
+}
+func (UnimplementedStockServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
 func (UnimplementedStockServer) mustEmbedUnimplementedStockServer() {}
 
 // UnsafeStockServer may be embedded to opt out of forward compatibility for this service.
@@ -93,6 +148,45 @@ func _Stock_GetStockSummary_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stock_ExportStockSummaries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStockSummariesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServer).ExportStockSummaries(m, &stockExportStockSummariesServer{ServerStream: stream})
+}
+
+type Stock_ExportStockSummariesServer interface {
+	Send(*ExportStockSummariesResponse) error
+	grpc.ServerStream
+}
+
+type stockExportStockSummariesServer struct {
+	grpc.ServerStream
+}
+
+func (x *stockExportStockSummariesServer) Send(m *ExportStockSummariesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Stock_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_GetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Stock_ServiceDesc is the grpc.ServiceDesc for Stock service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +198,189 @@ var Stock_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStockSummary",
 			Handler:    _Stock_GetStockSummary_Handler,
 		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Stock_GetTransactions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStockSummaries",
+			Handler:       _Stock_ExportStockSummaries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stock.proto",
+}
+
+const (
+	StockAdmin_OverrideStockSummary_FullMethodName  = "/proto.StockAdmin/OverrideStockSummary"
+	StockAdmin_DeleteStockSummary_FullMethodName    = "/proto.StockAdmin/DeleteStockSummary"
+	StockAdmin_RecomputeStockSummary_FullMethodName = "/proto.StockAdmin/RecomputeStockSummary"
+)
+
+// StockAdminClient is the client API for StockAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockAdmin corrects stored stock summaries. It is only served when auth is enabled, to clients whose policy lists
+// its methods, and every change is audit-logged with the caller and the given reason.
+type StockAdminClient interface {
+	OverrideStockSummary(ctx context.Context, in *OverrideStockSummaryRequest, opts ...grpc.CallOption) (*OverrideStockSummaryResponse, error)
+	DeleteStockSummary(ctx context.Context, in *DeleteStockSummaryRequest, opts ...grpc.CallOption) (*DeleteStockSummaryResponse, error)
+	RecomputeStockSummary(ctx context.Context, in *RecomputeStockSummaryRequest, opts ...grpc.CallOption) (*RecomputeStockSummaryResponse, error)
+}
+
+type stockAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockAdminClient(cc grpc.ClientConnInterface) StockAdminClient {
+	return &stockAdminClient{cc}
+}
+
+func (c *stockAdminClient) OverrideStockSummary(ctx context.Context, in *OverrideStockSummaryRequest, opts ...grpc.CallOption) (*OverrideStockSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OverrideStockSummaryResponse)
+	err := c.cc.Invoke(ctx, StockAdmin_OverrideStockSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAdminClient) DeleteStockSummary(ctx context.Context, in *DeleteStockSummaryRequest, opts ...grpc.CallOption) (*DeleteStockSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStockSummaryResponse)
+	err := c.cc.Invoke(ctx, StockAdmin_DeleteStockSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockAdminClient) RecomputeStockSummary(ctx context.Context, in *RecomputeStockSummaryRequest, opts ...grpc.CallOption) (*RecomputeStockSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecomputeStockSummaryResponse)
+	err := c.cc.Invoke(ctx, StockAdmin_RecomputeStockSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockAdminServer is the server API for StockAdmin service.
+// All implementations must embed UnimplementedStockAdminServer
+// for forward compatibility
+//
+// StockAdmin corrects stored stock summaries. It is only served when auth is enabled, to clients whose policy lists
+// its methods, and every change is audit-logged with the caller and the given reason.
+type StockAdminServer interface {
+	OverrideStockSummary(context.Context, *OverrideStockSummaryRequest) (*OverrideStockSummaryResponse, error)
+	DeleteStockSummary(context.Context, *DeleteStockSummaryRequest) (*DeleteStockSummaryResponse, error)
+	RecomputeStockSummary(context.Context, *RecomputeStockSummaryRequest) (*RecomputeStockSummaryResponse, error)
+	mustEmbedUnimplementedStockAdminServer()
+}
+
+// UnimplementedStockAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedStockAdminServer struct {
+}
+
+func (UnimplementedStockAdminServer) OverrideStockSummary(context.Context, *OverrideStockSummaryRequest) (*OverrideStockSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OverrideStockSummary not implemented")
+}
+func (UnimplementedStockAdminServer) DeleteStockSummary(context.Context, *DeleteStockSummaryRequest) (*DeleteStockSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStockSummary not implemented")
+}
+func (UnimplementedStockAdminServer) RecomputeStockSummary(context.Context, *RecomputeStockSummaryRequest) (*RecomputeStockSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecomputeStockSummary not implemented")
+}
+func (UnimplementedStockAdminServer) mustEmbedUnimplementedStockAdminServer() {}
+
+// UnsafeStockAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockAdminServer will
+// result in compilation errors.
+type UnsafeStockAdminServer interface {
+	mustEmbedUnimplementedStockAdminServer()
+}
+
+func RegisterStockAdminServer(s grpc.ServiceRegistrar, srv StockAdminServer) {
+	s.RegisterService(&StockAdmin_ServiceDesc, srv)
+}
+
+func _StockAdmin_OverrideStockSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideStockSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAdminServer).OverrideStockSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAdmin_OverrideStockSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAdminServer).OverrideStockSummary(ctx, req.(*OverrideStockSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAdmin_DeleteStockSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStockSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAdminServer).DeleteStockSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAdmin_DeleteStockSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAdminServer).DeleteStockSummary(ctx, req.(*DeleteStockSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockAdmin_RecomputeStockSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecomputeStockSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockAdminServer).RecomputeStockSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockAdmin_RecomputeStockSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockAdminServer).RecomputeStockSummary(ctx, req.(*RecomputeStockSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StockAdmin_ServiceDesc is the grpc.ServiceDesc for StockAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.StockAdmin",
+	HandlerType: (*StockAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OverrideStockSummary",
+			Handler:    _StockAdmin_OverrideStockSummary_Handler,
+		},
+		{
+			MethodName: "DeleteStockSummary",
+			Handler:    _StockAdmin_DeleteStockSummary_Handler,
+		},
+		{
+			MethodName: "RecomputeStockSummary",
+			Handler:    _StockAdmin_RecomputeStockSummary_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stock.proto",
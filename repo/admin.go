@@ -0,0 +1,65 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"stock/model"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// overrideAttempts bounds how many times OverrideStockSummary reapplies an override to a summary that keeps changing
+const overrideAttempts = 10
+
+// OverrideStockSummary applies override to the stock's summary of date and stores the result with replaceMemberScript,
+// which only replaces the summary if it's still the one override was applied to. When the summary changed in the
+// meantime, e.g. by the Kafka consumer, override is applied again to the new summary. It returns the summary before
+// and after, model.ErrSummaryNotFound when the stock has no summary on date and model.ErrSummaryChanged when the
+// summary kept changing.
+func (repo *Repo) OverrideStockSummary(ctx context.Context, stockCode string, date time.Time,
+	override func(summary model.Summary) (model.Summary, error)) (before, after model.Summary, err error) {
+	key := fmt.Sprintf(stockSummaryFmt, stockCode)
+	score := strconv.Itoa(int(date.Unix()))
+
+	for attempt := 0; attempt < overrideAttempts; attempt++ {
+		members, err := repo.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+			Min: score,
+			Max: score,
+		}).Result()
+		if err != nil {
+			return model.Summary{}, model.Summary{}, err
+		}
+		if len(members) == 0 {
+			return model.Summary{}, model.Summary{}, model.ErrSummaryNotFound
+		}
+
+		before, err = decodeSummary([]byte(members[0]))
+		if err != nil {
+			return model.Summary{}, model.Summary{}, err
+		}
+
+		after, err = override(before)
+		if err != nil {
+			return model.Summary{}, model.Summary{}, err
+		}
+
+		replaced, err := repo.redisClient.Eval(ctx, replaceMemberScript, []string{key},
+			members[0], float64(date.Unix()), encodeSummary(after)).Int()
+		if err != nil {
+			return model.Summary{}, model.Summary{}, err
+		}
+		if replaced == 1 {
+			return before, after, nil
+		}
+	}
+
+	return model.Summary{}, model.Summary{}, model.ErrSummaryChanged
+}
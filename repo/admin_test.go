@@ -0,0 +1,182 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/repo/_mock"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Repo_OverrideStockSummary(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	zRangeBy := &redis.ZRangeBy{Min: "1693267200", Max: "1693267200"}
+
+	summary := model.Summary{StockCode: "BBCA", Date: date, High: 91000, Close: 9050, Volume: 100}
+	overridden := summary
+	overridden.High = 9100
+
+	// Updated by the consumer while the override was applied
+	updated := summary
+	updated.Volume = 200
+	updatedOverridden := updated
+	updatedOverridden.High = 9100
+
+	override := func(summary model.Summary) (model.Summary, error) {
+		summary.High = 9100
+		return summary, nil
+	}
+
+	type args struct {
+		ctx      context.Context
+		override func(summary model.Summary) (model.Summary, error)
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantBefore model.Summary
+		wantAfter  model.Summary
+		wantErr    error
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), override: override},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+						Return(redis.NewStringSliceResult([]string{string(encodeSummary(summary))}, nil))
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(encodeSummary(summary)), float64(date.Unix()), encodeSummary(overridden)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					return m
+				},
+			},
+			wantBefore: summary,
+			wantAfter:  overridden,
+		},
+		{
+			name: "success-changed",
+			args: args{ctx: context.Background(), override: override},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					gomock.InOrder(
+						m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+							Return(redis.NewStringSliceResult([]string{string(encodeSummary(summary))}, nil)),
+						m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+							string(encodeSummary(summary)), float64(date.Unix()), encodeSummary(overridden)).
+							Return(redis.NewCmdResult(int64(0), nil)),
+						m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+							Return(redis.NewStringSliceResult([]string{string(encodeSummary(updated))}, nil)),
+						m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+							string(encodeSummary(updated)), float64(date.Unix()), encodeSummary(updatedOverridden)).
+							Return(redis.NewCmdResult(int64(1), nil)),
+					)
+
+					return m
+				},
+			},
+			wantBefore: updated,
+			wantAfter:  updatedOverridden,
+		},
+		{
+			name: "error-not-found",
+			args: args{ctx: context.Background(), override: override},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+
+					return m
+				},
+			},
+			wantErr: model.ErrSummaryNotFound,
+		},
+		{
+			name: "error-summary-changed",
+			args: args{ctx: context.Background(), override: override},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+						Return(redis.NewStringSliceResult([]string{string(encodeSummary(summary))}, nil)).
+						Times(overrideAttempts)
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(encodeSummary(summary)), float64(date.Unix()), encodeSummary(overridden)).
+						Return(redis.NewCmdResult(int64(0), nil)).
+						Times(overrideAttempts)
+
+					return m
+				},
+			},
+			wantErr: model.ErrSummaryChanged,
+		},
+		{
+			name: "error-eval",
+			args: args{ctx: context.Background(), override: override},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, zRangeBy).
+						Return(redis.NewStringSliceResult([]string{string(encodeSummary(summary))}, nil))
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(encodeSummary(summary)), float64(date.Unix()), encodeSummary(overridden)).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+
+					return m
+				},
+			},
+			wantErr: errors.New("error-eval"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotBefore, gotAfter, err := repo.OverrideStockSummary(tt.args.ctx, "BBCA", date, tt.args.override)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("repo.OverrideStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(tt.wantErr, model.ErrSummaryNotFound) && !errors.Is(err, model.ErrSummaryNotFound) ||
+				errors.Is(tt.wantErr, model.ErrSummaryChanged) && !errors.Is(err, model.ErrSummaryChanged) {
+				t.Errorf("repo.OverrideStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotBefore, tt.wantBefore) {
+				t.Errorf("repo.OverrideStockSummary() gotBefore = %v, wantBefore %v", gotBefore, tt.wantBefore)
+			}
+			if !reflect.DeepEqual(gotAfter, tt.wantAfter) {
+				t.Errorf("repo.OverrideStockSummary() gotAfter = %v, wantAfter %v", gotAfter, tt.wantAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"stock/model"
+)
+
+const (
+	// summaryEncodingJSON is not an actual version byte; members written before the compact encoding are
+	// JSON objects, which always start with '{'
+	summaryEncodingJSON byte = '{'
+
+	// summaryEncodingV1 members are laid out as:
+	// [version][stock code length (uvarint)][stock code][date unix (varint)][int64 and int32 fields (varint)...]
+	// New fields must only be appended to summaryFields so that older members still decode, with zero values
+	// for the fields they don't have.
+	summaryEncodingV1 byte = 0x01
+)
+
+var (
+	errSummaryTruncated = errors.New("truncated stock summary")
+)
+
+// summaryFields returns pointers to every int64 and int32 field of summary in the order they are encoded
+func summaryFields(summary *model.Summary) []interface{} {
+	fields := []interface{}{
+		&summary.Prev,
+		&summary.Open,
+		&summary.High,
+		&summary.Low,
+		&summary.Close,
+		&summary.Volume,
+		&summary.Value,
+		&summary.Average,
+	}
+
+	for _, sessionSummary := range []*model.SessionSummary{
+		&summary.PreOpening,
+		&summary.SessionOne,
+		&summary.SessionTwo,
+		&summary.PreClosing,
+	} {
+		fields = append(fields,
+			&sessionSummary.Open,
+			&sessionSummary.High,
+			&sessionSummary.Low,
+			&sessionSummary.Close,
+			&sessionSummary.Volume,
+			&sessionSummary.Value,
+		)
+	}
+
+	return append(fields,
+		&summary.FirstTradeTime,
+		&summary.FirstTradeSequence,
+		&summary.LastTradeTime,
+		&summary.LastTradeSequence,
+		&summary.Trades,
+		&summary.PreOpening.Trades,
+		&summary.SessionOne.Trades,
+		&summary.SessionTwo.Trades,
+		&summary.PreClosing.Trades,
+		&summary.PriceScale,
+		&summary.QuantityScale,
+		&summary.VWAP,
+		&summary.VWAPScale,
+		&summary.Regular.Volume,
+		&summary.Regular.Value,
+		&summary.Regular.Trades,
+		&summary.Negotiated.Volume,
+		&summary.Negotiated.Value,
+		&summary.Negotiated.Trades,
+		&summary.Cash.Volume,
+		&summary.Cash.Value,
+		&summary.Cash.Trades,
+		&summary.BuyVolume,
+		&summary.SellVolume,
+	)
+}
+
+// encodeSummary encodes summary with the latest compact encoding
+func encodeSummary(summary model.Summary) []byte {
+	data := make([]byte, 0, 64)
+	data = append(data, summaryEncodingV1)
+	data = binary.AppendUvarint(data, uint64(len(summary.StockCode)))
+	data = append(data, summary.StockCode...)
+	data = binary.AppendVarint(data, summary.Date.Unix())
+
+	for _, field := range summaryFields(&summary) {
+		switch field := field.(type) {
+		case *int64:
+			data = binary.AppendVarint(data, *field)
+		case *int32:
+			data = binary.AppendVarint(data, int64(*field))
+		}
+	}
+
+	return data
+}
+
+// decodeSummary decodes a stock summary member written with any of the supported encodings
+func decodeSummary(data []byte) (model.Summary, error) {
+	if len(data) == 0 {
+		return model.Summary{}, errSummaryTruncated
+	}
+
+	switch data[0] {
+	case summaryEncodingJSON:
+		summary := model.Summary{}
+		if err := json.Unmarshal(data, &summary); err != nil {
+			return model.Summary{}, err
+		}
+		return summary, nil
+	case summaryEncodingV1:
+		return decodeSummaryV1(data[1:])
+	default:
+		return model.Summary{}, fmt.Errorf("unknown stock summary encoding version %d", data[0])
+	}
+}
+
+func decodeSummaryV1(data []byte) (model.Summary, error) {
+	summary := model.Summary{}
+
+	stockCodeLen, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < stockCodeLen {
+		return model.Summary{}, errSummaryTruncated
+	}
+	data = data[n:]
+	summary.StockCode = string(data[:stockCodeLen])
+	data = data[stockCodeLen:]
+
+	dateUnix, n := binary.Varint(data)
+	if n <= 0 {
+		return model.Summary{}, errSummaryTruncated
+	}
+	data = data[n:]
+	summary.Date = time.Unix(dateUnix, 0).UTC()
+
+	for _, field := range summaryFields(&summary) {
+		if len(data) == 0 {
+			break
+		}
+
+		value, n := binary.Varint(data)
+		if n <= 0 {
+			return model.Summary{}, errSummaryTruncated
+		}
+		data = data[n:]
+
+		switch field := field.(type) {
+		case *int64:
+			*field = value
+		case *int32:
+			*field = int32(value)
+		}
+	}
+
+	return summary, nil
+}
+
+// isLegacySummary checks whether a stock summary member is still stored with the JSON encoding
+func isLegacySummary(data []byte) bool {
+	return len(data) > 0 && data[0] == summaryEncodingJSON
+}
@@ -0,0 +1,135 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+)
+
+func Test_decodeSummary(t *testing.T) {
+	summary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Prev:      8000,
+		Open:      8050,
+		High:      8100,
+		Low:       7950,
+		Close:     8100,
+		Volume:    900,
+		Value:     7210000,
+		Average:   8011,
+		Trades:    5,
+		SessionOne: model.SessionSummary{
+			Open:   8050,
+			High:   8100,
+			Low:    7950,
+			Close:  8100,
+			Volume: 900,
+			Value:  7210000,
+			Trades: 5,
+		},
+		FirstTradeTime:     time.Date(2023, 8, 29, 9, 0, 1, 0, time.UTC).UnixMilli(),
+		FirstTradeSequence: 12,
+		LastTradeTime:      time.Date(2023, 8, 29, 15, 49, 58, 0, time.UTC).UnixMilli(),
+		LastTradeSequence:  3390,
+		PriceScale:         2,
+		QuantityScale:      1,
+		VWAP:               801111,
+		VWAPScale:          4,
+		Regular:            model.BoardSummary{Volume: 700, Value: 5610000, Trades: 4},
+		Negotiated:         model.BoardSummary{Volume: 200, Value: 1600000, Trades: 1},
+		BuyVolume:          500,
+		SellVolume:         400,
+	}
+	summaryJSON, _ := json.Marshal(summary)
+
+	tests := []struct {
+		name  string
+		input []byte
+
+		wantResponse model.Summary
+		wantErr      bool
+	}{
+		{
+			name:         "success-v1",
+			input:        encodeSummary(summary),
+			wantResponse: summary,
+		},
+		{
+			name:         "success-legacy-json",
+			input:        summaryJSON,
+			wantResponse: summary,
+		},
+		{
+			name: "success-v1-missing-trailing-fields",
+			// version, stock code, date and prev only
+			input: encodeSummary(summary)[:1+1+len("BBCA")+5+2],
+			wantResponse: model.Summary{
+				StockCode: "BBCA",
+				Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+				Prev:      8000,
+			},
+		},
+		{
+			name:    "error-empty",
+			input:   []byte{},
+			wantErr: true,
+		},
+		{
+			name:    "error-unknown-version",
+			input:   []byte{0x7F, 0x00},
+			wantErr: true,
+		},
+		{
+			name:    "error-truncated-stock-code",
+			input:   []byte{summaryEncodingV1, 0x04, 'B', 'B'},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-json",
+			input:   []byte(`{"stock_code":`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := decodeSummary(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeSummary() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("decodeSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_encodeSummary_Size(t *testing.T) {
+	summary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Prev:      8000,
+		Open:      8050,
+		High:      8100,
+		Low:       7950,
+		Close:     8100,
+		Volume:    900,
+		Value:     7210000,
+		Average:   8011,
+	}
+	summaryJSON, _ := json.Marshal(summary)
+
+	if got := len(encodeSummary(summary)); got*4 > len(summaryJSON) {
+		t.Errorf("encodeSummary() size = %d, want at most a quarter of the JSON size %d", got, len(summaryJSON))
+	}
+}
@@ -7,9 +7,10 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 
+	"stock/logger"
 	"stock/model"
 
 	"github.com/go-redis/redis/v8"
@@ -20,6 +21,11 @@ type RedisClient interface {
 	ZRangeByScore(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd
 	ZRemRangeByScore(ctx context.Context, key, min, max string) *redis.IntCmd
 	ZAdd(ctx context.Context, key string, members ...*redis.Z) *redis.IntCmd
+	ZRangeWithScores(ctx context.Context, key string, start, stop int64) *redis.ZSliceCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	XRange(ctx context.Context, stream, start, stop string) *redis.XMessageSliceCmd
 }
 
 type Repo struct {
@@ -27,22 +33,62 @@ type Repo struct {
 }
 
 func New(cfg model.Config) *Repo {
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s%s", cfg.Redis.Host, cfg.Redis.Port),
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
+	client, err := newRedisClient(cfg.Redis)
+	if err != nil {
+		logger.For("redis").Error("Invalid config", logger.Err(err))
+		return nil
+	}
+	client.AddHook(newTracingHook())
 
 	// Ping the Redis server to check if it's reachable
-	_, err := client.Ping(context.Background()).Result()
+	_, err = client.Ping(context.Background()).Result()
 	if err != nil {
-		log.Print("[Redis] Failed to connect:", err)
+		logger.For("redis").Error("Failed to connect", logger.Err(err))
 		return nil
 	}
 
-	log.Printf("[Redis] Serving on port %s", cfg.Redis.Port)
+	logger.For("redis").Info("Connected", "mode", cfg.Redis.Mode)
 
 	return &Repo{
 		redisClient: client,
 	}
 }
+
+// newRedisClient builds the go-redis client matching the configured mode:
+// - standalone: a single node client on host:port
+// - sentinel: a failover client that discovers the master named MasterName through SentinelAddrs
+// - cluster: a cluster client seeded with ClusterAddrs
+func newRedisClient(cfg model.Redis) (redis.UniversalClient, error) {
+	switch cfg.Mode {
+	case model.RedisModeStandalone, "":
+		return redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s%s", cfg.Host, cfg.Port),
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}), nil
+	case model.RedisModeSentinel:
+		if cfg.MasterName == "" || len(cfg.SentinelAddrs) == 0 {
+			return nil, errors.New("sentinel mode requires master_name and sentinel_addrs")
+		}
+
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.SentinelAddrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+		}), nil
+	case model.RedisModeCluster:
+		if len(cfg.ClusterAddrs) == 0 {
+			return nil, errors.New("cluster mode requires cluster_addrs")
+		}
+
+		// Redis Cluster only supports database 0
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    cfg.ClusterAddrs,
+			Password: cfg.Password,
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid redis mode %s", cfg.Mode)
+	}
+}
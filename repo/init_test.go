@@ -0,0 +1,100 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"reflect"
+	"testing"
+
+	"stock/model"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func Test_newRedisClient(t *testing.T) {
+	tests := []struct {
+		name  string
+		input model.Redis
+
+		wantClient interface{}
+		wantErr    bool
+	}{
+		{
+			name: "success-standalone",
+			input: model.Redis{
+				Mode: model.RedisModeStandalone,
+				Host: "localhost",
+				Port: ":6379",
+			},
+			wantClient: &redis.Client{},
+		},
+		{
+			name: "success-default-standalone",
+			input: model.Redis{
+				Host: "localhost",
+				Port: ":6379",
+			},
+			wantClient: &redis.Client{},
+		},
+		{
+			name: "success-sentinel",
+			input: model.Redis{
+				Mode:          model.RedisModeSentinel,
+				MasterName:    "mymaster",
+				SentinelAddrs: []string{"localhost:26379", "localhost:26380"},
+			},
+			wantClient: &redis.Client{},
+		},
+		{
+			name: "success-cluster",
+			input: model.Redis{
+				Mode:         model.RedisModeCluster,
+				ClusterAddrs: []string{"localhost:7000"},
+			},
+			wantClient: &redis.ClusterClient{},
+		},
+		{
+			name: "error-sentinel-no-master-name",
+			input: model.Redis{
+				Mode:          model.RedisModeSentinel,
+				SentinelAddrs: []string{"localhost:26379"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-cluster-no-addrs",
+			input: model.Redis{
+				Mode: model.RedisModeCluster,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-invalid-mode",
+			input: model.Redis{
+				Mode: "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClient, err := newRedisClient(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRedisClient() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+			defer gotClient.Close()
+
+			if reflect.TypeOf(gotClient) != reflect.TypeOf(tt.wantClient) {
+				t.Errorf("newRedisClient() gotClient = %T, wantClient %T", gotClient, tt.wantClient)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/model"
+)
+
+const (
+	// Each stock has a stream per day, keyed by the same score as the day's summary. The stock code is wrapped in a
+	// hash tag so that the journal is stored in the same Redis Cluster slot as the stock's summaries.
+	transactionJournalFmt     = "transactions-{%s}-%d"
+	transactionJournalPattern = "transactions-{*}-*"
+
+	// Each journal has a set of the IDs of its transactions, by which a transaction delivered again isn't journaled
+	// twice. It's stored in the same slot as the journal and removed with it.
+	transactionIDsFmt = "transactionids-{%s}-%d"
+
+	// transactionField is the field of a journal entry holding its JSON encoded transaction
+	transactionField = "transaction"
+
+	// appendTransactionScript appends a journal entry with transaction ARGV[2] to stream KEYS[1], unless ID ARGV[1]
+	// is already in the set KEYS[2] of the IDs of the transactions journaled in KEYS[1]. An empty ID is always
+	// appended.
+	appendTransactionScript = `
+if ARGV[1] ~= "" and redis.call("SADD", KEYS[2], ARGV[1]) == 0 then
+	return 0
+end
+redis.call("XADD", KEYS[1], "*", "transaction", ARGV[2])
+return 1`
+)
+
+// AppendTransaction appends transaction to the journal of its stock and date with appendTransactionScript, so that
+// the entry ID orders it after every transaction journaled before. A transaction read from the same Kafka message as
+// one already journaled, i.e. a message delivered again, isn't appended; fills of the same order are read from
+// different messages and are all appended.
+func (repo *Repo) AppendTransaction(ctx context.Context, transaction model.Transaction) error {
+	value, err := json.Marshal(transaction)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{
+		fmt.Sprintf(transactionJournalFmt, transaction.StockCode, transaction.Date.Unix()),
+		fmt.Sprintf(transactionIDsFmt, transaction.StockCode, transaction.Date.Unix()),
+	}
+	return repo.redisClient.Eval(ctx, appendTransactionScript, keys, transaction.Source, value).Err()
+}
+
+// GetTransactions gets the journal of stockCode on date, the start of its trading day, by performing XRange over the
+// whole stream. Dates and timestamps are read back in UTC.
+func (repo *Repo) GetTransactions(ctx context.Context, stockCode string, date time.Time) (result []model.JournalEntry, err error) {
+	messages, err := repo.redisClient.XRange(ctx, fmt.Sprintf(transactionJournalFmt, stockCode, date.Unix()), "-", "+").Result()
+	if err != nil {
+		return []model.JournalEntry{}, err
+	}
+
+	result = []model.JournalEntry{}
+	for _, message := range messages {
+		value, ok := message.Values[transactionField].(string)
+		if !ok {
+			return []model.JournalEntry{}, fmt.Errorf("journal entry %s has no transaction", message.ID)
+		}
+
+		var transaction model.Transaction
+		if err := json.Unmarshal([]byte(value), &transaction); err != nil {
+			return []model.JournalEntry{}, fmt.Errorf("invalid journal entry %s: %w", message.ID, err)
+		}
+		transaction.Date = transaction.Date.UTC()
+		if !transaction.Timestamp.IsZero() {
+			transaction.Timestamp = transaction.Timestamp.UTC()
+		}
+
+		result = append(result, model.JournalEntry{ID: message.ID, Transaction: transaction})
+	}
+
+	return result, nil
+}
+
+// DeleteTransactions removes the journals of every stock dated before toDate, the start of the day in the timezone of
+// each stock given by getLocation, and returns how many journals were removed
+func (repo *Repo) DeleteTransactions(ctx context.Context, toDate time.Time, getLocation func(stockCode string) *time.Location) (int64, error) {
+	return repo.deleteTransactions(ctx, func(stockCode string, score int64) bool {
+		return score < model.TradingDate(toDate, getLocation(stockCode)).Unix()
+	})
+}
+
+// clearTransactions removes the journals of every stock dated fromDate or later, the start of the day in the timezone
+// of each stock given by getLocation, and returns how many journals were removed. A zero fromDate removes every
+// journal.
+func (repo *Repo) clearTransactions(ctx context.Context, fromDate time.Time, getLocation func(stockCode string) *time.Location) (int64, error) {
+	return repo.deleteTransactions(ctx, func(stockCode string, score int64) bool {
+		return fromDate.IsZero() || score >= model.TradingDate(fromDate, getLocation(stockCode)).Unix()
+	})
+}
+
+// deleteTransactions removes the journals, and their sets of transaction IDs, of the stocks and date scores matched by
+// match and returns how many journals were removed. Journals are removed one key at a time, as a stock's days may be
+// stored in different nodes.
+func (repo *Repo) deleteTransactions(ctx context.Context, match func(stockCode string, score int64) bool) (int64, error) {
+	keys, err := repo.scanKeys(ctx, transactionJournalPattern)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, key := range keys {
+		stockCode, score, ok := parseTransactionJournalKey(key)
+		if !ok || !match(stockCode, score) {
+			continue
+		}
+
+		removed, err := repo.redisClient.Del(ctx, key, fmt.Sprintf(transactionIDsFmt, stockCode, score)).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if removed > 0 {
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// parseTransactionJournalKey returns the stock code and date score of a key formatted by transactionJournalFmt
+func parseTransactionJournalKey(key string) (string, int64, bool) {
+	rest, ok := strings.CutPrefix(key, "transactions-{")
+	if !ok {
+		return "", 0, false
+	}
+
+	stockCode, scoreString, ok := strings.Cut(rest, "}-")
+	if !ok {
+		return "", 0, false
+	}
+
+	score, err := strconv.ParseInt(scoreString, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return stockCode, score, true
+}
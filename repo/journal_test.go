@@ -0,0 +1,397 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/repo/_mock"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Repo_AppendTransaction(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	journalKey := "transactions-{BBCA}-" + strconv.Itoa(int(date.Unix()))
+	idsKey := "transactionids-{BBCA}-" + strconv.Itoa(int(date.Unix()))
+
+	transaction := model.Transaction{
+		Type:        model.TransactionTypeE,
+		StockCode:   "BBCA",
+		OrderNumber: "202308290930000001",
+		Price:       9050,
+		Quantity:    100,
+		Date:        date,
+		Timestamp:   time.Date(2023, 8, 29, 9, 30, 0, 0, time.UTC),
+		Sequence:    1,
+		Session:     model.SessionOne,
+		Source:      "stock-0-42",
+	}
+	encoded, _ := json.Marshal(transaction)
+
+	// A second fill of the same order, read from another message
+	fill := transaction
+	fill.Quantity = 200
+	fill.Source = "stock-0-43"
+	encodedFill, _ := json.Marshal(fill)
+
+	unknownSource := transaction
+	unknownSource.Source = ""
+	encodedUnknownSource, _ := json.Marshal(unknownSource)
+
+	type args struct {
+		ctx   context.Context
+		input model.Transaction
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), input: transaction},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().Eval(gomock.Any(), appendTransactionScript, []string{journalKey, idsKey},
+						"stock-0-42", encoded).
+						Return(redis.NewCmdResult(int64(1), nil))
+					return m
+				},
+			},
+		},
+		{
+			name: "success-duplicate",
+			args: args{ctx: context.Background(), input: transaction},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().Eval(gomock.Any(), appendTransactionScript, []string{journalKey, idsKey},
+						"stock-0-42", encoded).
+						Return(redis.NewCmdResult(int64(0), nil))
+					return m
+				},
+			},
+		},
+		{
+			name: "success-same-order",
+			args: args{ctx: context.Background(), input: fill},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().Eval(gomock.Any(), appendTransactionScript, []string{journalKey, idsKey},
+						"stock-0-43", encodedFill).
+						Return(redis.NewCmdResult(int64(1), nil))
+					return m
+				},
+			},
+		},
+		{
+			name: "success-unknown-source",
+			args: args{ctx: context.Background(), input: unknownSource},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().Eval(gomock.Any(), appendTransactionScript, []string{journalKey, idsKey},
+						"", encodedUnknownSource).
+						Return(redis.NewCmdResult(int64(1), nil))
+					return m
+				},
+			},
+		},
+		{
+			name: "error-eval",
+			args: args{ctx: context.Background(), input: transaction},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().Eval(gomock.Any(), appendTransactionScript, gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			err := repo.AppendTransaction(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.AppendTransaction() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Repo_GetTransactions(t *testing.T) {
+	jakarta, err := model.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("model.LoadLocation() err = %v", err)
+	}
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta)
+	journalKey := "transactions-{BBCA}-" + strconv.Itoa(int(date.Unix()))
+
+	transaction := model.Transaction{
+		Type:        model.TransactionTypeE,
+		StockCode:   "BBCA",
+		OrderNumber: "202308290930000001",
+		Price:       9050,
+		Quantity:    100,
+		Date:        date,
+		Timestamp:   time.Date(2023, 8, 29, 9, 30, 0, 0, jakarta),
+		Sequence:    1,
+		Session:     model.SessionOne,
+		Board:       model.BoardRegular,
+		Side:        model.SideBuy,
+	}
+	encoded, _ := json.Marshal(transaction)
+
+	// Read back in UTC, like summaries
+	wantTransaction := transaction
+	wantTransaction.Date = date.UTC()
+	wantTransaction.Timestamp = transaction.Timestamp.UTC()
+
+	prev := model.Transaction{Type: model.TransactionTypeA, StockCode: "BBCA", Price: 9000, Date: date}
+	encodedPrev, _ := json.Marshal(prev)
+
+	wantPrev := prev
+	wantPrev.Date = date.UTC()
+
+	type args struct {
+		ctx       context.Context
+		stockCode string
+		date      time.Time
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []model.JournalEntry
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().XRange(gomock.Any(), journalKey, "-", "+").Return(redis.NewXMessageSliceCmdResult([]redis.XMessage{
+						{ID: "1693272600000-0", Values: map[string]interface{}{transactionField: string(encodedPrev)}},
+						{ID: "1693276200000-0", Values: map[string]interface{}{transactionField: string(encoded)}},
+					}, nil))
+					return m
+				},
+			},
+			wantResponse: []model.JournalEntry{
+				{ID: "1693272600000-0", Transaction: wantPrev},
+				{ID: "1693276200000-0", Transaction: wantTransaction},
+			},
+		},
+		{
+			name: "success-empty",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().XRange(gomock.Any(), journalKey, "-", "+").Return(redis.NewXMessageSliceCmdResult(nil, nil))
+					return m
+				},
+			},
+			wantResponse: []model.JournalEntry{},
+		},
+		{
+			name: "error-invalid-entry",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().XRange(gomock.Any(), journalKey, "-", "+").Return(redis.NewXMessageSliceCmdResult([]redis.XMessage{
+						{ID: "1693272600000-0", Values: map[string]interface{}{transactionField: "{"}},
+					}, nil))
+					return m
+				},
+			},
+			wantResponse: []model.JournalEntry{},
+			wantErr:      true,
+		},
+		{
+			name: "error-xrange",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().XRange(gomock.Any(), journalKey, "-", "+").Return(redis.NewXMessageSliceCmdResult(nil, errors.New("error-xrange")))
+					return m
+				},
+			},
+			wantResponse: []model.JournalEntry{},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.GetTransactions(tt.args.ctx, tt.args.stockCode, tt.args.date)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.GetTransactions() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("repo.GetTransactions() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_DeleteTransactions(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	toDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	score := strconv.Itoa(int(toDate.Unix()))
+	jakartaScore := strconv.Itoa(int(time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta).Unix()))
+	beforeScore := strconv.Itoa(int(toDate.AddDate(0, 0, -1).Unix()))
+	jakartaBeforeScore := strconv.Itoa(int(time.Date(2023, 8, 28, 0, 0, 0, 0, jakarta).Unix()))
+
+	getLocation := func(stockCode string) *time.Location {
+		if stockCode == "BBRI" {
+			return jakarta
+		}
+		return time.UTC
+	}
+
+	type args struct {
+		ctx    context.Context
+		toDate time.Time
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse int64
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), toDate: toDate},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					// toDate and later are kept
+					m.EXPECT().Scan(gomock.Any(), uint64(0), transactionJournalPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{
+							"transactions-{BBCA}-" + beforeScore,
+							"transactions-{BBCA}-" + score,
+							"transactions-{BBRI}-" + jakartaBeforeScore,
+							"transactions-{BBRI}-" + jakartaScore,
+						}, 0, nil))
+					m.EXPECT().Del(gomock.Any(), "transactions-{BBCA}-"+beforeScore, "transactionids-{BBCA}-"+beforeScore).
+						Return(redis.NewIntResult(2, nil))
+					m.EXPECT().Del(gomock.Any(), "transactions-{BBRI}-"+jakartaBeforeScore, "transactionids-{BBRI}-"+jakartaBeforeScore).
+						Return(redis.NewIntResult(1, nil))
+
+					return m
+				},
+			},
+			wantResponse: 2,
+		},
+		{
+			name: "error-del",
+			args: args{ctx: context.Background(), toDate: toDate},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), transactionJournalPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{"transactions-{BBCA}-" + beforeScore}, 0, nil))
+					m.EXPECT().Del(gomock.Any(), gomock.Any(), gomock.Any()).Return(redis.NewIntResult(0, errors.New("error-del")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.DeleteTransactions(tt.args.ctx, tt.args.toDate, getLocation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.DeleteTransactions() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("repo.DeleteTransactions() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_parseTransactionJournalKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+
+		wantStockCode string
+		wantScore     int64
+		wantOK        bool
+	}{
+		{name: "success", key: "transactions-{BBCA}-1693267200", wantStockCode: "BBCA", wantScore: 1693267200, wantOK: true},
+		{name: "success-negative-score", key: "transactions-{BBCA}--86400", wantStockCode: "BBCA", wantScore: -86400, wantOK: true},
+		{name: "error-prefix", key: "stocksummary-{BBCA}", wantOK: false},
+		{name: "error-score", key: "transactions-{BBCA}-today", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStockCode, gotScore, gotOK := parseTransactionJournalKey(tt.key)
+			if gotStockCode != tt.wantStockCode || gotScore != tt.wantScore || gotOK != tt.wantOK {
+				t.Errorf("parseTransactionJournalKey() = %v, %v, %v, want %v, %v, %v",
+					gotStockCode, gotScore, gotOK, tt.wantStockCode, tt.wantScore, tt.wantOK)
+			}
+		})
+	}
+}
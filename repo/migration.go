@@ -0,0 +1,384 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stock/model"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	scanCount = 100
+
+	// replaceMemberScript replaces member ARGV[1] of sorted set KEYS[1] with member ARGV[3] at score ARGV[2],
+	// but only if ARGV[1] still exists; a member that was rewritten in the meantime is left untouched
+	replaceMemberScript = `
+if redis.call("ZSCORE", KEYS[1], ARGV[1]) then
+	redis.call("ZREM", KEYS[1], ARGV[1])
+	redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+	return 1
+end
+return 0`
+
+	// Stock summaries were stored under untagged keys, e.g. "stocksummary-BBCA", before keys were hash-tagged
+	legacyStockSummaryPrefix  = "stocksummary-"
+	legacyStockSummaryPattern = "stocksummary-[^{]*"
+
+	// mergeMemberScript moves member ARGV[1] of sorted set KEYS[1] into sorted set KEYS[2] as member ARGV[4] at score
+	// ARGV[2], replacing member ARGV[3] that KEYS[2] had at that score, if not empty. It returns 0 and changes nothing
+	// if ARGV[1] no longer exists, and -1 if KEYS[2] no longer has exactly ARGV[3] at that score, e.g. after the
+	// consumer updated it. KEYS[1] and KEYS[2] may be the same key.
+	mergeMemberScript = `
+if not redis.call("ZSCORE", KEYS[1], ARGV[1]) then
+	return 0
+end
+local current = redis.call("ZRANGEBYSCORE", KEYS[2], ARGV[2], ARGV[2])
+if ARGV[3] == "" then
+	if #current > 0 then
+		return -1
+	end
+elseif #current ~= 1 or current[1] ~= ARGV[3] then
+	return -1
+end
+redis.call("ZREM", KEYS[1], ARGV[1])
+if ARGV[3] ~= "" then
+	redis.call("ZREM", KEYS[2], ARGV[3])
+end
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[4])
+return 1`
+
+	// mergeAttempts bounds how many times moveSummary merges into a summary that keeps changing
+	mergeAttempts = 10
+)
+
+// clusterScanner is implemented by the Redis Cluster client, whose keys are spread across several master nodes
+type clusterScanner interface {
+	ForEachMaster(ctx context.Context, fn func(ctx context.Context, client *redis.Client) error) error
+}
+
+// moveLegacyStockSummaries moves every member of the untagged stock summary keys into its hash-tagged key with
+// moveSummary. It returns the number of moved members.
+func (repo *Repo) moveLegacyStockSummaries(ctx context.Context) (int, error) {
+	keys, err := repo.scanKeys(ctx, legacyStockSummaryPattern)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, key := range keys {
+		stockKey := fmt.Sprintf(stockSummaryFmt, strings.TrimPrefix(key, legacyStockSummaryPrefix))
+
+		members, err := repo.redisClient.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return moved, err
+		}
+
+		for _, member := range members {
+			data, _ := member.Member.(string)
+			summary, err := decodeSummary([]byte(data))
+			if err != nil {
+				return moved, err
+			}
+
+			movedMember, err := repo.moveSummary(ctx, key, stockKey, data, summary)
+			if err != nil {
+				return moved, err
+			}
+			if movedMember {
+				moved++
+			}
+		}
+	}
+
+	return moved, nil
+}
+
+// moveSummary moves member data of sorted set key into sorted set toKey as summary, at the score of its date, with
+// mergeMemberScript. A summary toKey already has at that score, e.g. written by the consumer after the upgrade, is
+// merged with it by model.AggregateSummaries, ordered by their first trades and summary first when unknown, so that no
+// trade is lost. It reports false when data no longer exists, e.g. after a concurrent migration moved it.
+func (repo *Repo) moveSummary(ctx context.Context, key, toKey, data string, summary model.Summary) (bool, error) {
+	score := strconv.Itoa(int(summary.Date.Unix()))
+
+	for attempt := 0; attempt < mergeAttempts; attempt++ {
+		current, err := repo.redisClient.ZRangeByScore(ctx, toKey, &redis.ZRangeBy{
+			Min: score,
+			Max: score,
+		}).Result()
+		if err != nil {
+			return false, err
+		}
+
+		merged, previous := summary, ""
+		if len(current) > 0 {
+			previous = current[0]
+			existing, err := decodeSummary([]byte(previous))
+			if err != nil {
+				return false, err
+			}
+
+			summaries := []model.Summary{summary, existing}
+			if existing.FirstTradeTime != 0 && summary.FirstTradeTime != 0 && existing.FirstTradeTime < summary.FirstTradeTime {
+				summaries = []model.Summary{existing, summary}
+			}
+
+			merged, err = model.AggregateSummaries(summary.Date, summaries)
+			if err != nil {
+				return false, fmt.Errorf("merge %s summaries at score %s: %w", summary.StockCode, score, err)
+			}
+			merged.Monthly = summary.Monthly
+		}
+
+		moved, err := repo.redisClient.Eval(ctx, mergeMemberScript, []string{key, toKey},
+			data, float64(summary.Date.Unix()), previous, encodeSummary(merged)).Int()
+		if err != nil {
+			return false, err
+		}
+		if moved >= 0 {
+			return moved == 1, nil
+		}
+	}
+
+	return false, fmt.Errorf("%s summary at score %s of %s kept changing", summary.StockCode, score, toKey)
+}
+
+// MigrateStockSummaries rewrites every stock summary member that is still stored as JSON with the compact encoding.
+// Members of the untagged keys stored before keys were hash-tagged, e.g. "stocksummary-BBCA", which are all JSON, are
+// rewritten as they're moved into their hash-tagged keys, e.g. "stocksummary-{BBCA}". Untagged keys only exist in
+// standalone and Sentinel deployments, where both keys are on the same node, so each member is moved atomically by
+// moveSummary, which merges it with a summary of the same day written after the upgrade. Other members are replaced
+// atomically by replaceMemberScript, so the migration can run while the consumer keeps updating stock summaries. It
+// returns the number of migrated members.
+func (repo *Repo) MigrateStockSummaries(ctx context.Context) (int, error) {
+	moved, err := repo.moveLegacyStockSummaries(ctx)
+	if err != nil {
+		return moved, err
+	}
+
+	migrated, err := repo.rewriteStockSummaries(ctx, stockSummaryPattern, encodeLegacySummary)
+	return moved + migrated, err
+}
+
+// MigrateStockSummaryDates moves the daily and monthly stock summaries dated at midnight UTC, as they were before
+// summaries were dated in the timezone of their stock given by getLocation, to the start of the same day there, and
+// reads their first and last trade times as wall clock times of that timezone, as order numbers now are. Summaries
+// of stocks trading in UTC are left untouched. Each member is moved with moveSummary, merging it with a summary the
+// consumer has already written at the new score. It returns the number of migrated members.
+func (repo *Repo) MigrateStockSummaryDates(ctx context.Context, getLocation func(stockCode string) *time.Location) (int, error) {
+	migrated := 0
+	for _, pattern := range []string{stockSummaryPattern, stockSummaryArchivePattern} {
+		keys, err := repo.scanKeys(ctx, pattern)
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, key := range keys {
+			members, err := repo.redisClient.ZRangeWithScores(ctx, key, 0, -1).Result()
+			if err != nil {
+				return migrated, err
+			}
+
+			for _, member := range members {
+				data, _ := member.Member.(string)
+				summary, err := decodeSummary([]byte(data))
+				if err != nil {
+					return migrated, err
+				}
+
+				localized, ok := localizeSummaryDates(summary, getLocation(summary.StockCode))
+				if !ok {
+					continue
+				}
+
+				moved, err := repo.moveSummary(ctx, key, key, data, localized)
+				if err != nil {
+					return migrated, err
+				}
+				if moved {
+					migrated++
+				}
+			}
+		}
+	}
+
+	return migrated, nil
+}
+
+// localizeSummaryDates returns summary dated at the start of its day in location, if it's still dated at midnight UTC
+// while that day starts at another time there
+func localizeSummaryDates(summary model.Summary, location *time.Location) (model.Summary, bool) {
+	date := summary.Date.UTC()
+	if !date.Equal(model.TradingDate(date, time.UTC)) {
+		return summary, false
+	}
+
+	localDate := model.TradingDate(date, location)
+	if localDate.Equal(date) {
+		return summary, false
+	}
+
+	summary.Date = localDate.UTC()
+	summary.FirstTradeTime = localizeWallClock(summary.FirstTradeTime, location)
+	summary.LastTradeTime = localizeWallClock(summary.LastTradeTime, location)
+	return summary, true
+}
+
+// localizeWallClock returns the unix milliseconds of the wall clock time of unixMilli in UTC, read in location
+func localizeWallClock(unixMilli int64, location *time.Location) int64 {
+	if unixMilli == 0 {
+		return 0
+	}
+
+	t := time.UnixMilli(unixMilli).UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location).UnixMilli()
+}
+
+// encodeLegacySummary returns data with the compact encoding if it's still stored as JSON
+func encodeLegacySummary(data []byte) ([]byte, bool, error) {
+	if !isLegacySummary(data) {
+		return nil, false, nil
+	}
+
+	summary, err := decodeSummary(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return encodeSummary(summary), true, nil
+}
+
+// MigrateStockSummaryVWAP recomputes the VWAP of every daily and monthly stock summary member from its Value and
+// Volume with the given precision, e.g. for members stored before VWAPs were, or after the precision was changed.
+// Members are replaced like in MigrateStockSummaries. It returns the number of migrated members.
+func (repo *Repo) MigrateStockSummaryVWAP(ctx context.Context, precision int32) (int, error) {
+	migrated := 0
+	for _, pattern := range []string{stockSummaryPattern, stockSummaryArchivePattern} {
+		patternMigrated, err := repo.rewriteStockSummaries(ctx, pattern, migrateVWAP(precision))
+		migrated += patternMigrated
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// migrateVWAP returns a rewrite of rewriteStockSummaries that recomputes VWAPs with precision
+func migrateVWAP(precision int32) func(data []byte) ([]byte, bool, error) {
+	return func(data []byte) ([]byte, bool, error) {
+		summary, err := decodeSummary(data)
+		if err != nil {
+			return nil, false, err
+		}
+
+		migratedSummary, err := summary.WithVWAP(precision)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if migratedSummary == summary && !isLegacySummary(data) {
+			return nil, false, nil
+		}
+
+		return encodeSummary(migratedSummary), true, nil
+	}
+}
+
+// rewriteStockSummaries replaces every member of the keys matching pattern for which rewrite returns true with the
+// data it returns, atomically by replaceMemberScript. It returns the number of replaced members.
+func (repo *Repo) rewriteStockSummaries(ctx context.Context, pattern string,
+	rewrite func(data []byte) ([]byte, bool, error)) (int, error) {
+	keys, err := repo.scanKeys(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, key := range keys {
+		members, err := repo.redisClient.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return migrated, err
+		}
+
+		for _, member := range members {
+			data, _ := member.Member.(string)
+			rewritten, ok, err := rewrite([]byte(data))
+			if err != nil {
+				return migrated, err
+			}
+			if !ok {
+				continue
+			}
+
+			replaced, err := repo.redisClient.Eval(ctx, replaceMemberScript, []string{key},
+				data, member.Score, rewritten).Int()
+			if err != nil {
+				return migrated, err
+			}
+
+			migrated += replaced
+		}
+	}
+
+	return migrated, nil
+}
+
+// scanKeys returns every key matching pattern, scanning each master node when running against Redis Cluster
+func (repo *Repo) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	cluster, ok := repo.redisClient.(clusterScanner)
+	if !ok {
+		return scanNodeKeys(ctx, repo.redisClient, pattern)
+	}
+
+	var (
+		mutex sync.Mutex
+		keys  []string
+	)
+
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+		nodeKeys, err := scanNodeKeys(ctx, client, pattern)
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		keys = append(keys, nodeKeys...)
+		mutex.Unlock()
+
+		return nil
+	})
+
+	return keys, err
+}
+
+func scanNodeKeys(ctx context.Context, client RedisClient, pattern string) ([]string, error) {
+	var (
+		keys   []string
+		cursor uint64
+	)
+
+	for {
+		batch, nextCursor, err := client.Scan(ctx, cursor, pattern, scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, batch...)
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return keys, nil
+		}
+	}
+}
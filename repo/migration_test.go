@@ -0,0 +1,586 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/repo/_mock"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Repo_MigrateStockSummaries(t *testing.T) {
+	legacySummary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Time{}.AddDate(0, 0, 1),
+		Prev:      8000,
+		Open:      8050,
+		High:      8100,
+		Low:       7950,
+		Close:     8100,
+		Volume:    900,
+		Value:     7210000,
+		Average:   8011,
+	}
+	legacySummaryJSON, _ := json.Marshal(legacySummary)
+
+	migratedSummary := legacySummary
+	migratedSummary.Date = time.Time{}.AddDate(0, 0, 2)
+	migratedSummaryData := encodeSummary(migratedSummary)
+
+	// Stored by the baseline under an untagged key
+	baselineKey := "stocksummary-BBCA"
+	baselineDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	baselineJSON := `{"stock_code":"BBCA","date":"2023-08-29T00:00:00Z","prev":8000,"open":8050,"high":8100,` +
+		`"low":7950,"close":8100,"volume":900,"value":7210000,"average":8011}`
+	baselineSummary := legacySummary
+	baselineSummary.Date = baselineDate
+	baselineScore := &redis.ZRangeBy{Min: "1693267200", Max: "1693267200"}
+
+	// Written by the consumer after the upgrade, on the same day as the baseline summary
+	upgradedSummary := model.Summary{
+		StockCode: "BBCA", Date: baselineDate, Open: 8150, High: 8200, Low: 8100, Close: 8150,
+		Volume: 100, Value: 815000, Average: 8150, Trades: 1, VWAP: 8150,
+	}
+	mergedSummary := model.Summary{
+		StockCode: "BBCA", Date: baselineDate, Prev: 8000, Open: 8050, High: 8200, Low: 7950, Close: 8150,
+		Volume: 1000, Value: 8025000, Average: 8025, Trades: 1, VWAP: 8025,
+	}
+
+	noBaselineKeys := func(m *mock.MockRedisClient) {
+		m.EXPECT().Scan(gomock.Any(), uint64(0), legacyStockSummaryPattern, int64(scanCount)).
+			Return(redis.NewScanCmdResult([]string{}, 0, nil))
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse int
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					noBaselineKeys(m)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 1, nil))
+					m.EXPECT().Scan(gomock.Any(), uint64(1), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{}, 0, nil))
+
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{
+								Score:  float64(legacySummary.Date.Unix()),
+								Member: string(legacySummaryJSON),
+							},
+							{
+								Score:  float64(migratedSummary.Date.Unix()),
+								Member: string(migratedSummaryData),
+							},
+						}, nil))
+
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(legacySummaryJSON), float64(legacySummary.Date.Unix()), encodeSummary(legacySummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					return m
+				},
+			},
+			wantResponse: 1,
+		},
+		{
+			name: "success-member-rewritten-concurrently",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					noBaselineKeys(m)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{
+								Score:  float64(legacySummary.Date.Unix()),
+								Member: string(legacySummaryJSON),
+							},
+						}, nil))
+
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(legacySummaryJSON), float64(legacySummary.Date.Unix()), encodeSummary(legacySummary)).
+						Return(redis.NewCmdResult(int64(0), nil))
+
+					return m
+				},
+			},
+			wantResponse: 0,
+		},
+		{
+			name: "success-baseline-key",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), legacyStockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{baselineKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), baselineKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(baselineDate.Unix()), Member: baselineJSON},
+						}, nil))
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, baselineScore).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+					m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{baselineKey, expectedKey},
+						baselineJSON, float64(baselineDate.Unix()), "", encodeSummary(baselineSummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					// The moved member is already encoded
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(baselineDate.Unix()), Member: string(encodeSummary(baselineSummary))},
+						}, nil))
+
+					return m
+				},
+			},
+			wantResponse: 1,
+		},
+		{
+			name: "success-baseline-key-same-score",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), legacyStockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{baselineKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), baselineKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(baselineDate.Unix()), Member: baselineJSON},
+						}, nil))
+
+					// The consumer updates the day's summary after it was first read, so the merge is retried
+					gomock.InOrder(
+						m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, baselineScore).
+							Return(redis.NewStringSliceResult([]string{}, nil)),
+						m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{baselineKey, expectedKey},
+							baselineJSON, float64(baselineDate.Unix()), "", encodeSummary(baselineSummary)).
+							Return(redis.NewCmdResult(int64(-1), nil)),
+						m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, baselineScore).
+							Return(redis.NewStringSliceResult([]string{string(encodeSummary(upgradedSummary))}, nil)),
+						m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{baselineKey, expectedKey},
+							baselineJSON, float64(baselineDate.Unix()), string(encodeSummary(upgradedSummary)), encodeSummary(mergedSummary)).
+							Return(redis.NewCmdResult(int64(1), nil)),
+					)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(baselineDate.Unix()), Member: string(encodeSummary(mergedSummary))},
+						}, nil))
+
+					return m
+				},
+			},
+			wantResponse: 1,
+		},
+		{
+			name: "error-move-baseline-key",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), legacyStockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{baselineKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), baselineKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(baselineDate.Unix()), Member: baselineJSON},
+						}, nil))
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, baselineScore).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+					m.EXPECT().Eval(gomock.Any(), mergeMemberScript, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-scan",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), legacyStockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult(nil, 0, errors.New("error-scan")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-eval",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					noBaselineKeys(m)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{
+								Score:  float64(legacySummary.Date.Unix()),
+								Member: string(legacySummaryJSON),
+							},
+						}, nil))
+
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(legacySummaryJSON), float64(legacySummary.Date.Unix()), encodeSummary(legacySummary)).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.MigrateStockSummaries(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.MigrateStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("repo.MigrateStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_MigrateStockSummaryVWAP(t *testing.T) {
+	archiveKey := fmt.Sprintf(stockSummaryArchiveFmt, "BBCA")
+
+	summary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Time{}.AddDate(0, 0, 1),
+		Prev:      8000,
+		Open:      8050,
+		High:      8100,
+		Low:       7950,
+		Close:     8100,
+		Volume:    900,
+		Value:     7210000,
+		Average:   8011,
+	}
+	migratedSummary := summary
+	migratedSummary.VWAP, migratedSummary.VWAPScale = 80111111, 4
+
+	// Already migrated, or without trades
+	currentSummary := migratedSummary
+	currentSummary.Date = time.Time{}.AddDate(0, 0, 2)
+	prevOnlySummary := model.Summary{StockCode: "BBCA", Date: time.Time{}.AddDate(0, 0, 3), Prev: 8100}
+
+	monthlySummary := summary
+	monthlySummary.Date = time.Time{}.AddDate(0, 1, 0)
+	monthlySummary.VWAP = 8011
+	migratedMonthlySummary := monthlySummary
+	migratedMonthlySummary.VWAP, migratedMonthlySummary.VWAPScale = 80111111, 4
+
+	type args struct {
+		ctx       context.Context
+		precision int32
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse int
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:       context.Background(),
+				precision: 4,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(summary.Date.Unix()), Member: string(encodeSummary(summary))},
+							{Score: float64(currentSummary.Date.Unix()), Member: string(encodeSummary(currentSummary))},
+							{Score: float64(prevOnlySummary.Date.Unix()), Member: string(encodeSummary(prevOnlySummary))},
+						}, nil))
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(encodeSummary(summary)), float64(summary.Date.Unix()), encodeSummary(migratedSummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryArchivePattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{archiveKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), archiveKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(monthlySummary.Date.Unix()), Member: string(encodeSummary(monthlySummary))},
+						}, nil))
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{archiveKey},
+						string(encodeSummary(monthlySummary)), float64(monthlySummary.Date.Unix()), encodeSummary(migratedMonthlySummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					return m
+				},
+			},
+			wantResponse: 2,
+		},
+		{
+			name: "error-scan-archive",
+			args: args{
+				ctx:       context.Background(),
+				precision: 4,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(summary.Date.Unix()), Member: string(encodeSummary(summary))},
+						}, nil))
+					m.EXPECT().Eval(gomock.Any(), replaceMemberScript, []string{expectedKey},
+						string(encodeSummary(summary)), float64(summary.Date.Unix()), encodeSummary(migratedSummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryArchivePattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult(nil, 0, errors.New("error-scan")))
+
+					return m
+				},
+			},
+			wantResponse: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.MigrateStockSummaryVWAP(tt.args.ctx, tt.args.precision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.MigrateStockSummaryVWAP() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("repo.MigrateStockSummaryVWAP() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_MigrateStockSummaryDates(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	archiveKey := fmt.Sprintf(stockSummaryArchiveFmt, "BBCA")
+	utcKey := fmt.Sprintf(stockSummaryFmt, "AAPL")
+
+	summary := model.Summary{
+		StockCode:      "BBCA",
+		Date:           time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Close:          8100,
+		Volume:         900,
+		FirstTradeTime: time.Date(2023, 8, 29, 9, 0, 0, 0, time.UTC).UnixMilli(),
+		LastTradeTime:  time.Date(2023, 8, 29, 15, 0, 0, 0, time.UTC).UnixMilli(),
+	}
+	migratedSummary := summary
+	migratedSummary.Date = time.Date(2023, 8, 28, 17, 0, 0, 0, time.UTC)
+	migratedSummary.FirstTradeTime = time.Date(2023, 8, 29, 2, 0, 0, 0, time.UTC).UnixMilli()
+	migratedSummary.LastTradeTime = time.Date(2023, 8, 29, 8, 0, 0, 0, time.UTC).UnixMilli()
+
+	monthlySummary := model.Summary{StockCode: "BBCA", Date: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC), Close: 8100}
+	migratedMonthlySummary := monthlySummary
+	migratedMonthlySummary.Date = time.Date(2023, 7, 31, 17, 0, 0, 0, time.UTC)
+	migratedScore := &redis.ZRangeBy{Min: "1693242000", Max: "1693242000"}
+	migratedMonthlyScore := &redis.ZRangeBy{Min: "1690822800", Max: "1690822800"}
+
+	// Trading in UTC
+	utcSummary := model.Summary{StockCode: "AAPL", Date: time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC), Close: 180}
+
+	getLocation := func(stockCode string) *time.Location {
+		if stockCode == "AAPL" {
+			return time.UTC
+		}
+		return jakarta
+	}
+
+	type args struct {
+		ctx         context.Context
+		getLocation func(stockCode string) *time.Location
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse int
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:         context.Background(),
+				getLocation: getLocation,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey, utcKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(summary.Date.Unix()), Member: string(encodeSummary(summary))},
+							{Score: float64(migratedSummary.Date.Unix()), Member: string(encodeSummary(migratedSummary))},
+						}, nil))
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, migratedScore).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+					m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{expectedKey, expectedKey},
+						string(encodeSummary(summary)), float64(migratedSummary.Date.Unix()), "", encodeSummary(migratedSummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), utcKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(utcSummary.Date.Unix()), Member: string(encodeSummary(utcSummary))},
+						}, nil))
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryArchivePattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{archiveKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), archiveKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(monthlySummary.Date.Unix()), Member: string(encodeSummary(monthlySummary))},
+						}, nil))
+					m.EXPECT().ZRangeByScore(gomock.Any(), archiveKey, migratedMonthlyScore).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+					m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{archiveKey, archiveKey},
+						string(encodeSummary(monthlySummary)), float64(migratedMonthlySummary.Date.Unix()), "", encodeSummary(migratedMonthlySummary)).
+						Return(redis.NewCmdResult(int64(1), nil))
+
+					return m
+				},
+			},
+			wantResponse: 2,
+		},
+		{
+			name: "error-eval",
+			args: args{
+				ctx:         context.Background(),
+				getLocation: getLocation,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+					m.EXPECT().ZRangeWithScores(gomock.Any(), expectedKey, int64(0), int64(-1)).
+						Return(redis.NewZSliceCmdResult([]redis.Z{
+							{Score: float64(summary.Date.Unix()), Member: string(encodeSummary(summary))},
+						}, nil))
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, migratedScore).
+						Return(redis.NewStringSliceResult([]string{}, nil))
+					m.EXPECT().Eval(gomock.Any(), mergeMemberScript, []string{expectedKey, expectedKey},
+						string(encodeSummary(summary)), float64(migratedSummary.Date.Unix()), "", encodeSummary(migratedSummary)).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.MigrateStockSummaryDates(tt.args.ctx, tt.args.getLocation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.MigrateStockSummaryDates() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("repo.MigrateStockSummaryDates() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"stock/model"
+)
+
+// ClearStockSummaries removes the daily summaries of every stock dated fromDate or later, and their transaction
+// journals, so that they can be recomputed from scratch. fromDate's day starts at midnight in the timezone of each
+// stock, given by getLocation. A zero fromDate removes every daily summary. Monthly summaries archived by the retention
+// policy are kept, as their transactions may no longer be retained by Kafka.
+func (repo *Repo) ClearStockSummaries(ctx context.Context, fromDate time.Time, getLocation func(stockCode string) *time.Location) (model.ClearReport, error) {
+	report := model.ClearReport{FromDate: fromDate}
+
+	stockCodes, err := repo.GetStockCodes(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, stockCode := range stockCodes {
+		minScore := "-inf"
+		if !fromDate.IsZero() {
+			minScore = strconv.Itoa(int(model.TradingDate(fromDate, getLocation(stockCode)).Unix()))
+		}
+
+		cleared, err := repo.redisClient.ZRemRangeByScore(ctx, fmt.Sprintf(stockSummaryFmt, stockCode), minScore, "+inf").Result()
+		if err != nil {
+			return report, err
+		}
+
+		if cleared > 0 {
+			report.StockCodes = append(report.StockCodes, stockCode)
+			report.Rows += cleared
+		}
+	}
+
+	sort.Strings(report.StockCodes)
+
+	// Replayed transactions are journaled again
+	report.Journals, err = repo.clearTransactions(ctx, fromDate, getLocation)
+	if err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,187 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/repo/_mock"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Repo_ClearStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	fromScore := strconv.Itoa(int(fromDate.Unix()))
+
+	// BBRI trades in Jakarta, where the day starts 7 hours earlier
+	jakarta, err := model.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("model.LoadLocation() err = %v", err)
+	}
+	jakartaFromScore := strconv.Itoa(int(time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta).Unix()))
+	getLocation := func(stockCode string) *time.Location {
+		if stockCode == "BBRI" {
+			return jakarta
+		}
+		return time.UTC
+	}
+
+	type args struct {
+		ctx      context.Context
+		fromDate time.Time
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse model.ClearReport
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{"stocksummary-{BBRI}", expectedKey, "stocksummary-{TLKM}"}, 0, nil))
+
+					// Archived summaries are kept
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), expectedKey, fromScore, "+inf").
+						Return(redis.NewIntResult(3, nil))
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), "stocksummary-{BBRI}", jakartaFromScore, "+inf").
+						Return(redis.NewIntResult(2, nil))
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), "stocksummary-{TLKM}", fromScore, "+inf").
+						Return(redis.NewIntResult(0, nil))
+
+					// The day before fromDate is kept
+					m.EXPECT().Scan(gomock.Any(), uint64(0), transactionJournalPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{
+							"transactions-{BBCA}-" + strconv.Itoa(int(fromDate.AddDate(0, 0, -1).Unix())),
+							"transactions-{BBCA}-" + fromScore,
+							"transactions-{BBRI}-" + jakartaFromScore,
+						}, 0, nil))
+					m.EXPECT().Del(gomock.Any(), "transactions-{BBCA}-"+fromScore, "transactionids-{BBCA}-"+fromScore).
+						Return(redis.NewIntResult(2, nil))
+					m.EXPECT().Del(gomock.Any(), "transactions-{BBRI}-"+jakartaFromScore, "transactionids-{BBRI}-"+jakartaFromScore).
+						Return(redis.NewIntResult(1, nil))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{
+				FromDate:   fromDate,
+				Rows:       5,
+				StockCodes: []string{"BBCA", "BBRI"},
+				Journals:   2,
+			},
+		},
+		{
+			name: "success-every-date",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), expectedKey, "-inf", "+inf").
+						Return(redis.NewIntResult(10, nil))
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), transactionJournalPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{"transactions-{BBCA}-0"}, 0, nil))
+					m.EXPECT().Del(gomock.Any(), "transactions-{BBCA}-0", "transactionids-{BBCA}-0").Return(redis.NewIntResult(2, nil))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{
+				Rows:       10,
+				StockCodes: []string{"BBCA"},
+				Journals:   1,
+			},
+		},
+		{
+			name: "error-scan",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult(nil, 0, errors.New("error-scan")))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate},
+			wantErr:      true,
+		},
+		{
+			name: "error-zremrangebyscore",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey}, 0, nil))
+
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), expectedKey, fromScore, "+inf").
+						Return(redis.NewIntResult(0, errors.New("error-zremrangebyscore")))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.ClearStockSummaries(tt.args.ctx, tt.args.fromDate, getLocation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.ClearStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("repo.ClearStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"stock/model"
+)
+
+const (
+	stockSummaryArchiveFmt     = "stocksummaryarchive-{%s}"
+	stockSummaryArchivePattern = "stocksummaryarchive-{*}"
+
+	// archiveSummaryScript replaces the archived summary at score ARGV[1] of KEYS[1] with ARGV[2],
+	// then removes the daily summaries of KEYS[2] scored between ARGV[3] and ARGV[4].
+	// Both keys share the stock code hash tag, so they live in the same Redis Cluster slot.
+	archiveSummaryScript = `
+redis.call("ZREMRANGEBYSCORE", KEYS[1], ARGV[1], ARGV[1])
+redis.call("ZADD", KEYS[1], ARGV[1], ARGV[2])
+return redis.call("ZREMRANGEBYSCORE", KEYS[2], ARGV[3], ARGV[4])`
+)
+
+// GetStockCodes returns the code of every stock that has daily stock summaries
+func (repo *Repo) GetStockCodes(ctx context.Context) ([]string, error) {
+	keys, err := repo.scanKeys(ctx, stockSummaryPattern)
+	if err != nil {
+		return []string{}, err
+	}
+
+	stockCodePrefix, stockCodeSuffix, _ := strings.Cut(stockSummaryFmt, "%s")
+
+	stockCodes := []string{}
+	for _, key := range keys {
+		stockCode := strings.TrimSuffix(strings.TrimPrefix(key, stockCodePrefix), stockCodeSuffix)
+		stockCodes = append(stockCodes, stockCode)
+	}
+
+	return stockCodes, nil
+}
+
+// GetArchivedStockSummary gets the monthly stock summaries archived by the retention policy for the requested date range.
+// Archived summaries are scored by the unix value of the first day of their month.
+func (repo *Repo) GetArchivedStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	summaries, err := repo.getSummaries(ctx, fmt.Sprintf(stockSummaryArchiveFmt, request.StockCode), request)
+	for i := range summaries {
+		summaries[i].Monthly = true
+	}
+
+	return summaries, err
+}
+
+// ArchiveStockSummary atomically upserts an archived (monthly) stock summary and removes the daily stock summaries
+// between fromDate (inclusive) and toDate (inclusive) that were downsampled into it, using archiveSummaryScript
+func (repo *Repo) ArchiveStockSummary(ctx context.Context, archivedSummary model.Summary, request model.GetStockSummaryRequest) error {
+	archiveKey := fmt.Sprintf(stockSummaryArchiveFmt, archivedSummary.StockCode)
+	key := fmt.Sprintf(stockSummaryFmt, archivedSummary.StockCode)
+
+	return repo.redisClient.Eval(ctx, archiveSummaryScript, []string{archiveKey, key},
+		strconv.Itoa(int(archivedSummary.Date.Unix())),
+		encodeSummary(archivedSummary),
+		strconv.Itoa(int(request.FromDate.Unix())),
+		strconv.Itoa(int(request.ToDate.Unix())),
+	).Err()
+}
@@ -0,0 +1,270 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/repo/_mock"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Repo_GetStockCodes(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []string
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult([]string{expectedKey, "stocksummary-{BBRI}"}, 0, nil))
+
+					return m
+				},
+			},
+			wantResponse: []string{"BBCA", "BBRI"},
+		},
+		{
+			name: "error-scan",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Scan(gomock.Any(), uint64(0), stockSummaryPattern, int64(scanCount)).
+						Return(redis.NewScanCmdResult(nil, 0, errors.New("error-scan")))
+
+					return m
+				},
+			},
+			wantResponse: []string{},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.GetStockCodes(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.GetStockCodes() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("repo.GetStockCodes() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_GetArchivedStockSummary(t *testing.T) {
+	month := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	monthlySummary := model.Summary{StockCode: "BBCA", Date: month, Open: 8050, Close: 8100, Volume: 900}
+
+	type args struct {
+		ctx   context.Context
+		input model.GetStockSummaryRequest
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []model.Summary
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   context.Background(),
+				input: model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: month, ToDate: month},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRangeByScore(gomock.Any(), "stocksummaryarchive-{BBCA}", &redis.ZRangeBy{
+						Min: strconv.Itoa(int(month.Unix())),
+						Max: strconv.Itoa(int(month.Unix())),
+					}).Return(redis.NewStringSliceResult([]string{string(encodeSummary(monthlySummary))}, nil))
+					return m
+				},
+			},
+			wantResponse: []model.Summary{
+				{StockCode: "BBCA", Date: month, Open: 8050, Close: 8100, Volume: 900, Monthly: true},
+			},
+		},
+		{
+			name: "error-zrangebyscore",
+			args: args{
+				ctx:   context.Background(),
+				input: model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: month, ToDate: month},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRangeByScore(gomock.Any(), "stocksummaryarchive-{BBCA}", gomock.Any()).
+						Return(redis.NewStringSliceResult(nil, errors.New("error-zrangebyscore")))
+					return m
+				},
+			},
+			wantResponse: []model.Summary{},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.GetArchivedStockSummary(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.GetArchivedStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("repo.GetArchivedStockSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_ArchiveStockSummary(t *testing.T) {
+	var (
+		month    = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+		fromDate = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+		toDate   = time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	archivedSummary := model.Summary{
+		StockCode: "BBCA",
+		Date:      month,
+		Prev:      8050,
+		Open:      8050,
+		High:      8200,
+		Low:       8000,
+		Close:     8000,
+		Volume:    400,
+		Value:     3230000,
+		Average:   8075,
+	}
+
+	type args struct {
+		ctx             context.Context
+		archivedSummary model.Summary
+		request         model.GetStockSummaryRequest
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:             context.Background(),
+				archivedSummary: archivedSummary,
+				request: model.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  fromDate,
+					ToDate:    toDate,
+				},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Eval(gomock.Any(), archiveSummaryScript, []string{"stocksummaryarchive-{BBCA}", expectedKey},
+						strconv.Itoa(int(month.Unix())), encodeSummary(archivedSummary),
+						strconv.Itoa(int(fromDate.Unix())), strconv.Itoa(int(toDate.Unix()))).
+						Return(redis.NewCmdResult(int64(21), nil))
+
+					return m
+				},
+			},
+		},
+		{
+			name: "error-eval",
+			args: args{
+				ctx:             context.Background(),
+				archivedSummary: archivedSummary,
+				request: model.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  fromDate,
+					ToDate:    toDate,
+				},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+
+					m.EXPECT().Eval(gomock.Any(), archiveSummaryScript, gomock.Any(), gomock.Any()).
+						Return(redis.NewCmdResult(nil, errors.New("error-eval")))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			err := repo.ArchiveStockSummary(tt.args.ctx, tt.args.archivedSummary, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.ArchiveStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ package repo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -17,7 +16,9 @@ import (
 )
 
 const (
-	stockSummaryFmt = "stocksummary-%s"
+	// The stock code is wrapped in a hash tag so that every key of a stock is stored in the same Redis Cluster slot
+	stockSummaryFmt     = "stocksummary-{%s}"
+	stockSummaryPattern = "stocksummary-{*}"
 )
 
 // GetStockSummary gets stock summary data for stockCode for the requested date range by performing ZRangeByScore:
@@ -28,14 +29,31 @@ const (
 // To get a stock's summary for a single date, specify the same fromDate (inclusive) and toDate (inclusive).
 // To get a stock's summary over a period of time, specify a fromDate value that is less than toDate.
 func (repo *Repo) GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) (result []model.Summary, err error) {
-	key := fmt.Sprintf(stockSummaryFmt, request.StockCode)
+	return repo.getSummaries(ctx, fmt.Sprintf(stockSummaryFmt, request.StockCode), request)
+}
+
+// GetStockSummaryPage gets at most count of the stock summaries for the requested date range, skipping the first
+// offset summaries, so that long date ranges can be read one page at a time
+func (repo *Repo) GetStockSummaryPage(ctx context.Context, request model.GetStockSummaryRequest, offset, count int64) (result []model.Summary, err error) {
+	return repo.getSummaryPage(ctx, fmt.Sprintf(stockSummaryFmt, request.StockCode), request, offset, count)
+}
 
+// getSummaries gets the summaries stored in the sorted set key for the requested date range
+func (repo *Repo) getSummaries(ctx context.Context, key string, request model.GetStockSummaryRequest) (result []model.Summary, err error) {
+	return repo.getSummaryPage(ctx, key, request, 0, 0)
+}
+
+// getSummaryPage gets a page of the summaries stored in the sorted set key for the requested date range.
+// A zero offset and count gets every summary.
+func (repo *Repo) getSummaryPage(ctx context.Context, key string, request model.GetStockSummaryRequest, offset, count int64) (result []model.Summary, err error) {
 	fromDateUnix := request.FromDate.Unix()
 	toDateUnix := request.ToDate.Unix()
 
 	redisResult, err := repo.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{
-		Min: strconv.Itoa(int(fromDateUnix)),
-		Max: strconv.Itoa(int(toDateUnix)),
+		Min:    strconv.Itoa(int(fromDateUnix)),
+		Max:    strconv.Itoa(int(toDateUnix)),
+		Offset: offset,
+		Count:  count,
 	}).Result()
 	if err != nil {
 		return []model.Summary{}, err
@@ -43,8 +61,7 @@ func (repo *Repo) GetStockSummary(ctx context.Context, request model.GetStockSum
 
 	result = []model.Summary{}
 	for _, data := range redisResult {
-		summary := model.Summary{}
-		err = json.Unmarshal([]byte(data), &summary)
+		summary, err := decodeSummary([]byte(data))
 		if err != nil {
 			return []model.Summary{}, err
 		}
@@ -58,7 +75,7 @@ func (repo *Repo) GetStockSummary(ctx context.Context, request model.GetStockSum
 // UpdateStockSummary upserts stock summary data for a stockCode on a given date by performing the following Redis operations:
 // 1. ZRangeByScore to check existing stock summary for stockCode (key) on a given date (same min & max score). Score is unix value of the summary date.
 // 2. ZRem to remove any data being returned by ZRangeByScore.
-// 3. ZADd to store the stock summary for stockCode (key) for the given date (score).
+// 3. ZADd to store the stock summary for stockCode (key) for the given date (score), using the compact encoding.
 // This ensures a stockCode to have exactly 1 stock summary per date (score).
 func (repo *Repo) UpdateStockSummary(ctx context.Context, summary model.Summary) error {
 	key := fmt.Sprintf(stockSummaryFmt, summary.StockCode)
@@ -82,10 +99,7 @@ func (repo *Repo) UpdateStockSummary(ctx context.Context, summary model.Summary)
 		}
 	}
 
-	value, err := json.Marshal(summary)
-	if err != nil {
-		return err
-	}
+	value := encodeSummary(summary)
 
 	err = repo.redisClient.ZAdd(ctx, key, &redis.Z{
 		Score:  float64(dateUnix),
@@ -101,3 +115,14 @@ func (repo *Repo) UpdateStockSummary(ctx context.Context, summary model.Summary)
 
 	return err
 }
+
+// DeleteStockSummaries removes the stock summaries for the requested date range by performing ZRemRangeByScore,
+// with the same scores as GetStockSummary, and returns how many summaries were removed
+func (repo *Repo) DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) (deleted int64, err error) {
+	key := fmt.Sprintf(stockSummaryFmt, request.StockCode)
+
+	return repo.redisClient.ZRemRangeByScore(ctx, key,
+		strconv.Itoa(int(request.FromDate.Unix())),
+		strconv.Itoa(int(request.ToDate.Unix())),
+	).Result()
+}
@@ -22,7 +22,7 @@ import (
 )
 
 const (
-	expectedKey = "stocksummary-BBCA"
+	expectedKey = "stocksummary-{BBCA}"
 )
 
 func Test_Repo_GetStockSummary(t *testing.T) {
@@ -84,9 +84,9 @@ func Test_Repo_GetStockSummary(t *testing.T) {
 						Value:     7210000,
 						Average:   8011,
 					}
-					expectedSummaryTwoJSON, _ := json.Marshal(expectedSummaryTwo)
+					expectedSummaryTwoData := encodeSummary(expectedSummaryTwo)
 
-					expectedResult := []string{string(expectedSummaryOneJSON), string(expectedSummaryTwoJSON)}
+					expectedResult := []string{string(expectedSummaryOneJSON), string(expectedSummaryTwoData)}
 
 					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, &redis.ZRangeBy{
 						Min: strconv.Itoa(int(fromDate.Unix())),
@@ -246,11 +246,11 @@ func Test_Repo_UpdateStockSummary(t *testing.T) {
 						Value:     99999999,
 						Average:   9999,
 					}
-					expectedNewSummaryJSON, _ := json.Marshal(expectedNewSummary)
+					expectedNewSummaryData := encodeSummary(expectedNewSummary)
 					expectedMembers := []*redis.Z{
 						{
 							Score:  float64(expectedDate.Unix()),
-							Member: expectedNewSummaryJSON,
+							Member: expectedNewSummaryData,
 						},
 					}
 
@@ -300,11 +300,11 @@ func Test_Repo_UpdateStockSummary(t *testing.T) {
 						Value:     99999999,
 						Average:   9999,
 					}
-					expectedNewSummaryJSON, _ := json.Marshal(expectedNewSummary)
+					expectedNewSummaryData := encodeSummary(expectedNewSummary)
 					expectedMembers := []*redis.Z{
 						{
 							Score:  float64(expectedDate.Unix()),
-							Member: expectedNewSummaryJSON,
+							Member: expectedNewSummaryData,
 						},
 					}
 
@@ -454,11 +454,11 @@ func Test_Repo_UpdateStockSummary(t *testing.T) {
 						Value:     99999999,
 						Average:   9999,
 					}
-					expectedNewSummaryJSON, _ := json.Marshal(expectedNewSummary)
+					expectedNewSummaryData := encodeSummary(expectedNewSummary)
 					expectedMembers := []*redis.Z{
 						{
 							Score:  float64(expectedDate.Unix()),
-							Member: expectedNewSummaryJSON,
+							Member: expectedNewSummaryData,
 						},
 					}
 					m.EXPECT().ZAdd(gomock.Any(), expectedKey, expectedMembers).Return(redis.NewIntResult(int64(0), errors.New("error-zadd")))
@@ -493,3 +493,175 @@ func Test_Repo_UpdateStockSummary(t *testing.T) {
 		})
 	}
 }
+
+func Test_Repo_GetStockSummaryPage(t *testing.T) {
+	fromDate := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	summary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Prev:      9000,
+		Open:      9025,
+		High:      9100,
+		Low:       9000,
+		Close:     9050,
+		Volume:    100,
+		Value:     905000,
+		Average:   9050,
+	}
+
+	type args struct {
+		ctx    context.Context
+		input  model.GetStockSummaryRequest
+		offset int64
+		count  int64
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []model.Summary
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:    context.Background(),
+				input:  model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate},
+				offset: 500,
+				count:  500,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, &redis.ZRangeBy{
+						Min:    strconv.Itoa(int(fromDate.Unix())),
+						Max:    strconv.Itoa(int(toDate.Unix())),
+						Offset: 500,
+						Count:  500,
+					}).Return(redis.NewStringSliceResult([]string{string(encodeSummary(summary))}, nil))
+					return m
+				},
+			},
+			wantResponse: []model.Summary{summary},
+		},
+		{
+			name: "error-zrangebyscore",
+			args: args{
+				ctx:    context.Background(),
+				input:  model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate},
+				offset: 0,
+				count:  500,
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRangeByScore(gomock.Any(), expectedKey, gomock.Any()).
+						Return(redis.NewStringSliceResult(nil, errors.New("error-zrangebyscore")))
+					return m
+				},
+			},
+			wantResponse: []model.Summary{},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.GetStockSummaryPage(tt.args.ctx, tt.args.input, tt.args.offset, tt.args.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.GetStockSummaryPage() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("repo.GetStockSummaryPage() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Repo_DeleteStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx   context.Context
+		input model.GetStockSummaryRequest
+	}
+	type fields struct {
+		redisClient func(ctrl *gomock.Controller) RedisClient
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse int64
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   context.Background(),
+				input: model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), expectedKey, strconv.Itoa(int(fromDate.Unix())), strconv.Itoa(int(toDate.Unix()))).
+						Return(redis.NewIntResult(2, nil))
+					return m
+				},
+			},
+			wantResponse: 2,
+		},
+		{
+			name: "error-zremrangebyscore",
+			args: args{
+				ctx:   context.Background(),
+				input: model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate},
+			},
+			fields: fields{
+				redisClient: func(ctrl *gomock.Controller) RedisClient {
+					m := mock.NewMockRedisClient(ctrl)
+					m.EXPECT().ZRemRangeByScore(gomock.Any(), expectedKey, gomock.Any(), gomock.Any()).
+						Return(redis.NewIntResult(0, errors.New("error-zremrangebyscore")))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := &Repo{
+				redisClient: tt.fields.redisClient(ctrl),
+			}
+
+			gotResponse, err := repo.DeleteStockSummaries(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repo.DeleteStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if gotResponse != tt.wantResponse {
+				t.Errorf("repo.DeleteStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
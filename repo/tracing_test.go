@@ -0,0 +1,89 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_tracingHook(t *testing.T) {
+	type span struct {
+		name   string
+		status codes.Code
+	}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, hook tracingHook)
+		want []span
+	}{
+		{
+			name: "success-command",
+			run: func(ctx context.Context, hook tracingHook) {
+				cmd := redis.NewStringSliceCmd(ctx, "zrangebyscore", "BBCA", "20230829", "20230829")
+				ctx, _ = hook.BeforeProcess(ctx, cmd)
+				_ = hook.AfterProcess(ctx, cmd)
+			},
+			want: []span{{name: "zrangebyscore", status: codes.Unset}},
+		},
+		{
+			name: "success-command-nil",
+			run: func(ctx context.Context, hook tracingHook) {
+				cmd := redis.NewStringCmd(ctx, "get", "BBCA")
+				cmd.SetErr(redis.Nil)
+				ctx, _ = hook.BeforeProcess(ctx, cmd)
+				_ = hook.AfterProcess(ctx, cmd)
+			},
+			want: []span{{name: "get", status: codes.Unset}},
+		},
+		{
+			name: "error-pipeline",
+			run: func(ctx context.Context, hook tracingHook) {
+				cmds := []redis.Cmder{
+					redis.NewIntCmd(ctx, "zadd", "BBCA", 20230829, "summary"),
+					redis.NewIntCmd(ctx, "zremrangebyscore", "BBCA", "-inf", "20230101"),
+				}
+				cmds[1].SetErr(errors.New("error-redis"))
+				ctx, _ = hook.BeforeProcessPipeline(ctx, cmds)
+				_ = hook.AfterProcessPipeline(ctx, cmds)
+			},
+			want: []span{{name: "pipeline", status: codes.Error}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer func() { _ = tracerProvider.Shutdown(context.Background()) }()
+
+			ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+			tt.run(ctx, tracingHook{tracer: tracerProvider.Tracer("stock/repo")})
+			parent.End()
+
+			var got []span
+			for _, stub := range exporter.GetSpans() {
+				if stub.Name == "parent" {
+					continue
+				}
+				if stub.Parent.SpanID() != parent.SpanContext().SpanID() {
+					t.Errorf("tracingHook span %s parent = %v, want %v", stub.Name, stub.Parent.SpanID(), parent.SpanContext().SpanID())
+				}
+				got = append(got, span{name: stub.Name, status: stub.Status.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tracingHook spans = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"stock/model"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	apiKeyHeader        = "x-api-key"
+	bearerPrefix        = "bearer "
+
+	// adminServicePrefix prefixes the methods of the admin service, which clients are only allowed to call when their
+	// policy lists them
+	adminServicePrefix = "/proto.StockAdmin/"
+)
+
+// authenticator authenticates GRPC calls with a bearer JWT or an API key, then authorizes them against the
+// caller's client policy. The caller's identity is passed to handlers through the context.
+type authenticator struct {
+	apiKeys    []model.APIKey
+	clients    map[string]model.ClientPolicy
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey // By key ID
+	parser     *jwt.Parser
+}
+
+// stockCodeRequest is implemented by requests for a single stock's data
+type stockCodeRequest interface {
+	GetStockCode() string
+}
+
+// stockCodesRequest is implemented by requests for the data of many stocks, or of every stock when none is listed
+type stockCodesRequest interface {
+	GetStockCodes() []string
+}
+
+func newAuthenticator(cfg model.Auth) (*authenticator, error) {
+	auth := &authenticator{
+		apiKeys:    cfg.APIKeys,
+		clients:    map[string]model.ClientPolicy{},
+		hmacSecret: []byte(cfg.JWT.HS256Secret),
+		rsaKeys:    map[string]*rsa.PublicKey{},
+	}
+
+	for _, client := range cfg.Clients {
+		auth.clients[client.ClientID] = client
+	}
+
+	if cfg.JWT.JWKSFile != "" {
+		rsaKeys, err := loadJWKS(cfg.JWT.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		auth.rsaKeys = rsaKeys
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JWT.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+	if cfg.JWT.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(cfg.JWT.Audience))
+	}
+	auth.parser = jwt.NewParser(parserOptions...)
+
+	return auth, nil
+}
+
+func (auth *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	identity, err := auth.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.authorize(identity, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+
+	return handler(model.ContextWithIdentity(ctx, identity), req)
+}
+
+func (auth *authenticator) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	identity, err := auth.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	if err := auth.authorize(identity, info.FullMethod, nil); err != nil {
+		return err
+	}
+
+	return handler(srv, &authorizedStream{
+		ServerStream: stream,
+		ctx:          model.ContextWithIdentity(stream.Context(), identity),
+		auth:         auth,
+		identity:     identity,
+		method:       info.FullMethod,
+	})
+}
+
+// authenticate returns the identity of the caller from the call's metadata, or an Unauthenticated error
+func (auth *authenticator) authenticate(ctx context.Context) (model.Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(authorizationHeader); len(values) > 0 {
+		if len(values[0]) <= len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+			return model.Identity{}, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+		}
+
+		clientID, err := auth.verifyJWT(values[0][len(bearerPrefix):])
+		if err != nil {
+			return model.Identity{}, status.Errorf(codes.Unauthenticated, "invalid bearer token: %v", err)
+		}
+
+		return model.Identity{ClientID: clientID, Method: model.AuthMethodJWT}, nil
+	}
+
+	if values := md.Get(apiKeyHeader); len(values) > 0 {
+		for _, apiKey := range auth.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(apiKey.Key), []byte(values[0])) == 1 {
+				return model.Identity{ClientID: apiKey.ClientID, Method: model.AuthMethodAPIKey}, nil
+			}
+		}
+
+		return model.Identity{}, status.Error(codes.Unauthenticated, "invalid api key")
+	}
+
+	return model.Identity{}, status.Error(codes.Unauthenticated, "missing bearer token or api key")
+}
+
+// verifyJWT verifies token's signature and claims, and returns its subject
+func (auth *authenticator) verifyJWT(token string) (string, error) {
+	claims := jwt.RegisteredClaims{}
+	_, err := auth.parser.ParseWithClaims(token, &claims, auth.getVerificationKey)
+	if err != nil {
+		return "", err
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return claims.Subject, nil
+}
+
+func (auth *authenticator) getVerificationKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if len(auth.hmacSecret) == 0 {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return auth.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		keyID, _ := token.Header["kid"].(string)
+		if key, ok := auth.rsaKeys[keyID]; ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown key id %q", keyID)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// authorize checks the caller's client policy for the called method and, when known, the requested stock code
+func (auth *authenticator) authorize(identity model.Identity, method string, req interface{}) error {
+	client, ok := auth.clients[identity.ClientID]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "client %s has no access policy", identity.ClientID)
+	}
+
+	if len(client.AllowedMethods) == 0 && strings.HasPrefix(method, adminServicePrefix) {
+		return status.Errorf(codes.PermissionDenied, "client %s is not allowed to call admin method %s", identity.ClientID, method)
+	}
+
+	if !isAllowed(client.AllowedMethods, method, matchMethod) {
+		return status.Errorf(codes.PermissionDenied, "client %s is not allowed to call %s", identity.ClientID, method)
+	}
+
+	var stockCodes []string
+	switch request := req.(type) {
+	case stockCodeRequest:
+		stockCodes = []string{request.GetStockCode()}
+	case stockCodesRequest:
+		stockCodes = request.GetStockCodes()
+		if len(stockCodes) == 0 && len(client.AllowedStockCodes) > 0 {
+			return status.Errorf(codes.PermissionDenied, "client %s is not allowed to access every stock", identity.ClientID)
+		}
+	}
+
+	for _, stockCode := range stockCodes {
+		if !isAllowed(client.AllowedStockCodes, stockCode, strings.EqualFold) {
+			return status.Errorf(codes.PermissionDenied, "client %s is not allowed to access stock %s",
+				identity.ClientID, stockCode)
+		}
+	}
+
+	return nil
+}
+
+// isAllowed checks whether value matches an entry of allowList; an empty allowList allows every value
+func isAllowed(allowList []string, value string, match func(pattern, value string) bool) bool {
+	if len(allowList) == 0 {
+		return true
+	}
+
+	for _, pattern := range allowList {
+		if match(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchMethod matches a full GRPC method name against a method name or a "/package.Service/*" pattern
+func matchMethod(pattern, method string) bool {
+	if servicePrefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(method, servicePrefix)
+	}
+	return pattern == method
+}
+
+// authorizedStream authorizes every message received on a stream against the caller's client policy
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	auth     *authenticator
+	identity model.Identity
+	method   string
+}
+
+func (stream *authorizedStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *authorizedStream) RecvMsg(m interface{}) error {
+	if err := stream.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return stream.auth.authorize(stream.identity, stream.method, m)
+}
+
+// loadJWKS reads the RSA public keys of a JSON Web Key Set file
+func loadJWKS(file string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	rsaKeys := map[string]*rsa.PublicKey{}
+	for _, key := range jwks.Keys {
+		if key.KeyType != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %s: %v", key.KeyID, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %s: %v", key.KeyID, err)
+		}
+
+		rsaKeys[key.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(rsaKeys) == 0 {
+		return nil, fmt.Errorf("no RSA key found in %s", file)
+	}
+
+	return rsaKeys, nil
+}
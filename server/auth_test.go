@@ -0,0 +1,329 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"stock/model"
+	"stock/proto"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testHS256Secret = "test-secret"
+
+func signTestJWT(t *testing.T, method jwt.SigningMethod, key interface{}, keyID string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("token.SignedString() err = %v", err)
+	}
+	return signed
+}
+
+func writeTestJWKS(t *testing.T, dir, keyID string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+
+	file := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	return file
+}
+
+func Test_authenticator_unaryInterceptor(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v", err)
+	}
+
+	auth, err := newAuthenticator(model.Auth{
+		Enabled: true,
+		JWT: model.JWT{
+			HS256Secret: testHS256Secret,
+			JWKSFile:    writeTestJWKS(t, t.TempDir(), "key-1", &rsaKey.PublicKey),
+			Issuer:      "stock-issuer",
+		},
+		APIKeys: []model.APIKey{
+			{Key: "key-dashboard", ClientID: "dashboard"},
+			{Key: "key-unlisted", ClientID: "unlisted"},
+		},
+		Clients: []model.ClientPolicy{
+			{ClientID: "dashboard", AllowedStockCodes: []string{"BBCA"}},
+			{ClientID: "reporting", AllowedMethods: []string{"/proto.Stock/*"}},
+			{ClientID: "admin-only", AllowedMethods: []string{"/proto.StockAdmin/*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newAuthenticator() err = %v", err)
+	}
+
+	validClaims := func(subject string) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "stock-issuer",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+	}
+
+	expiredClaims := validClaims("reporting")
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuerClaims := validClaims("reporting")
+	wrongIssuerClaims.Issuer = "other-issuer"
+
+	otherRSAKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	tests := []struct {
+		name     string
+		metadata metadata.MD
+		req      *proto.GetStockSummaryRequest
+
+		wantIdentity model.Identity
+		wantCode     codes.Code
+	}{
+		{
+			name: "success-hs256",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodHS256, []byte(testHS256Secret), "", validClaims("reporting"))),
+			req:          &proto.GetStockSummaryRequest{StockCode: "TLKM"},
+			wantIdentity: model.Identity{ClientID: "reporting", Method: model.AuthMethodJWT},
+		},
+		{
+			name: "success-rs256",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodRS256, rsaKey, "key-1", validClaims("reporting"))),
+			req:          &proto.GetStockSummaryRequest{StockCode: "TLKM"},
+			wantIdentity: model.Identity{ClientID: "reporting", Method: model.AuthMethodJWT},
+		},
+		{
+			name:         "success-api-key",
+			metadata:     metadata.Pairs(apiKeyHeader, "key-dashboard"),
+			req:          &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantIdentity: model.Identity{ClientID: "dashboard", Method: model.AuthMethodAPIKey},
+		},
+		{
+			name:     "error-missing-credentials",
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "error-invalid-api-key",
+			metadata: metadata.Pairs(apiKeyHeader, "key-invalid"),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "error-not-bearer",
+			metadata: metadata.Pairs(authorizationHeader, "Basic dXNlcjpwYXNz"),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "error-expired-token",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodHS256, []byte(testHS256Secret), "", expiredClaims)),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "error-wrong-issuer",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodHS256, []byte(testHS256Secret), "", wrongIssuerClaims)),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "error-wrong-signature",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodHS256, []byte("other-secret"), "", validClaims("reporting"))),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "error-unknown-key-id",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodRS256, otherRSAKey, "key-2", validClaims("reporting"))),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "error-client-without-policy",
+			metadata: metadata.Pairs(apiKeyHeader, "key-unlisted"),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "error-stock-code-not-allowed",
+			metadata: metadata.Pairs(apiKeyHeader, "key-dashboard"),
+			req:      &proto.GetStockSummaryRequest{StockCode: "TLKM"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "error-method-not-allowed",
+			metadata: metadata.Pairs(authorizationHeader,
+				"Bearer "+signTestJWT(t, jwt.SigningMethodHS256, []byte(testHS256Secret), "", validClaims("admin-only"))),
+			req:      &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.metadata)
+
+			var gotIdentity model.Identity
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotIdentity, _ = model.IdentityFromContext(ctx)
+				return &proto.GetStockSummaryResponse{}, nil
+			}
+
+			_, err := auth.unaryInterceptor(ctx, tt.req,
+				&grpc.UnaryServerInfo{FullMethod: proto.Stock_GetStockSummary_FullMethodName}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("authenticator.unaryInterceptor() err = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if gotIdentity != tt.wantIdentity {
+				t.Errorf("authenticator.unaryInterceptor() gotIdentity = %v, wantIdentity %v", gotIdentity, tt.wantIdentity)
+			}
+		})
+	}
+}
+
+func Test_newAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+
+	emptyJWKS := filepath.Join(dir, "empty.json")
+	_ = os.WriteFile(emptyJWKS, []byte(`{"keys":[]}`), 0o600)
+
+	tests := []struct {
+		name    string
+		cfg     model.Auth
+		wantErr bool
+	}{
+		{
+			name: "success-hs256-only",
+			cfg:  model.Auth{JWT: model.JWT{HS256Secret: testHS256Secret}},
+		},
+		{
+			name:    "error-missing-jwks-file",
+			cfg:     model.Auth{JWT: model.JWT{JWKSFile: filepath.Join(dir, "missing.json")}},
+			wantErr: true,
+		},
+		{
+			name:    "error-jwks-without-rsa-key",
+			cfg:     model.Auth{JWT: model.JWT{JWKSFile: emptyJWKS}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newAuthenticator(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newAuthenticator() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authenticator_authorize(t *testing.T) {
+	auth, err := newAuthenticator(model.Auth{
+		Clients: []model.ClientPolicy{
+			{ClientID: "dashboard", AllowedStockCodes: []string{"BBCA", "TLKM"}},
+			{ClientID: "reporting"},
+			{ClientID: "ops", AllowedMethods: []string{"/proto.StockAdmin/*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newAuthenticator() err = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		clientID string
+		method   string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{
+			name:     "success-allowed-stock-codes",
+			clientID: "dashboard",
+			method:   proto.Stock_ExportStockSummaries_FullMethodName,
+			req:      &proto.ExportStockSummariesRequest{StockCodes: []string{"BBCA", "TLKM"}},
+		},
+		{
+			name:     "success-every-stock-unrestricted",
+			clientID: "reporting",
+			method:   proto.Stock_ExportStockSummaries_FullMethodName,
+			req:      &proto.ExportStockSummariesRequest{},
+		},
+		{
+			name:     "success-admin-method-listed",
+			clientID: "ops",
+			method:   proto.StockAdmin_OverrideStockSummary_FullMethodName,
+			req:      &proto.OverrideStockSummaryRequest{StockCode: "BBCA"},
+		},
+		{
+			name:     "error-admin-method-unlisted",
+			clientID: "reporting",
+			method:   proto.StockAdmin_DeleteStockSummary_FullMethodName,
+			req:      &proto.DeleteStockSummaryRequest{StockCode: "BBCA"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "error-stock-code-not-allowed",
+			clientID: "dashboard",
+			method:   proto.Stock_ExportStockSummaries_FullMethodName,
+			req:      &proto.ExportStockSummariesRequest{StockCodes: []string{"BBCA", "ASII"}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "error-every-stock-restricted",
+			clientID: "dashboard",
+			method:   proto.Stock_ExportStockSummaries_FullMethodName,
+			req:      &proto.ExportStockSummariesRequest{},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := auth.authorize(model.Identity{ClientID: tt.clientID}, tt.method, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("authenticator.authorize() err = %v, wantCode %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"stock/logger"
+	"stock/model"
+
+	"github.com/IBM/sarama"
+)
+
+// Headers added to dead-lettered messages, next to the headers of the original message
+const (
+	deadLetterHeaderReason    = "dead_letter_reason"
+	deadLetterHeaderError     = "dead_letter_error"
+	deadLetterHeaderTopic     = "dead_letter_topic"
+	deadLetterHeaderPartition = "dead_letter_partition"
+	deadLetterHeaderOffset    = "dead_letter_offset"
+)
+
+// withDeadLetter returns a message handler that publishes the messages next rejects as late to topic, unchanged
+// apart from headers recording the reason and where the message was consumed from. When a message can't be
+// published, its error wraps model.ErrRedeliver, so that it isn't marked as consumed and is dead-lettered again.
+func withDeadLetter(producer sarama.SyncProducer, topic string,
+	next func(ctx context.Context, message *sarama.ConsumerMessage) error,
+) func(ctx context.Context, message *sarama.ConsumerMessage) error {
+	return func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		err := next(ctx, message)
+
+		var rejection *model.RejectionError
+		if !errors.As(err, &rejection) || rejection.Reason != model.RejectionLate {
+			return err
+		}
+
+		headers := make([]sarama.RecordHeader, 0, len(message.Headers)+5)
+		for _, header := range message.Headers {
+			headers = append(headers, *header)
+		}
+		headers = append(headers,
+			sarama.RecordHeader{Key: []byte(deadLetterHeaderReason), Value: []byte(rejection.Reason)},
+			sarama.RecordHeader{Key: []byte(deadLetterHeaderError), Value: []byte(rejection.Message)},
+			sarama.RecordHeader{Key: []byte(deadLetterHeaderTopic), Value: []byte(message.Topic)},
+			sarama.RecordHeader{Key: []byte(deadLetterHeaderPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+			sarama.RecordHeader{Key: []byte(deadLetterHeaderOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		)
+
+		deadLetter := &sarama.ProducerMessage{
+			Topic:     topic,
+			Value:     sarama.ByteEncoder(message.Value),
+			Headers:   headers,
+			Timestamp: message.Timestamp,
+		}
+		if message.Key != nil {
+			deadLetter.Key = sarama.ByteEncoder(message.Key)
+		}
+
+		ctx = logger.ContextWith(ctx,
+			slog.String(logger.KeyTopic, message.Topic),
+			slog.Int64(logger.KeyPartition, int64(message.Partition)),
+			slog.Int64(logger.KeyOffset, message.Offset),
+		)
+
+		if _, _, sendErr := producer.SendMessage(deadLetter); sendErr != nil {
+			slog.ErrorContext(ctx, "Failed to dead-letter transaction", logger.Err(sendErr))
+			return errors.Join(err, sendErr, model.ErrRedeliver)
+		}
+
+		slog.InfoContext(ctx, "Dead-lettered transaction", slog.String("dead_letter_topic", topic))
+		return err
+	}
+}
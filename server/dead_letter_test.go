@@ -0,0 +1,180 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"stock/model"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+func Test_withDeadLetter(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Topic:     "stock",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("BBCA"),
+		Value:     []byte(`{"type":"E"}`),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("traceparent"), Value: []byte("00-trace")}},
+	}
+	late := &model.RejectionError{Reason: model.RejectionLate, Message: "trade is 1h0m0s behind the last trade"}
+
+	tests := []struct {
+		name    string
+		err     error
+		expect  func(producer *mocks.SyncProducer)
+		wantErr error
+	}{
+		{
+			name: "success-late",
+			err:  late,
+			expect: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(deadLetter *sarama.ProducerMessage) error {
+					if deadLetter.Topic != "stock_dead_letter" {
+						return fmt.Errorf("topic = %s, want stock_dead_letter", deadLetter.Topic)
+					}
+
+					headers := map[string]string{}
+					for _, header := range deadLetter.Headers {
+						headers[string(header.Key)] = string(header.Value)
+					}
+					for key, want := range map[string]string{
+						"traceparent":             "00-trace",
+						deadLetterHeaderReason:    string(model.RejectionLate),
+						deadLetterHeaderError:     late.Message,
+						deadLetterHeaderTopic:     "stock",
+						deadLetterHeaderPartition: "2",
+						deadLetterHeaderOffset:    "42",
+					} {
+						if headers[key] != want {
+							return fmt.Errorf("header %s = %q, want %q", key, headers[key], want)
+						}
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "success-not-late",
+			err:  &model.RejectionError{Reason: model.RejectionTickSize},
+		},
+		{
+			name: "success-processed",
+		},
+		{
+			name: "error-send",
+			err:  late,
+			expect: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageAndFail(sarama.ErrNotEnoughReplicas)
+			},
+			wantErr: sarama.ErrNotEnoughReplicas,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := mocks.NewSyncProducer(t, nil)
+			if tt.expect != nil {
+				tt.expect(producer)
+			}
+			defer producer.Close()
+
+			handler := withDeadLetter(producer, "stock_dead_letter", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+				return tt.err
+			})
+
+			err := handler(context.Background(), message)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, tt.err) || !errors.Is(err, model.ErrRedeliver) {
+					t.Errorf("withDeadLetter() err = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != tt.err {
+				t.Errorf("withDeadLetter() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_withDeadLetter_ConsumeClaim(t *testing.T) {
+	message := &sarama.ConsumerMessage{Topic: "stock", Offset: 42, Value: []byte(`{"type":"E"}`)}
+	late := &model.RejectionError{Reason: model.RejectionLate, Message: "trade is 1h0m0s behind the last trade"}
+
+	tests := []struct {
+		name       string
+		err        error
+		expect     func(producer *mocks.SyncProducer)
+		wantMarked bool
+		wantErr    bool
+	}{
+		{
+			name: "success-dead-lettered",
+			err:  late,
+			expect: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageAndSucceed()
+			},
+			wantMarked: true,
+		},
+		{
+			name:       "success-rejected",
+			err:        &model.RejectionError{Reason: model.RejectionTickSize},
+			wantMarked: true,
+		},
+		{
+			name: "error-send",
+			err:  late,
+			expect: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageAndFail(sarama.ErrNotEnoughReplicas)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := mocks.NewSyncProducer(t, nil)
+			if tt.expect != nil {
+				tt.expect(producer)
+			}
+			defer producer.Close()
+
+			consumer := &model.Consumer{Handler: withDeadLetter(producer, "stock_dead_letter",
+				func(ctx context.Context, message *sarama.ConsumerMessage) error {
+					return tt.err
+				})}
+
+			messages := make(chan *sarama.ConsumerMessage, 1)
+			messages <- message
+			close(messages)
+
+			marked := make(chan *sarama.ConsumerMessage, 1)
+			session := &mockConsumerGroupSession{ctx: context.Background(), marked: marked}
+
+			err := consumer.ConsumeClaim(session, &testConsumerGroupClaim{messages: messages})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("consumer.ConsumeClaim() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if gotMarked := len(marked) == 1; gotMarked != tt.wantMarked {
+				t.Errorf("consumer.ConsumeClaim() gotMarked = %v, wantMarked %v", gotMarked, tt.wantMarked)
+			}
+		})
+	}
+}
+
+// testConsumerGroupClaim is a claim of the messages of a channel
+type testConsumerGroupClaim struct {
+	mockConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (claim *testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return claim.messages }
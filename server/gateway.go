@@ -0,0 +1,399 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/handler"
+	"stock/logger"
+	"stock/model"
+	"stock/proto"
+	"stock/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	openAPIPath = "/openapi.json"
+)
+
+var (
+	tracer = otel.Tracer("stock/server")
+)
+
+// gatewayRoute maps an HTTP GET route onto a GRPC method. Parameters of the route's path (e.g. "{code}") and query
+// are copied into the request fields they are mapped to in params.
+type gatewayRoute struct {
+	pattern string
+	method  protoreflect.MethodDescriptor
+	params  map[string]protoreflect.Name
+	invoke  func(ctx context.Context, req protoreflect.ProtoMessage) (protoreflect.ProtoMessage, error)
+}
+
+// gateway serves the GRPC handlers as an HTTP/JSON API, through the same interceptors as the GRPC server
+type gateway struct {
+	routes       []gatewayRoute
+	interceptors []grpc.UnaryServerInterceptor
+	cors         model.CORS
+	openAPI      []byte
+}
+
+// ServeGateway serves the HTTP/JSON API of the GRPC handlers and its OpenAPI document on /openapi.json, over TLS when
+// the GRPC server uses TLS
+func ServeGateway(cfg model.Config, grpcHandler *handler.Handler, interceptors *Interceptors) {
+	if !cfg.HTTP.Enabled {
+		return
+	}
+
+	log := logger.For("gateway")
+
+	httpServer, err := newGatewayServer(cfg, grpcHandler, interceptors)
+	if err != nil {
+		logger.Fatal(log, "Failed to create gateway", logger.Err(err))
+	}
+
+	listen, err := net.Listen(cfg.HTTP.Network, cfg.HTTP.Port)
+	if err != nil {
+		log.Error("Failed to listen", "port", cfg.HTTP.Port, logger.Err(err))
+		return
+	}
+
+	log.Info("Serving", "port", cfg.HTTP.Port, "tls", httpServer.TLSConfig != nil)
+	if httpServer.TLSConfig != nil {
+		err = httpServer.ServeTLS(listen, "", "")
+	} else {
+		err = httpServer.Serve(listen)
+	}
+	if err != nil {
+		log.Error("Failed to serve gateway", logger.Err(err))
+	}
+}
+
+// newGatewayServer returns the HTTP server of the gateway, with the TLS config of the GRPC server when it uses TLS, so
+// that credentials sent to the gateway are never sent in clear text while GRPC clients' are encrypted
+func newGatewayServer(cfg model.Config, grpcHandler proto.StockServer, interceptors *Interceptors) (*http.Server, error) {
+	gw, err := newGateway(cfg, grpcHandler, interceptors)
+	if err != nil {
+		return nil, err
+	}
+
+	httpServer := &http.Server{
+		Handler:           gw,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	if cfg.GRPC.TLS.Enabled {
+		httpServer.TLSConfig, err = newServerTLSConfig(cfg.GRPC.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+	}
+
+	return httpServer, nil
+}
+
+func newGateway(cfg model.Config, grpcHandler proto.StockServer, interceptors *Interceptors) (*gateway, error) {
+	routes := newGatewayRoutes(grpcHandler)
+
+	openAPI, err := newOpenAPIDocument(routes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gateway{
+		routes:       routes,
+		interceptors: interceptors.unary,
+		cors:         cfg.HTTP.CORS,
+		openAPI:      openAPI,
+	}, nil
+}
+
+func newGatewayRoutes(grpcHandler proto.StockServer) []gatewayRoute {
+	stockService := proto.File_stock_proto.Services().ByName("Stock")
+
+	return []gatewayRoute{
+		{
+			pattern: "/v1/stocks/{code}/summary",
+			method:  stockService.Methods().ByName("GetStockSummary"),
+			params:  map[string]protoreflect.Name{"code": "stockCode", "from": "fromDate", "to": "toDate"},
+			invoke: func(ctx context.Context, req protoreflect.ProtoMessage) (protoreflect.ProtoMessage, error) {
+				return grpcHandler.GetStockSummary(ctx, req.(*proto.GetStockSummaryRequest))
+			},
+		},
+		{
+			pattern: "/v1/stocks/{code}/transactions",
+			method:  stockService.Methods().ByName("GetTransactions"),
+			params:  map[string]protoreflect.Name{"code": "stockCode", "date": "date"},
+			invoke: func(ctx context.Context, req protoreflect.ProtoMessage) (protoreflect.ProtoMessage, error) {
+				return grpcHandler.GetTransactions(ctx, req.(*proto.GetTransactionsRequest))
+			},
+		},
+	}
+}
+
+func (gw *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if gw.setCORSHeaders(w, r) && r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeGatewayError(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+		return
+	}
+
+	if r.URL.Path == openAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(gw.openAPI)
+		return
+	}
+
+	for _, route := range gw.routes {
+		if pathParams, ok := matchGatewayPattern(route.pattern, r.URL.Path); ok {
+			gw.serveRoute(w, r, route, pathParams)
+			return
+		}
+	}
+
+	writeGatewayError(w, http.StatusNotFound, status.New(codes.NotFound, "route not found"))
+}
+
+func (gw *gateway) serveRoute(w http.ResponseWriter, r *http.Request, route gatewayRoute, pathParams map[string]string) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(route.method.Input().FullName())
+	if err != nil {
+		writeGatewayStatus(w, status.Convert(err))
+		return
+	}
+	req := messageType.New()
+
+	query := r.URL.Query()
+	for param, fieldName := range route.params {
+		value, ok := pathParams[param]
+		if !ok {
+			value = query.Get(param)
+		}
+
+		if field := req.Descriptor().Fields().ByName(fieldName); field != nil && value != "" {
+			req.Set(field, protoreflect.ValueOfString(value))
+		}
+	}
+
+	transportStream := &gatewayTransportStream{
+		method: fmt.Sprintf("/%s/%s", route.method.Parent().FullName(), route.method.Name()),
+		header: metadata.MD{},
+	}
+	ctx := grpc.NewContextWithServerTransportStream(newGatewayContext(r), transportStream)
+
+	// The span continues the trace of the HTTP client, if any, like the GRPC server does for GRPC clients
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+	ctx, span := tracer.Start(ctx, r.Method+" "+route.pattern,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.HTTPRoute(route.pattern),
+			semconv.RPCMethod(string(route.method.Name())),
+		),
+	)
+
+	info := &grpc.UnaryServerInfo{FullMethod: transportStream.method}
+	invoke := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return route.invoke(ctx, req.(protoreflect.ProtoMessage))
+	}
+	for i := len(gw.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := gw.interceptors[i], invoke
+		invoke = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	resp, err := invoke(ctx, req.Interface())
+	span.SetAttributes(semconv.HTTPResponseStatusCode(httpStatusFromCode(status.Code(err))))
+	tracing.End(span, err)
+
+	for key, values := range transportStream.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	if err != nil {
+		writeGatewayStatus(w, status.Convert(err))
+		return
+	}
+
+	body, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(resp.(protoreflect.ProtoMessage))
+	if err != nil {
+		writeGatewayStatus(w, status.Convert(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// setCORSHeaders allows the request's origin if it is configured, and reports whether the origin was allowed
+func (gw *gateway) setCORSHeaders(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+
+	w.Header().Add("Vary", "Origin")
+	if len(gw.cors.AllowedOrigins) == 0 || !isAllowed(gw.cors.AllowedOrigins, origin, matchOrigin) {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Expose-Headers", "Retry-After")
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		if len(gw.cors.AllowedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(gw.cors.AllowedHeaders, ", "))
+		}
+		if gw.cors.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(gw.cors.MaxAge.Seconds())))
+		}
+	}
+
+	return true
+}
+
+func matchOrigin(pattern, origin string) bool {
+	return pattern == "*" || strings.EqualFold(pattern, origin)
+}
+
+// matchGatewayPattern matches a path against a pattern, and returns the values of the pattern's parameters
+func matchGatewayPattern(pattern, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			params[segment[1:len(segment)-1]] = pathSegments[i]
+			continue
+		}
+
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// newGatewayContext passes the request's headers as incoming metadata and its remote address as the peer
+func newGatewayContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		md.Append(strings.ToLower(key), values...)
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	ctx = logger.ContextWith(ctx, slog.String(logger.KeyComponent, "gateway"))
+	return peer.NewContext(ctx, &peer.Peer{Addr: gatewayAddr(r.RemoteAddr)})
+}
+
+func writeGatewayStatus(w http.ResponseWriter, st *status.Status) {
+	writeGatewayError(w, httpStatusFromCode(st.Code()), st)
+}
+
+func writeGatewayError(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	body, _ := protojson.Marshal(st.Proto())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(body)
+}
+
+// httpStatusFromCode maps a GRPC status code to its HTTP status
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// gatewayTransportStream collects the headers set by interceptors and handlers, so that they reach the HTTP response
+type gatewayTransportStream struct {
+	method string
+	header metadata.MD
+}
+
+func (stream *gatewayTransportStream) Method() string {
+	return stream.method
+}
+
+func (stream *gatewayTransportStream) SetHeader(md metadata.MD) error {
+	stream.header = metadata.Join(stream.header, md)
+	return nil
+}
+
+func (stream *gatewayTransportStream) SendHeader(md metadata.MD) error {
+	return stream.SetHeader(md)
+}
+
+func (stream *gatewayTransportStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
+
+type gatewayAddr string
+
+func (addr gatewayAddr) Network() string {
+	return "tcp"
+}
+
+func (addr gatewayAddr) String() string {
+	return string(addr)
+}
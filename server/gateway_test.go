@@ -0,0 +1,480 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock/handler"
+	mock "stock/handler/_mock"
+	"stock/model"
+	"stock/proto"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test_gateway_ServeHTTP(t *testing.T) {
+	type args struct {
+		method string
+		target string
+		header map[string]string
+	}
+	type fields struct {
+		cfg          model.Config
+		stockUsecase func(ctrl *gomock.Controller) handler.StockUsecase
+	}
+
+	noCall := func(ctrl *gomock.Controller) handler.StockUsecase {
+		return mock.NewMockStockUsecase(ctrl)
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantStatus int
+		wantBody   string
+		wantHeader map[string]string
+	}{
+		{
+			name: "success-get-stock-summary",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/summary?from=2023-08-29&to=2023-08-29",
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) handler.StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+						ToDate:    time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+					}).Return([]model.Summary{
+						{
+							StockCode: "BBCA",
+							Date:      time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+							Prev:      9000,
+							Open:      9025,
+							High:      9100,
+							Low:       9000,
+							Close:     9050,
+							Volume:    100,
+							Value:     905000,
+							Average:   9050,
+						},
+					}, nil)
+					return m
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"result":[{"stockCode":"BBCA","date":"2023-08-29","prev":"9000","open":"9025","high":"9100",` +
+				`"low":"9000","close":"9050","volume":"100","value":"905000","average":"9050","sessions":[],"priceScale":0,` +
+				`"quantityScale":0,"decimals":{"prev":"9000","open":"9025","high":"9100","low":"9000","close":"9050",` +
+				`"volume":"100","value":"905000","average":"9050","vwap":"0","buyVolume":"0","sellVolume":"0"},"vwap":"0",` +
+				`"vwapScale":0,"trades":"0","boards":[],"buyVolume":"0","sellVolume":"0",` +
+				`"period":"day"}]}`,
+		},
+		{
+			name: "error-invalid-date",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/summary?from=2023-08&to=2023-08-29",
+			},
+			fields:     fields{stockUsecase: noCall},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error-get-stock-summary",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/summary?from=2023-08-29&to=2023-08-29",
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) handler.StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("error-get-stock-summary"))
+					return m
+				},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "success-get-transactions",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/transactions?date=2023-08-29",
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) handler.StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)).
+						Return([]model.JournalEntry{
+							{ID: "1693301400000-0", Transaction: model.Transaction{
+								Type:        model.TransactionTypeE,
+								StockCode:   "BBCA",
+								OrderNumber: "202308290930000001",
+								Price:       9050,
+								Quantity:    100,
+								Timestamp:   time.Date(2023, 8, 29, 9, 30, 0, 0, time.UTC),
+							}},
+						}, nil)
+					return m
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"transactions":[{"id":"1693301400000-0","type":"E","orderNumber":"202308290930000001",` +
+				`"price":"9050","quantity":"100","timestamp":"2023-08-29T09:30:00Z","sequence":"0","session":"",` +
+				`"board":"","side":"","priceScale":0,"quantityScale":0}]}`,
+		},
+		{
+			name: "error-journal-disabled",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/transactions?date=2023-08-29",
+			},
+			fields: fields{
+				stockUsecase: func(ctrl *gomock.Controller) handler.StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().GetTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, model.ErrJournalDisabled)
+					return m
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error-route-not-found",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA",
+			},
+			fields:     fields{stockUsecase: noCall},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error-method-not-allowed",
+			args: args{
+				method: http.MethodPost,
+				target: "/v1/stocks/BBCA/summary",
+			},
+			fields:     fields{stockUsecase: noCall},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "error-unauthenticated",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/summary?from=2023-08-29&to=2023-08-29",
+			},
+			fields: fields{
+				cfg:          model.Config{Auth: model.Auth{Enabled: true}},
+				stockUsecase: noCall,
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "error-rate-limited",
+			args: args{
+				method: http.MethodGet,
+				target: "/v1/stocks/BBCA/summary?from=2013-08-29&to=2023-08-29",
+			},
+			fields: fields{
+				cfg: model.Config{RateLimit: model.RateLimit{
+					Enabled: true,
+					Default: model.MethodLimit{RequestsPerSecond: 1, Burst: 1, MaxInFlight: 1},
+				}},
+				stockUsecase: func(ctrl *gomock.Controller) handler.StockUsecase {
+					m := mock.NewMockStockUsecase(ctrl)
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return m
+				},
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeader: map[string]string{"Retry-After": "1"},
+		},
+		{
+			name: "success-cors-preflight",
+			args: args{
+				method: http.MethodOptions,
+				target: "/v1/stocks/BBCA/summary",
+				header: map[string]string{"Origin": "https://dashboard.example.com"},
+			},
+			fields: fields{
+				cfg: model.Config{HTTP: model.HTTP{CORS: model.CORS{
+					AllowedOrigins: []string{"https://dashboard.example.com"},
+					AllowedHeaders: []string{"Authorization"},
+					MaxAge:         time.Minute,
+				}}},
+				stockUsecase: noCall,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				"Access-Control-Allow-Origin":  "https://dashboard.example.com",
+				"Access-Control-Allow-Headers": "Authorization",
+				"Access-Control-Max-Age":       "60",
+			},
+		},
+		{
+			name: "error-cors-origin-not-allowed",
+			args: args{
+				method: http.MethodOptions,
+				target: "/v1/stocks/BBCA/summary",
+				header: map[string]string{"Origin": "https://other.example.com"},
+			},
+			fields: fields{
+				cfg: model.Config{HTTP: model.HTTP{CORS: model.CORS{
+					AllowedOrigins: []string{"https://dashboard.example.com"},
+				}}},
+				stockUsecase: noCall,
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantHeader: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interceptors, err := NewInterceptors(tt.fields.cfg)
+			if err != nil {
+				t.Fatalf("NewInterceptors() err = %v", err)
+			}
+
+			gw, err := newGateway(tt.fields.cfg, handler.New(tt.fields.cfg, tt.fields.stockUsecase(ctrl)), interceptors)
+			if err != nil {
+				t.Fatalf("newGateway() err = %v", err)
+			}
+
+			newRequest := func() *http.Request {
+				r := httptest.NewRequest(tt.args.method, tt.args.target, nil)
+				for key, value := range tt.args.header {
+					r.Header.Set(key, value)
+				}
+				return r
+			}
+
+			// A rate limited route is called twice, the first call using up the bucket
+			if tt.fields.cfg.RateLimit.Enabled {
+				gw.ServeHTTP(httptest.NewRecorder(), newRequest())
+			}
+
+			recorder := httptest.NewRecorder()
+			gw.ServeHTTP(recorder, newRequest())
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("gateway.ServeHTTP() gotStatus = %v, wantStatus %v, body %s", recorder.Code, tt.wantStatus,
+					recorder.Body.String())
+			}
+
+			if tt.wantBody != "" {
+				var gotBody, wantBody interface{}
+				_ = json.Unmarshal(recorder.Body.Bytes(), &gotBody)
+				_ = json.Unmarshal([]byte(tt.wantBody), &wantBody)
+				if !jsonEqual(gotBody, wantBody) {
+					t.Errorf("gateway.ServeHTTP() gotBody = %s, wantBody %s", recorder.Body.String(), tt.wantBody)
+				}
+			}
+
+			for key, value := range tt.wantHeader {
+				if got := recorder.Header().Get(key); got != value {
+					t.Errorf("gateway.ServeHTTP() header %s = %v, want %v", key, got, value)
+				}
+			}
+		})
+	}
+}
+
+func Test_NewInterceptors_SharedRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := model.Config{RateLimit: model.RateLimit{
+		Enabled:         true,
+		ClientKeyHeader: "x-client-id",
+		Default:         model.MethodLimit{RequestsPerSecond: 1, Burst: 1},
+	}}
+
+	stockUsecase := mock.NewMockStockUsecase(ctrl)
+	stockUsecase.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return(nil, nil)
+	stockHandler := handler.New(cfg, stockUsecase)
+
+	interceptors, err := NewInterceptors(cfg)
+	if err != nil {
+		t.Fatalf("NewInterceptors() err = %v", err)
+	}
+
+	grpcServer, err := newGRPCServer(cfg, stockHandler, interceptors)
+	if err != nil {
+		t.Fatalf("newGRPCServer() err = %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() err = %v", err)
+	}
+	defer conn.Close()
+
+	gw, err := newGateway(cfg, stockHandler, interceptors)
+	if err != nil {
+		t.Fatalf("newGateway() err = %v", err)
+	}
+
+	// The GRPC call uses up the client's bucket, so the same client's gateway call is rejected
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-client-id", "dashboard")
+	_, err = proto.NewStockClient(conn).GetStockSummary(ctx,
+		&proto.GetStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-29", ToDate: "2023-08-29"})
+	if err != nil {
+		t.Fatalf("GetStockSummary() err = %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/stocks/BBCA/summary?from=2023-08-29&to=2023-08-29", nil)
+	r.Header.Set("X-Client-Id", "dashboard")
+	recorder := httptest.NewRecorder()
+	gw.ServeHTTP(recorder, r)
+
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("gateway.ServeHTTP() gotStatus = %v, wantStatus %v", recorder.Code, http.StatusTooManyRequests)
+	}
+}
+
+func Test_newGatewayServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := generateTestCertificate(t, dir, "ca", true, nil)
+	serverCert := generateTestCertificate(t, dir, "server", false, &ca)
+
+	cfg := model.Config{GRPC: model.GRPC{TLS: model.TLS{
+		Enabled:  true,
+		CertFile: serverCert.certFile,
+		KeyFile:  serverCert.keyFile,
+	}}}
+
+	interceptors, err := NewInterceptors(cfg)
+	if err != nil {
+		t.Fatalf("NewInterceptors() err = %v", err)
+	}
+
+	httpServer, err := newGatewayServer(cfg, &proto.UnimplementedStockServer{}, interceptors)
+	if err != nil {
+		t.Fatalf("newGatewayServer() err = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+	go func() { _ = httpServer.ServeTLS(listener, "", "") }()
+	defer httpServer.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+		ServerName: "localhost",
+	}}}
+
+	response, err := client.Get("https://" + listener.Addr().String() + "/openapi.json")
+	if err != nil {
+		t.Fatalf("http.Client.Get() err = %v", err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("http.Client.Get() gotStatus = %v, wantStatus %v", response.StatusCode, http.StatusOK)
+	}
+
+	// Plaintext requests are refused
+	response, err = http.Get("http://" + listener.Addr().String() + "/openapi.json")
+	if err == nil {
+		_ = response.Body.Close()
+		if response.StatusCode == http.StatusOK {
+			t.Errorf("http.Get() gotStatus = %v over plaintext HTTP", response.StatusCode)
+		}
+	}
+}
+
+func jsonEqual(got, want interface{}) bool {
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	return string(gotJSON) == string(wantJSON)
+}
+
+func Test_newOpenAPIDocument(t *testing.T) {
+	document, err := newOpenAPIDocument(newGatewayRoutes(nil))
+	if err != nil {
+		t.Fatalf("newOpenAPIDocument() err = %v", err)
+	}
+
+	var got struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]struct {
+			Get struct {
+				OperationID string `json:"operationId"`
+				Parameters  []struct {
+					Name     string `json:"name"`
+					In       string `json:"in"`
+					Required bool   `json:"required"`
+				} `json:"parameters"`
+			} `json:"get"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(document, &got); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v", err)
+	}
+
+	operation := got.Paths["/v1/stocks/{code}/summary"].Get
+	if operation.OperationID != "GetStockSummary" || len(operation.Parameters) != 3 {
+		t.Fatalf("newOpenAPIDocument() operation = %+v", operation)
+	}
+	if param := operation.Parameters[0]; param.Name != "code" || param.In != "path" || !param.Required {
+		t.Errorf("newOpenAPIDocument() parameter = %+v, want required path parameter code", param)
+	}
+
+	operation = got.Paths["/v1/stocks/{code}/transactions"].Get
+	if operation.OperationID != "GetTransactions" || len(operation.Parameters) != 2 {
+		t.Fatalf("newOpenAPIDocument() operation = %+v", operation)
+	}
+
+	for _, schema := range []string{
+		"GetStockSummaryResponse", "StockSummary", "SessionSummary", "GetTransactionsResponse", "Transaction", "Status",
+	} {
+		if _, ok := got.Components.Schemas[schema]; !ok {
+			t.Errorf("newOpenAPIDocument() is missing schema %s", schema)
+		}
+	}
+	if format := got.Components.Schemas["StockSummary"].Properties["volume"]["format"]; format != "int64" {
+		t.Errorf("newOpenAPIDocument() volume format = %v, want int64", format)
+	}
+}
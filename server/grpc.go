@@ -6,27 +6,99 @@
 package server
 
 import (
-	"log"
+	"fmt"
 	"net"
 
 	"stock/handler"
+	"stock/logger"
 	"stock/model"
 	"stock/proto"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-func ServeGRPC(cfg model.Config, grpcHandler *handler.Handler) {
+func ServeGRPC(cfg model.Config, grpcHandler *handler.Handler, interceptors *Interceptors) {
+	log := logger.For("grpc")
+
 	listen, err := net.Listen(cfg.GRPC.Network, cfg.GRPC.Port)
 	if err != nil {
-		log.Printf("[GRPC] Failed to listen to port %s: %v", cfg.GRPC.Port, err)
+		log.Error("Failed to listen", "port", cfg.GRPC.Port, logger.Err(err))
 	}
 
-	grpcServer := grpc.NewServer()
-	proto.RegisterStockServer(grpcServer, grpcHandler)
+	grpcServer, err := newGRPCServer(cfg, grpcHandler, interceptors)
+	if err != nil {
+		logger.Fatal(log, "Invalid config", logger.Err(err))
+	}
 
-	log.Printf("[GRPC] Serving on port %v", cfg.GRPC.Port)
+	log.Info("Serving", "port", cfg.GRPC.Port)
 	if err := grpcServer.Serve(listen); err != nil {
-		log.Fatalf("[GRPC] Failed to serve GRPC server: %v", err)
+		logger.Fatal(log, "Failed to serve GRPC server", logger.Err(err))
+	}
+}
+
+// newGRPCServer returns a server of grpcHandler with the configured credentials and interceptors
+func newGRPCServer(cfg model.Config, grpcHandler *handler.Handler, interceptors *Interceptors) (*grpc.Server, error) {
+	var options []grpc.ServerOption
+	if cfg.GRPC.TLS.Enabled {
+		tlsConfig, err := newServerTLSConfig(cfg.GRPC.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	options = append(options,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(interceptors.unary...),
+		grpc.ChainStreamInterceptor(interceptors.stream...),
+	)
+
+	grpcServer := grpc.NewServer(options...)
+	proto.RegisterStockServer(grpcServer, grpcHandler)
+
+	// Admin methods change stored summaries, so they are only served to authenticated callers
+	if cfg.Auth.Enabled {
+		proto.RegisterStockAdminServer(grpcServer, grpcHandler)
 	}
+
+	return grpcServer, nil
+}
+
+// Interceptors are the interceptors applied to every call. The same instances are shared by the GRPC server and the
+// gateway, so that calls through either count against the same rate limits.
+type Interceptors struct {
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+}
+
+// NewInterceptors returns the interceptors applied to every call, in order
+func NewInterceptors(cfg model.Config) (*Interceptors, error) {
+	// Calls are logged first, so that calls rejected by the other interceptors are logged too
+	interceptors := &Interceptors{
+		unary:  []grpc.UnaryServerInterceptor{loggingUnaryInterceptor},
+		stream: []grpc.StreamServerInterceptor{loggingStreamInterceptor},
+	}
+
+	if cfg.Auth.Enabled {
+		auth, err := newAuthenticator(cfg.Auth)
+		if err != nil {
+			return nil, err
+		}
+
+		interceptors.unary = append(interceptors.unary, auth.unaryInterceptor)
+		interceptors.stream = append(interceptors.stream, auth.streamInterceptor)
+	}
+
+	// Rate limits run after authentication so that clients are identified by their identity
+	if cfg.RateLimit.Enabled {
+		limiter := newRateLimiter(cfg.RateLimit)
+
+		interceptors.unary = append(interceptors.unary, limiter.unaryInterceptor)
+		interceptors.stream = append(interceptors.stream, limiter.streamInterceptor)
+	}
+
+	return interceptors, nil
 }
@@ -0,0 +1,636 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"stock/handler"
+	"stock/model"
+	"stock/proto"
+	"stock/repo"
+	"stock/simulator"
+	"stock/usecase"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func Test_Integration_GetStockSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		instruments  model.Instruments
+		transactions []model.KafkaTransaction
+		expect       func(producer *mocks.SyncProducer)
+		request      *proto.GetStockSummaryRequest
+
+		wantResponse *proto.GetStockSummaryResponse
+	}{
+		{
+			name: "success-sessions",
+			transactions: []model.KafkaTransaction{
+				{Type: "A", OrderNumber: "202308290840000001", Price: "8000", StockCode: "BBCA"},
+				{Type: "E", OrderBook: "RG", OrderNumber: "202308290855000002", ExecutionPrice: "8100", ExecutedQuantity: "100", StockCode: "BBCA"},
+				{Type: "E", OrderBook: "RG", OrderNumber: "202308290930000003", OrderVerb: "B", ExecutionPrice: "8200", ExecutedQuantity: "200", StockCode: "BBCA"},
+				{Type: "P", OrderBook: "NG", OrderNumber: "202308291400000004", OrderVerb: "S", Price: "8150", Quantity: "100", StockCode: "BBCA"},
+				{Type: "E", OrderNumber: "202308290930000005", ExecutionPrice: "3000", ExecutedQuantity: "100", StockCode: "TLKM"},
+			},
+			request: &proto.GetStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-29", ToDate: "2023-08-29"},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "BBCA",
+						Date:      "2023-08-29",
+						Prev:      8000,
+						Open:      8100,
+						High:      8200,
+						Low:       8100,
+						Close:     8150,
+						Volume:    400,
+						Value:     3265000,
+						Average:   8162,
+						Sessions: []*proto.SessionSummary{
+							{Session: string(model.SessionPreOpening), Open: 8100, High: 8100, Low: 8100, Close: 8100, Volume: 100, Value: 810000,
+								Decimals: &proto.Decimals{Open: "8100", High: "8100", Low: "8100", Close: "8100", Volume: "100", Value: "810000"}},
+							{Session: string(model.SessionOne), Open: 8200, High: 8200, Low: 8200, Close: 8200, Volume: 200, Value: 1640000,
+								Decimals: &proto.Decimals{Open: "8200", High: "8200", Low: "8200", Close: "8200", Volume: "200", Value: "1640000"}},
+							{Session: string(model.SessionTwo), Open: 8150, High: 8150, Low: 8150, Close: 8150, Volume: 100, Value: 815000,
+								Decimals: &proto.Decimals{Open: "8150", High: "8150", Low: "8150", Close: "8150", Volume: "100", Value: "815000"}},
+						},
+						Decimals: &proto.Decimals{
+							Prev: "8000", Open: "8100", High: "8200", Low: "8100", Close: "8150",
+							Volume: "400", Value: "3265000", Average: "8162", Vwap: "8162.5000", BuyVolume: "200", SellVolume: "100",
+						},
+						Vwap:      81625000,
+						VwapScale: 4,
+						Period:    model.PeriodDay,
+						Trades:    3,
+						Boards: []*proto.BoardSummary{
+							{Board: string(model.BoardRegular), Volume: 300, Value: 2450000, Trades: 2,
+								Decimals: &proto.Decimals{Volume: "300", Value: "2450000"}},
+							{Board: string(model.BoardNegotiated), Volume: 100, Value: 815000, Trades: 1,
+								Decimals: &proto.Decimals{Volume: "100", Value: "815000"}},
+						},
+						BuyVolume:  200,
+						SellVolume: 100,
+					},
+				},
+			},
+		},
+		{
+			name: "success-late-dead-lettered",
+			transactions: []model.KafkaTransaction{
+				{Type: "E", OrderNumber: "202308291000000001", ExecutionPrice: "8150", ExecutedQuantity: "100", StockCode: "BBCA"},
+				{Type: "E", OrderNumber: "202308290930000002", ExecutionPrice: "8200", ExecutedQuantity: "100", StockCode: "BBCA"},
+			},
+			expect: func(producer *mocks.SyncProducer) {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(deadLetter *sarama.ProducerMessage) error {
+					var transaction model.KafkaTransaction
+					value, _ := deadLetter.Value.Encode()
+					if err := json.Unmarshal(value, &transaction); err != nil {
+						return err
+					}
+					if transaction.OrderNumber != "202308290930000002" {
+						t.Errorf("dead letter OrderNumber = %s, want 202308290930000002", transaction.OrderNumber)
+					}
+					return nil
+				})
+			},
+			request: &proto.GetStockSummaryRequest{StockCode: "BBCA", FromDate: "2023-08-29", ToDate: "2023-08-29"},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "BBCA",
+						Date:      "2023-08-29",
+						Open:      8150,
+						High:      8150,
+						Low:       8150,
+						Close:     8150,
+						Volume:    100,
+						Value:     815000,
+						Average:   8150,
+						Sessions: []*proto.SessionSummary{
+							{Session: string(model.SessionOne), Open: 8150, High: 8150, Low: 8150, Close: 8150, Volume: 100, Value: 815000,
+								Decimals: &proto.Decimals{Open: "8150", High: "8150", Low: "8150", Close: "8150", Volume: "100", Value: "815000"}},
+						},
+						Decimals: &proto.Decimals{
+							Prev: "0", Open: "8150", High: "8150", Low: "8150", Close: "8150",
+							Volume: "100", Value: "815000", Average: "8150", Vwap: "8150.0000", BuyVolume: "0", SellVolume: "0",
+						},
+						Vwap:      81500000,
+						VwapScale: 4,
+						Period:    model.PeriodDay,
+						Trades:    1,
+					},
+				},
+			},
+		},
+		{
+			name:        "success-decimal",
+			instruments: model.Instruments{{StockCode: "USDIDR", PriceScale: 2, QuantityScale: 1, TickSizes: []model.TickSize{{MinPrice: 0, Tick: 25}}}},
+			transactions: []model.KafkaTransaction{
+				{Type: "A", OrderNumber: "202308290840000001", Price: "15234", StockCode: "USDIDR"},
+				{Type: "E", OrderBook: "RG", OrderNumber: "202308290930000002", OrderVerb: "B", ExecutionPrice: "15234.25", ExecutedQuantity: "1000.5", StockCode: "USDIDR"},
+				{Type: "E", OrderNumber: "202308290931000003", ExecutionPrice: "15234.5", ExecutedQuantity: "0.5", StockCode: "USDIDR"},
+			},
+			request: &proto.GetStockSummaryRequest{StockCode: "USDIDR", FromDate: "2023-08-29", ToDate: "2023-08-29"},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "USDIDR",
+						Date:      "2023-08-29",
+						Prev:      1523400,
+						Open:      1523425,
+						High:      1523450,
+						Low:       1523425,
+						Close:     1523450,
+						Volume:    10010,
+						Value:     15249484375,
+						Average:   1523425,
+						Sessions: []*proto.SessionSummary{
+							{Session: string(model.SessionOne), Open: 1523425, High: 1523450, Low: 1523425, Close: 1523450, Volume: 10010, Value: 15249484375,
+								Decimals: &proto.Decimals{Open: "15234.25", High: "15234.50", Low: "15234.25", Close: "15234.50", Volume: "1001.0", Value: "15249484.375"}},
+						},
+						PriceScale:    2,
+						QuantityScale: 1,
+						Decimals: &proto.Decimals{
+							Prev: "15234.00", Open: "15234.25", High: "15234.50", Low: "15234.25", Close: "15234.50",
+							Volume: "1001.0", Value: "15249484.375", Average: "15234.25", Vwap: "15234.250125",
+							BuyVolume: "1000.5", SellVolume: "0.0",
+						},
+						Vwap:      15234250125,
+						VwapScale: 6,
+						Period:    model.PeriodDay,
+						Trades:    2,
+						Boards: []*proto.BoardSummary{
+							{Board: string(model.BoardRegular), Volume: 10005, Value: 15241867125, Trades: 1,
+								Decimals: &proto.Decimals{Volume: "1000.5", Value: "15241867.125"}},
+						},
+						BuyVolume: 10005,
+					},
+				},
+			},
+		},
+		{
+			name:        "success-instrument-timezone",
+			instruments: model.Instruments{{StockCode: "ASII", Timezone: "Asia/Jakarta"}},
+			transactions: []model.KafkaTransaction{
+				{Type: "A", OrderNumber: "202308290840000001", Price: "6000", StockCode: "ASII"},
+				{Type: "E", OrderNumber: "202308290930000002", ExecutionPrice: "6100", ExecutedQuantity: "100", StockCode: "ASII"},
+			},
+			request: &proto.GetStockSummaryRequest{StockCode: "ASII", FromDate: "2023-08-29", ToDate: "2023-08-29"},
+			wantResponse: &proto.GetStockSummaryResponse{
+				Result: []*proto.StockSummary{
+					{
+						StockCode: "ASII",
+						Date:      "2023-08-29",
+						Prev:      6000,
+						Open:      6100,
+						High:      6100,
+						Low:       6100,
+						Close:     6100,
+						Volume:    100,
+						Value:     610000,
+						Average:   6100,
+						Sessions: []*proto.SessionSummary{
+							{Session: string(model.SessionOne), Open: 6100, High: 6100, Low: 6100, Close: 6100, Volume: 100, Value: 610000,
+								Decimals: &proto.Decimals{Open: "6100", High: "6100", Low: "6100", Close: "6100", Volume: "100", Value: "610000"}},
+						},
+						Decimals: &proto.Decimals{
+							Prev: "6000", Open: "6100", High: "6100", Low: "6100", Close: "6100",
+							Volume: "100", Value: "610000", Average: "6100", Vwap: "6100.0000", BuyVolume: "0", SellVolume: "0",
+						},
+						Vwap:      61000000,
+						VwapScale: 4,
+						Period:    model.PeriodDay,
+						Trades:    1,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := model.DefaultConfigLocal
+			cfg.Instruments = tt.instruments
+
+			harness := newIntegrationHarness(t, cfg)
+			if tt.expect != nil {
+				tt.expect(harness.producer)
+			}
+
+			for _, transaction := range tt.transactions {
+				harness.publish(cfg.Kafka.Topic, transaction)
+			}
+
+			gotResponse, err := harness.client.GetStockSummary(context.Background(), tt.request)
+			if err != nil {
+				t.Errorf("client.GetStockSummary() err = %v", err)
+				return
+			}
+
+			if !protobuf.Equal(gotResponse, tt.wantResponse) {
+				t.Errorf("client.GetStockSummary() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Integration_Simulation(t *testing.T) {
+	cfg := model.DefaultConfigLocal
+	harness := newIntegrationHarness(t, cfg)
+
+	simulation, err := simulator.Simulate(simulator.Options{
+		Seed:          42,
+		Stocks:        3,
+		Transactions:  100,
+		Date:          time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Schedule:      cfg.Schedule,
+		TickSizes:     cfg.Validation.TickSizes,
+		VWAPPrecision: cfg.VWAP.Precision,
+	})
+	if err != nil {
+		t.Fatalf("simulator.Simulate() err = %v", err)
+	}
+
+	for _, transaction := range simulation.Transactions {
+		harness.publish(cfg.Kafka.Topic, transaction)
+	}
+
+	mismatches, err := simulator.Verify(context.Background(), harness.repo, simulation.Expected)
+	if err != nil {
+		t.Fatalf("simulator.Verify() err = %v", err)
+	}
+	for _, mismatch := range mismatches {
+		t.Errorf("simulator.Verify() expected = %+v, actual %+v", mismatch.Expected, mismatch.Actual)
+	}
+}
+
+func Test_Integration_StockAdmin(t *testing.T) {
+	cfg := model.DefaultConfigLocal
+	cfg.Auth = model.Auth{
+		Enabled: true,
+		APIKeys: []model.APIKey{
+			{Key: "key-ops", ClientID: "ops"},
+			{Key: "key-reporting", ClientID: "reporting"},
+		},
+		Clients: []model.ClientPolicy{
+			{ClientID: "ops", AllowedMethods: []string{"/proto.Stock/*", "/proto.StockAdmin/*"}},
+			{ClientID: "reporting"},
+		},
+	}
+	harness := newIntegrationHarness(t, cfg)
+
+	opsCtx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "key-ops")
+	reportingCtx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "key-reporting")
+
+	// A bad tick sets the High
+	for _, transaction := range []model.KafkaTransaction{
+		{Type: "E", OrderNumber: "202308290930000001", ExecutionPrice: "6100", ExecutedQuantity: "100", StockCode: "ASII"},
+		{Type: "E", OrderNumber: "202308290931000002", ExecutionPrice: "6700", ExecutedQuantity: "100", StockCode: "ASII"},
+	} {
+		harness.publish(cfg.Kafka.Topic, transaction)
+	}
+
+	override := &proto.OverrideStockSummaryRequest{
+		StockCode: "ASII",
+		Date:      "2023-08-29",
+		Decimals:  &proto.Decimals{High: "6100", Close: "6100", Volume: "100", Value: "610000"},
+		Reason:    "bad tick",
+	}
+	if _, err := harness.admin.OverrideStockSummary(reportingCtx, override); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("admin.OverrideStockSummary() err = %v, wantCode %v", err, codes.PermissionDenied)
+	}
+
+	overridden, err := harness.admin.OverrideStockSummary(opsCtx, override)
+	if err != nil {
+		t.Fatalf("admin.OverrideStockSummary() err = %v", err)
+	}
+	if got := overridden.GetBefore().GetHigh(); got != 6700 {
+		t.Errorf("admin.OverrideStockSummary() gotBefore.High = %v, wantBefore.High %v", got, 6700)
+	}
+
+	request := &proto.GetStockSummaryRequest{StockCode: "ASII", FromDate: "2023-08-29", ToDate: "2023-08-29"}
+	gotResponse, err := harness.client.GetStockSummary(opsCtx, request)
+	if err != nil {
+		t.Fatalf("client.GetStockSummary() err = %v", err)
+	}
+	if len(gotResponse.GetResult()) != 1 || !protobuf.Equal(gotResponse.GetResult()[0], overridden.GetAfter()) {
+		t.Errorf("client.GetStockSummary() gotResponse = %v, wantResult %v", gotResponse, overridden.GetAfter())
+	}
+	if got := overridden.GetAfter(); got.GetHigh() != 6100 || got.GetAverage() != 6100 || got.GetVwap() != 61000000 {
+		t.Errorf("admin.OverrideStockSummary() gotAfter = %v", got)
+	}
+
+	deleted, err := harness.admin.DeleteStockSummary(opsCtx, &proto.DeleteStockSummaryRequest{
+		StockCode: "ASII",
+		FromDate:  "2023-08-29",
+		ToDate:    "2023-08-29",
+		Reason:    "duplicate day",
+	})
+	if err != nil {
+		t.Fatalf("admin.DeleteStockSummary() err = %v", err)
+	}
+	if len(deleted.GetDeleted()) != 1 {
+		t.Errorf("admin.DeleteStockSummary() gotDeleted = %v, wantDeleted 1 summary", deleted.GetDeleted())
+	}
+
+	gotResponse, err = harness.client.GetStockSummary(opsCtx, request)
+	if err != nil {
+		t.Fatalf("client.GetStockSummary() err = %v", err)
+	}
+	if len(gotResponse.GetResult()) != 0 {
+		t.Errorf("client.GetStockSummary() gotResponse = %v, wantResponse no summaries", gotResponse)
+	}
+
+	// The journal keeps the deleted day, including the bad tick, so the original summary is rebuilt
+	transactions, err := harness.client.GetTransactions(opsCtx, &proto.GetTransactionsRequest{StockCode: "ASII", Date: "2023-08-29"})
+	if err != nil {
+		t.Fatalf("client.GetTransactions() err = %v", err)
+	}
+	if got := transactions.GetTransactions(); len(got) != 2 || got[1].GetOrderNumber() != "202308290931000002" {
+		t.Errorf("client.GetTransactions() gotResponse = %v, wantResponse 2 transactions", transactions)
+	}
+
+	recomputed, err := harness.admin.RecomputeStockSummary(opsCtx, &proto.RecomputeStockSummaryRequest{
+		StockCode: "ASII",
+		Date:      "2023-08-29",
+		Reason:    "deleted by mistake",
+	})
+	if err != nil {
+		t.Fatalf("admin.RecomputeStockSummary() err = %v", err)
+	}
+	if recomputed.GetBefore() != nil || !protobuf.Equal(recomputed.GetAfter(), overridden.GetBefore()) {
+		t.Errorf("admin.RecomputeStockSummary() gotResponse = %v, wantAfter %v", recomputed, overridden.GetBefore())
+	}
+
+	gotResponse, err = harness.client.GetStockSummary(opsCtx, request)
+	if err != nil {
+		t.Fatalf("client.GetStockSummary() err = %v", err)
+	}
+	if len(gotResponse.GetResult()) != 1 || !protobuf.Equal(gotResponse.GetResult()[0], recomputed.GetAfter()) {
+		t.Errorf("client.GetStockSummary() gotResponse = %v, wantResult %v", gotResponse, recomputed.GetAfter())
+	}
+}
+
+func Test_Integration_PartialFills(t *testing.T) {
+	cfg := model.DefaultConfigLocal
+	cfg.Auth = model.Auth{
+		Enabled: true,
+		APIKeys: []model.APIKey{{Key: "key-ops", ClientID: "ops"}},
+		Clients: []model.ClientPolicy{
+			{ClientID: "ops", AllowedMethods: []string{"/proto.Stock/*", "/proto.StockAdmin/*"}},
+		},
+	}
+	harness := newIntegrationHarness(t, cfg)
+
+	opsCtx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "key-ops")
+
+	// An order filled twice has two executions with the same order number
+	for _, transaction := range []model.KafkaTransaction{
+		{Type: "E", OrderNumber: "202308290930000001", ExecutionPrice: "6100", ExecutedQuantity: "100", StockCode: "ASII"},
+		{Type: "E", OrderNumber: "202308290930000001", ExecutionPrice: "6125", ExecutedQuantity: "200", StockCode: "ASII"},
+	} {
+		harness.publish(cfg.Kafka.Topic, transaction)
+	}
+
+	request := &proto.GetStockSummaryRequest{StockCode: "ASII", FromDate: "2023-08-29", ToDate: "2023-08-29"}
+	consumed, err := harness.client.GetStockSummary(opsCtx, request)
+	if err != nil {
+		t.Fatalf("client.GetStockSummary() err = %v", err)
+	}
+	if len(consumed.GetResult()) != 1 || consumed.GetResult()[0].GetVolume() != 300 {
+		t.Fatalf("client.GetStockSummary() gotResponse = %v, wantResponse a summary of volume 300", consumed)
+	}
+
+	transactions, err := harness.client.GetTransactions(opsCtx, &proto.GetTransactionsRequest{StockCode: "ASII", Date: "2023-08-29"})
+	if err != nil {
+		t.Fatalf("client.GetTransactions() err = %v", err)
+	}
+	if got := transactions.GetTransactions(); len(got) != 2 {
+		t.Errorf("client.GetTransactions() gotResponse = %v, wantResponse 2 transactions", transactions)
+	}
+
+	// The summary rebuilt from the journal has both fills
+	recomputed, err := harness.admin.RecomputeStockSummary(opsCtx, &proto.RecomputeStockSummaryRequest{
+		StockCode: "ASII",
+		Date:      "2023-08-29",
+		Reason:    "check journal",
+	})
+	if err != nil {
+		t.Fatalf("admin.RecomputeStockSummary() err = %v", err)
+	}
+	if !protobuf.Equal(recomputed.GetAfter(), consumed.GetResult()[0]) {
+		t.Errorf("admin.RecomputeStockSummary() gotAfter = %v, wantAfter %v", recomputed.GetAfter(), consumed.GetResult()[0])
+	}
+}
+
+// integrationHarness wires the real handler, usecase and repo as main does, backed by an in-process Redis server.
+// Messages are consumed through consumeKafka from sarama mock partition consumers, and summaries are read through a
+// gRPC client of the real server over an in-memory connection.
+type integrationHarness struct {
+	t          *testing.T
+	partitions map[string]*mocks.PartitionConsumer
+	marked     chan *sarama.ConsumerMessage
+	producer   *mocks.SyncProducer
+	client     proto.StockClient
+	admin      proto.StockAdminClient
+	repo       *repo.Repo
+}
+
+func newIntegrationHarness(t *testing.T, cfg model.Config) *integrationHarness {
+	t.Helper()
+
+	redis := miniredis.RunT(t)
+	cfg.Redis = model.Redis{
+		Mode: model.RedisModeStandalone,
+		Host: redis.Host(),
+		Port: ":" + redis.Port(),
+	}
+
+	stockRepo := repo.New(cfg)
+	if stockRepo == nil {
+		t.Fatal("repo.New() failed to connect to miniredis")
+	}
+	stockHandler := handler.New(cfg, usecase.New(cfg, stockRepo))
+
+	harness := &integrationHarness{
+		t:          t,
+		partitions: map[string]*mocks.PartitionConsumer{},
+		marked:     make(chan *sarama.ConsumerMessage, 64),
+		producer:   mocks.NewSyncProducer(t, nil),
+		repo:       stockRepo,
+	}
+
+	consumer := mocks.NewConsumer(t, nil)
+	for _, topic := range cfg.Kafka.GetTopicNames() {
+		harness.partitions[topic] = consumer.ExpectConsumePartition(topic, 0, sarama.OffsetOldest)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		consumeKafka(ctx, cfg, &mockConsumerGroup{consumer: consumer, marked: harness.marked}, harness.producer, stockHandler)
+	}()
+
+	interceptors, err := NewInterceptors(cfg)
+	if err != nil {
+		t.Fatalf("NewInterceptors() err = %v", err)
+	}
+
+	grpcServer, err := newGRPCServer(cfg, stockHandler, interceptors)
+	if err != nil {
+		t.Fatalf("newGRPCServer() err = %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() err = %v", err)
+	}
+	harness.client = proto.NewStockClient(conn)
+	harness.admin = proto.NewStockAdminClient(conn)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		grpcServer.Stop()
+
+		cancel()
+		<-consumed
+		_ = consumer.Close()
+		_ = harness.producer.Close()
+	})
+
+	return harness
+}
+
+// publish produces transaction to partition 0 of topic and waits until the consumer has processed it
+func (harness *integrationHarness) publish(topic string, transaction model.KafkaTransaction) {
+	harness.t.Helper()
+
+	value, err := json.Marshal(transaction)
+	if err != nil {
+		harness.t.Fatalf("json.Marshal() err = %v", err)
+	}
+
+	message := &sarama.ConsumerMessage{
+		Key:       []byte(transaction.StockCode),
+		Value:     value,
+		Timestamp: time.Now(),
+	}
+	harness.partitions[topic].YieldMessage(message)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case marked := <-harness.marked:
+			if marked.Topic == message.Topic && marked.Offset == message.Offset {
+				return
+			}
+		case <-timeout:
+			harness.t.Fatalf("message %s/%d was not processed", message.Topic, message.Offset)
+		}
+	}
+}
+
+// mockConsumerGroup is a single member group that is assigned partition 0 of every topic, consumed from the
+// partition consumers of a sarama mock consumer. Marked messages are sent to marked instead of being committed.
+type mockConsumerGroup struct {
+	consumer *mocks.Consumer
+	marked   chan<- *sarama.ConsumerMessage
+}
+
+func (group *mockConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	session := &mockConsumerGroupSession{ctx: ctx, marked: group.marked, claims: map[string][]int32{}}
+
+	var claims []*mockConsumerGroupClaim
+	for _, topic := range topics {
+		partitionConsumer, err := group.consumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
+		if err != nil {
+			return err
+		}
+
+		session.claims[topic] = []int32{0}
+		claims = append(claims, &mockConsumerGroupClaim{topic: topic, PartitionConsumer: partitionConsumer})
+	}
+
+	if err := handler.Setup(session); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, claim := range claims {
+		wg.Add(1)
+		go func(claim *mockConsumerGroupClaim) {
+			defer wg.Done()
+			_ = handler.ConsumeClaim(session, claim)
+		}(claim)
+	}
+
+	// As in a real group, the claims end when the session's context is done
+	<-ctx.Done()
+	for _, claim := range claims {
+		claim.AsyncClose()
+	}
+	wg.Wait()
+
+	return handler.Cleanup(session)
+}
+
+func (group *mockConsumerGroup) Errors() <-chan error                 { return nil }
+func (group *mockConsumerGroup) Close() error                         { return nil }
+func (group *mockConsumerGroup) Pause(partitions map[string][]int32)  {}
+func (group *mockConsumerGroup) Resume(partitions map[string][]int32) {}
+func (group *mockConsumerGroup) PauseAll()                            {}
+func (group *mockConsumerGroup) ResumeAll()                           {}
+
+type mockConsumerGroupSession struct {
+	ctx    context.Context
+	marked chan<- *sarama.ConsumerMessage
+	claims map[string][]int32
+}
+
+func (session *mockConsumerGroupSession) Claims() map[string][]int32 { return session.claims }
+func (session *mockConsumerGroupSession) MemberID() string           { return "integration" }
+func (session *mockConsumerGroupSession) GenerationID() int32        { return 1 }
+func (session *mockConsumerGroupSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+}
+func (session *mockConsumerGroupSession) Commit() {}
+func (session *mockConsumerGroupSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+}
+func (session *mockConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	session.marked <- msg
+}
+func (session *mockConsumerGroupSession) Context() context.Context { return session.ctx }
+
+type mockConsumerGroupClaim struct {
+	sarama.PartitionConsumer
+	topic string
+}
+
+func (claim *mockConsumerGroupClaim) Topic() string        { return claim.topic }
+func (claim *mockConsumerGroupClaim) Partition() int32     { return 0 }
+func (claim *mockConsumerGroupClaim) InitialOffset() int64 { return sarama.OffsetOldest }
@@ -7,54 +7,89 @@ package server
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 
 	"stock/handler"
+	"stock/logger"
 	"stock/model"
 
 	"github.com/IBM/sarama"
 )
 
 func ServeKafka(cfg model.Config, handler *handler.Handler) {
-	config := sarama.NewConfig()
-	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRange()
-	config.Consumer.Offsets.Initial = sarama.OffsetNewest
-	config.Version = sarama.DefaultVersion
+	log := logger.For("kafka")
 
-	broker := fmt.Sprintf("%s%s", cfg.Kafka.Host, cfg.Kafka.Port)
-	brokers := []string{broker}
-	groupID := cfg.Kafka.GroupID
+	config, err := newKafkaConfig(cfg.Kafka)
+	if err != nil {
+		logger.Fatal(log, "Invalid config", logger.Err(err))
+	}
 
-	consumerGroup, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	consumerGroup, err := sarama.NewConsumerGroup(cfg.Kafka.GetBrokers(), cfg.Kafka.GroupID, config)
 	if err != nil {
-		log.Fatalf("[Error][Kafka] Failed creating consumer group: %v", err)
+		logger.Fatal(log, "Failed creating consumer group", logger.Err(err))
 	}
 	defer func() {
 		if err = consumerGroup.Close(); err != nil {
-			log.Printf("[Error][Kafka] Failed closing consumer group: %v", err)
+			log.Error("Failed closing consumer group", logger.Err(err))
 		}
 	}()
 
-	topics := []string{cfg.Kafka.Topic}
+	// Late trades are rejected by the usecase; publish them to the dead-letter topic instead of only logging them
+	var producer sarama.SyncProducer
+	if cfg.Lateness.DeadLetterTopic != "" {
+		producer, err = NewSyncProducer(cfg.Kafka)
+		if err != nil {
+			logger.Fatal(log, "Failed creating dead-letter producer", logger.Err(err))
+		}
+		defer func() {
+			if err = producer.Close(); err != nil {
+				log.Error("Failed closing dead-letter producer", logger.Err(err))
+			}
+		}()
+	}
 
-	consumer := &model.Consumer{Handler: handler.ProcessStockTransaction}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, os.Interrupt)
+	consumeKafka(ctx, cfg, consumerGroup, producer, handler)
+}
 
-	log.Printf("[Kafka] Serving on port %s", cfg.Kafka.Port)
-	for {
-		if err := consumerGroup.Consume(context.Background(), topics, consumer); err != nil {
-			log.Printf("[Error][Kafka] Error from consumer: %v", err)
-		}
+// NewSyncProducer returns a producer to the brokers of the consumer group, with the same client settings.
+// Messages are only acknowledged once every in-sync replica has them.
+func NewSyncProducer(cfg model.KafkaConsumer) (sarama.SyncProducer, error) {
+	config, err := newKafkaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+
+	return sarama.NewSyncProducer(cfg.GetBrokers(), config)
+}
+
+// consumeKafka processes the messages of the configured topics with handler until ctx is done, rejoining the group
+// after every rebalance. Late trades are published to the dead-letter topic when producer is not nil.
+func consumeKafka(ctx context.Context, cfg model.Config, consumerGroup sarama.ConsumerGroup, producer sarama.SyncProducer,
+	handler *handler.Handler,
+) {
+	log := logger.For("kafka")
+
+	topics := cfg.Kafka.GetTopicNames()
+
+	consumer := &model.Consumer{Handler: handler.ProcessStockTransaction}
+	if producer != nil {
+		consumer.Handler = withDeadLetter(producer, cfg.Lateness.DeadLetterTopic, consumer.Handler)
+	}
+
+	// Every message is logged with the component, in addition to its own fields
+	ctx = logger.ContextWith(ctx, slog.String(logger.KeyComponent, "kafka"))
 
-		select {
-		case <-signals:
-			return
-		default:
+	log.Info("Serving", "brokers", cfg.Kafka.GetBrokers(), "topics", topics)
+	for ctx.Err() == nil {
+		if err := consumerGroup.Consume(ctx, topics, consumer); err != nil {
+			log.Error("Error from consumer", logger.Err(err))
 		}
 	}
 }
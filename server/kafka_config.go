@@ -0,0 +1,213 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"stock/model"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+)
+
+// newKafkaConfig returns the sarama config shared by the consumer group and the replay command
+func newKafkaConfig(cfg model.KafkaConsumer) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+
+	config.Version = sarama.DefaultVersion
+	if cfg.Version != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.Version)
+		if err != nil {
+			return nil, err
+		}
+		config.Version = version
+	}
+
+	if cfg.ClientID != "" {
+		config.ClientID = cfg.ClientID
+	}
+
+	strategy, err := newKafkaRebalanceStrategy(cfg.RebalanceStrategy)
+	if err != nil {
+		return nil, err
+	}
+	config.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{strategy}
+
+	switch cfg.InitialOffset {
+	case model.KafkaOffsetNewest, "":
+		config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	case model.KafkaOffsetOldest:
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	default:
+		return nil, fmt.Errorf("invalid initial offset %s", cfg.InitialOffset)
+	}
+
+	for _, topic := range cfg.GetTopics() {
+		if topic.Name == "" {
+			return nil, errors.New("topic name is required")
+		}
+
+		switch topic.Decoder {
+		case model.KafkaDecoderJSON, model.KafkaDecoderCSV, "":
+		default:
+			return nil, fmt.Errorf("invalid decoder %s of topic %s", topic.Decoder, topic.Name)
+		}
+	}
+
+	// Zero values keep the sarama defaults
+	if cfg.SessionTimeout > 0 {
+		config.Consumer.Group.Session.Timeout = cfg.SessionTimeout
+	}
+	if cfg.HeartbeatInterval > 0 {
+		config.Consumer.Group.Heartbeat.Interval = cfg.HeartbeatInterval
+	}
+	if cfg.FetchMinBytes > 0 {
+		config.Consumer.Fetch.Min = cfg.FetchMinBytes
+	}
+	if cfg.FetchDefaultBytes > 0 {
+		config.Consumer.Fetch.Default = cfg.FetchDefaultBytes
+	}
+	if cfg.FetchMaxBytes > 0 {
+		config.Consumer.Fetch.Max = cfg.FetchMaxBytes
+	}
+	if cfg.MaxWaitTime > 0 {
+		config.Consumer.MaxWaitTime = cfg.MaxWaitTime
+	}
+
+	if cfg.SASL.Enabled {
+		if err := setKafkaSASL(config, cfg.SASL); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newKafkaTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+
+	// Validate catches inconsistent settings, e.g. a heartbeat interval longer than the session timeout
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func newKafkaRebalanceStrategy(name string) (sarama.BalanceStrategy, error) {
+	switch name {
+	case model.KafkaRebalanceRange, "":
+		return sarama.NewBalanceStrategyRange(), nil
+	case model.KafkaRebalanceRoundRobin:
+		return sarama.NewBalanceStrategyRoundRobin(), nil
+	case model.KafkaRebalanceSticky:
+		return sarama.NewBalanceStrategySticky(), nil
+	case model.KafkaRebalanceCooperativeSticky:
+		// The cooperative rebalance protocol is not implemented by sarama: it would silently rebalance eagerly
+		return nil, errors.New("rebalance strategy cooperative-sticky is not supported by the Kafka client, use sticky instead")
+	default:
+		return nil, fmt.Errorf("invalid rebalance strategy %s", name)
+	}
+}
+
+func setKafkaSASL(config *sarama.Config, cfg model.KafkaSASL) error {
+	if cfg.Username == "" {
+		return errors.New("sasl requires username")
+	}
+
+	config.Net.SASL.Enable = true
+	config.Net.SASL.User = cfg.Username
+	config.Net.SASL.Password = cfg.Password
+
+	switch cfg.Mechanism {
+	case sarama.SASLTypePlaintext:
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case sarama.SASLTypeSCRAMSHA256:
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: sha256.New}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: sha512.New}
+		}
+	default:
+		return fmt.Errorf("invalid sasl mechanism %s", cfg.Mechanism)
+	}
+
+	return nil
+}
+
+func newKafkaTLSConfig(cfg model.KafkaTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+		// #nosec G402 -- only for test brokers with self-signed certificates, as documented
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificate found in %s", cfg.CAFile)
+		}
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("tls client certificate requires both cert_file and key_file")
+	}
+
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// scramClient runs the client side of a SCRAM conversation for sarama
+type scramClient struct {
+	hashGenerator scram.HashGeneratorFcn
+	conversation  *scram.ClientConversation
+}
+
+func (client *scramClient) Begin(username, password, authzID string) error {
+	scramClient, err := client.hashGenerator.NewClient(username, password, authzID)
+	if err != nil {
+		return err
+	}
+
+	client.conversation = scramClient.NewConversation()
+	return nil
+}
+
+func (client *scramClient) Step(challenge string) (string, error) {
+	return client.conversation.Step(challenge)
+}
+
+func (client *scramClient) Done() bool {
+	return client.conversation.Done()
+}
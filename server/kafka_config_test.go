@@ -0,0 +1,210 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"stock/model"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+)
+
+func Test_newKafkaConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := generateTestCertificate(t, dir, "ca", true, nil)
+	client := generateTestCertificate(t, dir, "client", false, &ca)
+
+	tests := []struct {
+		name  string
+		cfg   model.KafkaConsumer
+		check func(t *testing.T, config *sarama.Config)
+
+		wantErr bool
+	}{
+		{
+			name: "success-default",
+			cfg:  model.DefaultConfigLocal.Kafka,
+			check: func(t *testing.T, config *sarama.Config) {
+				if config.Version != sarama.DefaultVersion {
+					t.Errorf("newKafkaConfig() Version = %v, want %v", config.Version, sarama.DefaultVersion)
+				}
+				if name := config.Consumer.Group.Rebalance.GroupStrategies[0].Name(); name != sarama.RangeBalanceStrategyName {
+					t.Errorf("newKafkaConfig() strategy = %s, want %s", name, sarama.RangeBalanceStrategyName)
+				}
+				if config.Consumer.Offsets.Initial != sarama.OffsetNewest {
+					t.Errorf("newKafkaConfig() Initial = %d, want %d", config.Consumer.Offsets.Initial, sarama.OffsetNewest)
+				}
+				if config.ClientID != "stock" {
+					t.Errorf("newKafkaConfig() ClientID = %s, want stock", config.ClientID)
+				}
+			},
+		},
+		{
+			name: "success-tuned",
+			cfg: model.KafkaConsumer{
+				Topics:            []model.KafkaTopic{{Name: "stock"}, {Name: "stock-csv", Decoder: model.KafkaDecoderCSV}},
+				Version:           "2.8.0",
+				RebalanceStrategy: model.KafkaRebalanceSticky,
+				SessionTimeout:    30 * time.Second,
+				HeartbeatInterval: 5 * time.Second,
+				FetchMinBytes:     1024,
+				FetchDefaultBytes: 4 << 20,
+				FetchMaxBytes:     16 << 20,
+				MaxWaitTime:       time.Second,
+				InitialOffset:     model.KafkaOffsetOldest,
+			},
+			check: func(t *testing.T, config *sarama.Config) {
+				if config.Version != sarama.V2_8_0_0 {
+					t.Errorf("newKafkaConfig() Version = %v, want %v", config.Version, sarama.V2_8_0_0)
+				}
+				if name := config.Consumer.Group.Rebalance.GroupStrategies[0].Name(); name != sarama.StickyBalanceStrategyName {
+					t.Errorf("newKafkaConfig() strategy = %s, want %s", name, sarama.StickyBalanceStrategyName)
+				}
+				if config.Consumer.Group.Session.Timeout != 30*time.Second || config.Consumer.Group.Heartbeat.Interval != 5*time.Second {
+					t.Errorf("newKafkaConfig() session timeout = %v, heartbeat interval = %v", config.Consumer.Group.Session.Timeout, config.Consumer.Group.Heartbeat.Interval)
+				}
+				if config.Consumer.Fetch.Min != 1024 || config.Consumer.Fetch.Default != 4<<20 || config.Consumer.Fetch.Max != 16<<20 {
+					t.Errorf("newKafkaConfig() fetch = %+v", config.Consumer.Fetch)
+				}
+				if config.Consumer.MaxWaitTime != time.Second {
+					t.Errorf("newKafkaConfig() MaxWaitTime = %v, want %v", config.Consumer.MaxWaitTime, time.Second)
+				}
+				if config.Consumer.Offsets.Initial != sarama.OffsetOldest {
+					t.Errorf("newKafkaConfig() Initial = %d, want %d", config.Consumer.Offsets.Initial, sarama.OffsetOldest)
+				}
+			},
+		},
+		{
+			name: "success-sasl-scram-tls",
+			cfg: model.KafkaConsumer{
+				Topic:             "stock",
+				RebalanceStrategy: model.KafkaRebalanceRoundRobin,
+				SASL:              model.KafkaSASL{Enabled: true, Mechanism: sarama.SASLTypeSCRAMSHA512, Username: "stock", Password: "secret"},
+				TLS:               model.KafkaTLS{Enabled: true, CAFile: ca.certFile, CertFile: client.certFile, KeyFile: client.keyFile},
+			},
+			check: func(t *testing.T, config *sarama.Config) {
+				if !config.Net.SASL.Enable || config.Net.SASL.Mechanism != sarama.SASLTypeSCRAMSHA512 || config.Net.SASL.SCRAMClientGeneratorFunc == nil {
+					t.Errorf("newKafkaConfig() SASL = %+v", config.Net.SASL)
+				}
+				if !config.Net.TLS.Enable || config.Net.TLS.Config.RootCAs == nil || len(config.Net.TLS.Config.Certificates) != 1 {
+					t.Errorf("newKafkaConfig() TLS = %+v", config.Net.TLS)
+				}
+			},
+		},
+		{
+			name:    "error-cooperative-sticky",
+			cfg:     model.KafkaConsumer{Topic: "stock", RebalanceStrategy: model.KafkaRebalanceCooperativeSticky},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-rebalance-strategy",
+			cfg:     model.KafkaConsumer{Topic: "stock", RebalanceStrategy: "balanced"},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-version",
+			cfg:     model.KafkaConsumer{Topic: "stock", Version: "latest"},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-initial-offset",
+			cfg:     model.KafkaConsumer{Topic: "stock", InitialOffset: "latest"},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-decoder",
+			cfg:     model.KafkaConsumer{Topics: []model.KafkaTopic{{Name: "stock", Decoder: "avro"}}},
+			wantErr: true,
+		},
+		{
+			name:    "error-missing-topic",
+			cfg:     model.KafkaConsumer{},
+			wantErr: true,
+		},
+		{
+			name:    "error-heartbeat-after-session-timeout",
+			cfg:     model.KafkaConsumer{Topic: "stock", SessionTimeout: 5 * time.Second, HeartbeatInterval: 10 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "error-invalid-sasl-mechanism",
+			cfg:     model.KafkaConsumer{Topic: "stock", SASL: model.KafkaSASL{Enabled: true, Mechanism: "GSSAPI", Username: "stock"}},
+			wantErr: true,
+		},
+		{
+			name:    "error-sasl-missing-username",
+			cfg:     model.KafkaConsumer{Topic: "stock", SASL: model.KafkaSASL{Enabled: true, Mechanism: sarama.SASLTypePlaintext}},
+			wantErr: true,
+		},
+		{
+			name:    "error-tls-missing-key",
+			cfg:     model.KafkaConsumer{Topic: "stock", TLS: model.KafkaTLS{Enabled: true, CertFile: client.certFile}},
+			wantErr: true,
+		},
+		{
+			name:    "error-tls-invalid-ca",
+			cfg:     model.KafkaConsumer{Topic: "stock", TLS: model.KafkaTLS{Enabled: true, CAFile: client.keyFile}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := newKafkaConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newKafkaConfig() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.check != nil {
+				tt.check(t, config)
+			}
+		})
+	}
+}
+
+func Test_scramClient(t *testing.T) {
+	for _, hashGenerator := range []scram.HashGeneratorFcn{scram.SHA256, scram.SHA512} {
+		credentials, err := hashGenerator.NewClient("stock", "secret", "")
+		if err != nil {
+			t.Fatalf("NewClient() err = %v", err)
+		}
+
+		server, err := hashGenerator.NewServer(func(username string) (scram.StoredCredentials, error) {
+			return credentials.GetStoredCredentials(scram.KeyFactors{Salt: "salt", Iters: 4096}), nil
+		})
+		if err != nil {
+			t.Fatalf("NewServer() err = %v", err)
+		}
+
+		client := &scramClient{hashGenerator: hashGenerator}
+		if err := client.Begin("stock", "secret", ""); err != nil {
+			t.Fatalf("scramClient.Begin() err = %v", err)
+		}
+
+		serverConversation := server.NewConversation()
+		challenge := ""
+		for !client.Done() {
+			response, err := client.Step(challenge)
+			if err != nil {
+				t.Fatalf("scramClient.Step() err = %v", err)
+			}
+			if response == "" {
+				break
+			}
+
+			if challenge, err = serverConversation.Step(response); err != nil {
+				t.Fatalf("server Step() err = %v", err)
+			}
+		}
+
+		if !serverConversation.Valid() {
+			t.Errorf("scramClient conversation is not valid")
+		}
+	}
+}
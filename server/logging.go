@@ -0,0 +1,116 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"stock/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	requestIDHeader = "x-request-id"
+
+	// maxRequestIDLength bounds the request IDs accepted from callers; longer ones are replaced
+	maxRequestIDLength = 128
+)
+
+// loggingUnaryInterceptor logs every call with its request ID, which is taken from the caller's metadata or
+// generated, and returned to the caller. Handlers log with the call's context to carry the same fields.
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	requestID := getRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+	attrs := []slog.Attr{
+		slog.String(logger.KeyRequestID, requestID),
+		slog.String(logger.KeyMethod, info.FullMethod),
+	}
+	if request, ok := req.(stockCodeRequest); ok {
+		attrs = append(attrs, slog.String(logger.KeyStockCode, request.GetStockCode()))
+	}
+	ctx = logger.ContextWith(ctx, attrs...)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, start, err)
+
+	return resp, err
+}
+
+func loggingStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	requestID := getRequestID(stream.Context())
+	_ = stream.SetHeader(metadata.Pairs(requestIDHeader, requestID))
+
+	ctx := logger.ContextWith(stream.Context(),
+		slog.String(logger.KeyRequestID, requestID),
+		slog.String(logger.KeyMethod, info.FullMethod),
+	)
+
+	start := time.Now()
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	logCall(ctx, start, err)
+
+	return err
+}
+
+// logCall logs a completed call at the info level, or at the warn or error level if it failed due to
+// the caller or the server respectively
+func logCall(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.ResourceExhausted, codes.FailedPrecondition, codes.OutOfRange, codes.Unauthenticated:
+		level = slog.LevelWarn
+	default:
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, logger.Err(err))
+	}
+
+	slog.Default().LogAttrs(ctx, level, "Handled call", attrs...)
+}
+
+// getRequestID returns the caller's request ID, or a new random one
+func getRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(requestIDHeader); len(values) > 0 && values[0] != "" && len(values[0]) <= maxRequestIDLength {
+		return values[0]
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// contextStream overrides the context of a stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextStream) Context() context.Context {
+	return stream.ctx
+}
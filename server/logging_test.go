@@ -0,0 +1,114 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextLoggingStockServer logs from GetStockSummary with the call's context
+type contextLoggingStockServer struct {
+	proto.UnimplementedStockServer
+}
+
+func (s *contextLoggingStockServer) GetStockSummary(ctx context.Context, req *proto.GetStockSummaryRequest) (*proto.GetStockSummaryResponse, error) {
+	slog.InfoContext(ctx, "Getting stock summary")
+	return nil, status.Error(codes.InvalidArgument, "invalid request")
+}
+
+func Test_loggingUnaryInterceptor(t *testing.T) {
+	var output bytes.Buffer
+	testLogger, _ := logger.New(model.Log{Level: "debug"}, &output)
+
+	defaultLogger := slog.Default()
+	slog.SetDefault(testLogger)
+	defer slog.SetDefault(defaultLogger)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(loggingUnaryInterceptor))
+	proto.RegisterStockServer(grpcServer, &contextLoggingStockServer{})
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() err = %v", err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		wantRequestID bool
+	}{
+		{
+			name:          "success-caller-request-id",
+			requestID:     "request-1",
+			wantRequestID: true,
+		},
+		{
+			name: "success-generated-request-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output.Reset()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if tt.requestID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, requestIDHeader, tt.requestID)
+			}
+
+			var header metadata.MD
+			_, _ = proto.NewStockClient(conn).GetStockSummary(ctx, &proto.GetStockSummaryRequest{StockCode: "BBCA"},
+				grpc.Header(&header))
+
+			requestIDs := header.Get(requestIDHeader)
+			if len(requestIDs) != 1 || requestIDs[0] == "" {
+				t.Fatalf("GetStockSummary() request IDs = %v, want one", requestIDs)
+			}
+			if tt.wantRequestID && requestIDs[0] != tt.requestID {
+				t.Errorf("GetStockSummary() request ID = %v, want %v", requestIDs[0], tt.requestID)
+			}
+
+			// Both the handler's and the interceptor's records carry the call's fields
+			decoder := json.NewDecoder(&output)
+			for _, wantMsg := range []string{"Getting stock summary", "Handled call"} {
+				record := map[string]interface{}{}
+				if err := decoder.Decode(&record); err != nil {
+					t.Fatalf("json.Decode() err = %v", err)
+				}
+
+				if record["msg"] != wantMsg || record[logger.KeyRequestID] != requestIDs[0] ||
+					record[logger.KeyStockCode] != "BBCA" ||
+					record[logger.KeyMethod] != proto.Stock_GetStockSummary_FullMethodName {
+					t.Errorf("loggingUnaryInterceptor() record = %v", record)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"expvar"
+	"net"
+	"net/http"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+)
+
+// ServeMetrics serves the service's expvar metrics in JSON on /debug/vars
+func ServeMetrics(cfg model.Config) {
+	log := logger.For("metrics")
+
+	listen, err := net.Listen(cfg.Metrics.Network, cfg.Metrics.Port)
+	if err != nil {
+		log.Error("Failed to listen", "port", cfg.Metrics.Port, logger.Err(err))
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	log.Info("Serving", "port", cfg.Metrics.Port)
+	if err := httpServer.Serve(listen); err != nil {
+		log.Error("Failed to serve metrics server", logger.Err(err))
+	}
+}
@@ -0,0 +1,159 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	openAPIVersion     = "3.0.3"
+	openAPIStatusName  = "Status"
+	openAPISchemasPath = "#/components/schemas/"
+)
+
+// newOpenAPIDocument describes the gateway's routes from the descriptors of stock.proto, so that the document
+// follows the proto definitions. Messages are described as encoded by protojson, e.g. int64 fields are strings.
+func newOpenAPIDocument(routes []gatewayRoute) ([]byte, error) {
+	schemas := map[string]interface{}{
+		openAPIStatusName: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+				"message": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		addOpenAPISchema(schemas, route.method.Output())
+
+		params := make([]string, 0, len(route.params))
+		for param := range route.params {
+			params = append(params, param)
+		}
+		sort.Strings(params)
+
+		var parameters []interface{}
+		for _, param := range params {
+			field := route.method.Input().Fields().ByName(route.params[param])
+			in, required := "query", false
+			if strings.Contains(route.pattern, "{"+param+"}") {
+				in, required = "path", true
+			}
+
+			parameters = append(parameters, map[string]interface{}{
+				"name":     param,
+				"in":       in,
+				"required": required,
+				"schema":   getOpenAPIFieldSchema(field),
+			})
+		}
+
+		paths[route.pattern] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": string(route.method.Name()),
+				"tags":        []string{string(route.method.Parent().Name())},
+				"parameters":  parameters,
+				"responses": map[string]interface{}{
+					"200": getOpenAPIResponse("OK", route.method.Output()),
+					"default": map[string]interface{}{
+						"description": "Error",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"$ref": openAPISchemasPath + openAPIStatusName},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   "Stock API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}, "", "  ")
+}
+
+func getOpenAPIResponse(description string, message protoreflect.MessageDescriptor) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": openAPISchemasPath + string(message.Name())},
+			},
+		},
+	}
+}
+
+// addOpenAPISchema adds the schema of message and of the messages it references
+func addOpenAPISchema(schemas map[string]interface{}, message protoreflect.MessageDescriptor) {
+	name := string(message.Name())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := map[string]interface{}{}
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[field.JSONName()] = getOpenAPIFieldSchema(field)
+
+		if field.Message() != nil {
+			addOpenAPISchema(schemas, field.Message())
+		}
+	}
+}
+
+func getOpenAPIFieldSchema(field protoreflect.FieldDescriptor) map[string]interface{} {
+	schema := getOpenAPIKindSchema(field)
+	if field.IsList() {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func getOpenAPIKindSchema(field protoreflect.FieldDescriptor) map[string]interface{} {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return map[string]interface{}{"$ref": openAPISchemasPath + string(field.Message().Name())}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"expvar"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"stock/model"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	retryAfterHeader = "retry-after"
+	rateLimitDateFmt = "2006-01-02"
+
+	// maxTokenBuckets bounds the number of tracked clients; full buckets are dropped once it is exceeded
+	maxTokenBuckets = 10000
+)
+
+var (
+	rateLimitMetrics = expvar.NewMap("rate_limit")
+)
+
+// rateLimiter rejects GRPC calls exceeding their client's token bucket or their method's in-flight cap
+type rateLimiter struct {
+	clientKeyHeader string
+	defaultLimit    *methodLimiter
+	methods         map[string]*methodLimiter
+	now             func() time.Time
+
+	mutex   sync.Mutex
+	buckets map[bucketKey]*tokenBucket
+}
+
+type methodLimiter struct {
+	limit    model.MethodLimit
+	inFlight chan struct{} // nil when in-flight calls are not capped
+}
+
+type bucketKey struct {
+	method string
+	client string
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// dateRangeRequest is implemented by requests for a range of daily data
+type dateRangeRequest interface {
+	GetFromDate() string
+	GetToDate() string
+}
+
+func newRateLimiter(cfg model.RateLimit) *rateLimiter {
+	limiter := &rateLimiter{
+		clientKeyHeader: cfg.ClientKeyHeader,
+		defaultLimit:    newMethodLimiter(cfg.Default),
+		methods:         map[string]*methodLimiter{},
+		now:             time.Now,
+		buckets:         map[bucketKey]*tokenBucket{},
+	}
+
+	for _, limit := range cfg.Methods {
+		limiter.methods[limit.Method] = newMethodLimiter(limit)
+	}
+
+	return limiter
+}
+
+func newMethodLimiter(limit model.MethodLimit) *methodLimiter {
+	method := &methodLimiter{limit: limit}
+	if limit.MaxInFlight > 0 {
+		method.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return method
+}
+
+func (limiter *rateLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	release, retryAfter, err := limiter.acquire(ctx, info.FullMethod, req)
+	if err != nil {
+		_ = grpc.SetHeader(ctx, retryAfterMetadata(retryAfter))
+		return nil, err
+	}
+	defer release()
+
+	return handler(ctx, req)
+}
+
+func (limiter *rateLimiter) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if info.IsClientStream {
+		// Client streams have no single request to weigh, so they cost one token
+		release, retryAfter, err := limiter.acquire(stream.Context(), info.FullMethod, nil)
+		if err != nil {
+			_ = stream.SetHeader(retryAfterMetadata(retryAfter))
+			return err
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+
+	limitedStream := &rateLimitedStream{ServerStream: stream, limiter: limiter, method: info.FullMethod, release: func() {}}
+	defer func() { limitedStream.release() }()
+
+	return handler(srv, limitedStream)
+}
+
+// rateLimitedStream acquires a server-streaming call's limits once its request is received, so that the call is
+// weighed by the request like a unary call
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter  *rateLimiter
+	method   string
+	received bool
+	release  func()
+}
+
+func (stream *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := stream.ServerStream.RecvMsg(m); err != nil || stream.received {
+		return err
+	}
+	stream.received = true
+
+	release, retryAfter, err := stream.limiter.acquire(stream.Context(), stream.method, m)
+	if err != nil {
+		_ = stream.SetHeader(retryAfterMetadata(retryAfter))
+		return err
+	}
+
+	stream.release = release
+	return nil
+}
+
+// acquire takes a slot of the call's method's in-flight cap, then the call's tokens from its client's bucket, so that
+// calls rejected by the cap don't use up tokens.
+// On rejection, it returns a ResourceExhausted error and how long the client should wait before retrying.
+func (limiter *rateLimiter) acquire(ctx context.Context, method string, req interface{}) (func(), time.Duration, error) {
+	methodLimiter, ok := limiter.methods[method]
+	if !ok {
+		methodLimiter = limiter.defaultLimit
+	}
+
+	release := func() {}
+	if methodLimiter.inFlight != nil {
+		select {
+		case methodLimiter.inFlight <- struct{}{}:
+			release = func() { <-methodLimiter.inFlight }
+		default:
+			rateLimitMetrics.Add("concurrency_limited", 1)
+			return nil, time.Second, status.Errorf(codes.ResourceExhausted, "too many concurrent calls of %s", method)
+		}
+	}
+
+	client := limiter.getClientKey(ctx)
+	if retryAfter, ok := limiter.take(bucketKey{method: method, client: client}, methodLimiter.limit,
+		getRequestCost(methodLimiter.limit, req)); !ok {
+		release()
+		rateLimitMetrics.Add("rate_limited", 1)
+		return nil, retryAfter, status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded for client %s",
+			method, client)
+	}
+
+	return release, 0, nil
+}
+
+// take removes cost tokens from the bucket, or returns how long until the bucket holds enough tokens
+func (limiter *rateLimiter) take(key bucketKey, limit model.MethodLimit, cost float64) (time.Duration, bool) {
+	if limit.RequestsPerSecond <= 0 || limit.Burst <= 0 {
+		return 0, true
+	}
+
+	now := limiter.now()
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		if len(limiter.buckets) >= maxTokenBuckets {
+			limiter.dropFullBuckets(now)
+		}
+
+		bucket = &tokenBucket{tokens: float64(limit.Burst), updatedAt: now}
+		limiter.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*limit.RequestsPerSecond)
+	bucket.updatedAt = now
+
+	if bucket.tokens < cost {
+		return time.Duration((cost - bucket.tokens) / limit.RequestsPerSecond * float64(time.Second)), false
+	}
+
+	bucket.tokens -= cost
+	return 0, true
+}
+
+// dropFullBuckets forgets the clients whose buckets have refilled, as they are indistinguishable from new clients
+func (limiter *rateLimiter) dropFullBuckets(now time.Time) {
+	for key, bucket := range limiter.buckets {
+		limit := limiter.defaultLimit.limit
+		if methodLimiter, ok := limiter.methods[key.method]; ok {
+			limit = methodLimiter.limit
+		}
+
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*limit.RequestsPerSecond >= float64(limit.Burst) {
+			delete(limiter.buckets, key)
+		}
+	}
+}
+
+// getClientKey identifies the caller by its authenticated identity, its client key metadata, or its peer address
+func (limiter *rateLimiter) getClientKey(ctx context.Context) string {
+	if identity, ok := model.IdentityFromContext(ctx); ok {
+		return "identity:" + identity.ClientID
+	}
+
+	if limiter.clientKeyHeader != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(limiter.clientKeyHeader); len(values) > 0 && values[0] != "" {
+			return "key:" + values[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer:" + host
+	}
+
+	return "unknown"
+}
+
+// getRequestCost weighs a call by the date range it requests, capped at the burst so that any call can pass
+func getRequestCost(limit model.MethodLimit, req interface{}) float64 {
+	request, ok := req.(dateRangeRequest)
+	if !ok || limit.DaysPerToken <= 0 {
+		return 1
+	}
+
+	fromDate, err := time.Parse(rateLimitDateFmt, request.GetFromDate())
+	if err != nil {
+		return 1
+	}
+	toDate, err := time.Parse(rateLimitDateFmt, request.GetToDate())
+	if err != nil || toDate.Before(fromDate) {
+		return 1
+	}
+
+	days := int(toDate.Sub(fromDate).Hours()/24) + 1
+	cost := 1 + float64((days-1)/limit.DaysPerToken)
+	if limit.Burst > 0 {
+		cost = math.Min(cost, float64(limit.Burst))
+	}
+	return cost
+}
+
+// retryAfterMetadata holds the retry delay in whole seconds, rounded up
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(retryAfterHeader, strconv.FormatInt(seconds, 10))
+}
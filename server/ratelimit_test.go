@@ -0,0 +1,296 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func Test_getRequestCost(t *testing.T) {
+	limit := model.MethodLimit{Burst: 10, DaysPerToken: 31}
+
+	tests := []struct {
+		name  string
+		limit model.MethodLimit
+		req   interface{}
+		want  float64
+	}{
+		{
+			name:  "success-single-day",
+			limit: limit,
+			req:   &proto.GetStockSummaryRequest{FromDate: "2023-08-01", ToDate: "2023-08-01"},
+			want:  1,
+		},
+		{
+			name:  "success-one-month",
+			limit: limit,
+			req:   &proto.GetStockSummaryRequest{FromDate: "2023-08-01", ToDate: "2023-08-31"},
+			want:  1,
+		},
+		{
+			name:  "success-two-months",
+			limit: limit,
+			req:   &proto.GetStockSummaryRequest{FromDate: "2023-08-01", ToDate: "2023-09-30"},
+			want:  2,
+		},
+		{
+			name:  "success-capped-at-burst",
+			limit: limit,
+			req:   &proto.GetStockSummaryRequest{FromDate: "2013-01-01", ToDate: "2023-12-31"},
+			want:  10,
+		},
+		{
+			name:  "success-not-weighted",
+			limit: model.MethodLimit{Burst: 10},
+			req:   &proto.GetStockSummaryRequest{FromDate: "2013-01-01", ToDate: "2023-12-31"},
+			want:  1,
+		},
+		{
+			name:  "success-invalid-date",
+			limit: limit,
+			req:   &proto.GetStockSummaryRequest{FromDate: "2023-08", ToDate: "2023-12-31"},
+			want:  1,
+		},
+		{
+			name:  "success-no-date-range",
+			limit: limit,
+			req:   nil,
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRequestCost(tt.limit, tt.req); got != tt.want {
+				t.Errorf("getRequestCost() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rateLimiter_take(t *testing.T) {
+	now := time.Date(2023, 8, 29, 9, 0, 0, 0, time.UTC)
+
+	limiter := newRateLimiter(model.RateLimit{Default: model.MethodLimit{RequestsPerSecond: 2, Burst: 4}})
+	limiter.now = func() time.Time { return now }
+
+	key := bucketKey{method: proto.Stock_GetStockSummary_FullMethodName, client: "identity:dashboard"}
+	limit := limiter.defaultLimit.limit
+
+	if _, ok := limiter.take(key, limit, 3); !ok {
+		t.Fatalf("rateLimiter.take() rejected the first call")
+	}
+
+	retryAfter, ok := limiter.take(key, limit, 3)
+	if ok {
+		t.Fatalf("rateLimiter.take() accepted a call exceeding the burst")
+	}
+	if retryAfter != time.Second {
+		t.Errorf("rateLimiter.take() retryAfter = %v, want %v", retryAfter, time.Second)
+	}
+
+	// Other clients have their own bucket
+	if _, ok := limiter.take(bucketKey{method: key.method, client: "identity:reporting"}, limit, 3); !ok {
+		t.Errorf("rateLimiter.take() rejected another client")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := limiter.take(key, limit, 3); !ok {
+		t.Errorf("rateLimiter.take() rejected a call after the bucket refilled")
+	}
+}
+
+func Test_rateLimiter_getClientKey(t *testing.T) {
+	limiter := newRateLimiter(model.RateLimit{ClientKeyHeader: "x-client-id"})
+	peerAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "success-identity",
+			ctx: model.ContextWithIdentity(metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("x-client-id", "dashboard")), model.Identity{ClientID: "reporting"}),
+			want: "identity:reporting",
+		},
+		{
+			name: "success-metadata-key",
+			ctx: peer.NewContext(metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("x-client-id", "dashboard")), &peer.Peer{Addr: peerAddr}),
+			want: "key:dashboard",
+		},
+		{
+			name: "success-peer-address",
+			ctx:  peer.NewContext(context.Background(), &peer.Peer{Addr: peerAddr}),
+			want: "peer:10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limiter.getClientKey(tt.ctx); got != tt.want {
+				t.Errorf("rateLimiter.getClientKey() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// blockingStockServer holds GetStockSummary calls until release is closed
+type blockingStockServer struct {
+	proto.UnimplementedStockServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStockServer) GetStockSummary(ctx context.Context, req *proto.GetStockSummaryRequest) (*proto.GetStockSummaryResponse, error) {
+	s.started <- struct{}{}
+	<-s.release
+	return &proto.GetStockSummaryResponse{}, nil
+}
+
+func startTestRateLimitedServer(t *testing.T, cfg model.RateLimit, stockServer proto.StockServer) proto.StockClient {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+
+	limiter := newRateLimiter(cfg)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(limiter.unaryInterceptor),
+		grpc.ChainStreamInterceptor(limiter.streamInterceptor))
+	proto.RegisterStockServer(grpcServer, stockServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() err = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return proto.NewStockClient(conn)
+}
+
+func Test_rateLimiter_unaryInterceptor(t *testing.T) {
+	client := startTestRateLimitedServer(t, model.RateLimit{
+		ClientKeyHeader: "x-client-id",
+		Methods: []model.MethodLimit{
+			{Method: proto.Stock_GetStockSummary_FullMethodName, RequestsPerSecond: 0.01, Burst: 1},
+		},
+	}, &proto.UnimplementedStockServer{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-client-id", "dashboard")
+
+	// Reaching the unimplemented handler means the call was let through
+	_, err := client.GetStockSummary(ctx, &proto.GetStockSummaryRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("GetStockSummary() err = %v, want code %v", err, codes.Unimplemented)
+	}
+
+	var header metadata.MD
+	_, err = client.GetStockSummary(ctx, &proto.GetStockSummaryRequest{}, grpc.Header(&header))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("GetStockSummary() err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+	if got := header.Get(retryAfterHeader); len(got) != 1 || got[0] != "100" {
+		t.Errorf("GetStockSummary() retry-after = %v, want [100]", got)
+	}
+}
+
+func Test_rateLimiter_streamInterceptor(t *testing.T) {
+	client := startTestRateLimitedServer(t, model.RateLimit{
+		ClientKeyHeader: "x-client-id",
+		Methods: []model.MethodLimit{
+			{Method: proto.Stock_ExportStockSummaries_FullMethodName, RequestsPerSecond: 0.01, Burst: 10, DaysPerToken: 1},
+		},
+	}, &proto.UnimplementedStockServer{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-client-id", "dashboard")
+
+	// Ten days take the whole burst
+	stream, err := client.ExportStockSummaries(ctx, &proto.ExportStockSummariesRequest{
+		FromDate: "2023-08-01", ToDate: "2023-08-10", Format: "csv",
+	})
+	if err != nil {
+		t.Fatalf("ExportStockSummaries() err = %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("ExportStockSummaries() err = %v, want code %v", err, codes.Unimplemented)
+	}
+
+	stream, err = client.ExportStockSummaries(ctx, &proto.ExportStockSummariesRequest{
+		FromDate: "2023-08-01", ToDate: "2023-08-01", Format: "csv",
+	})
+	if err != nil {
+		t.Fatalf("ExportStockSummaries() err = %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("ExportStockSummaries() err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("stream.Header() err = %v", err)
+	}
+	if got := header.Get(retryAfterHeader); len(got) != 1 || got[0] != "100" {
+		t.Errorf("ExportStockSummaries() retry-after = %v, want [100]", got)
+	}
+}
+
+func Test_rateLimiter_MaxInFlight(t *testing.T) {
+	stockServer := &blockingStockServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+	// Two tokens cover the two calls that pass, as calls rejected by the cap don't take any
+	client := startTestRateLimitedServer(t, model.RateLimit{
+		Default: model.MethodLimit{MaxInFlight: 1, RequestsPerSecond: 0.01, Burst: 2},
+	}, stockServer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetStockSummary(ctx, &proto.GetStockSummaryRequest{})
+		done <- err
+	}()
+	<-stockServer.started
+
+	var header metadata.MD
+	_, err := client.GetStockSummary(ctx, &proto.GetStockSummaryRequest{}, grpc.Header(&header))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("GetStockSummary() err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+	if got := header.Get(retryAfterHeader); len(got) != 1 {
+		t.Errorf("GetStockSummary() retry-after = %v, want one value", got)
+	}
+
+	close(stockServer.release)
+	if err := <-done; err != nil {
+		t.Fatalf("GetStockSummary() err = %v", err)
+	}
+
+	// The slot is released once the call completes
+	go func() { <-stockServer.started }()
+	if _, err := client.GetStockSummary(ctx, &proto.GetStockSummaryRequest{}); err != nil {
+		t.Errorf("GetStockSummary() err = %v", err)
+	}
+}
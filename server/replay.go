@@ -0,0 +1,321 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"stock/model"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	replayDateFmt = "2006-01-02"
+)
+
+// KafkaReplayer moves the committed offsets of the consumer group back, so that the consumers consume transactions
+// again once they are restarted. The consumers must be stopped during a replay, as Kafka rejects offsets committed
+// for a group that has active members.
+type KafkaReplayer struct {
+	client  sarama.Client
+	groupID string
+	// locations are the timezones of trading days: the exchange's, then those of the instruments
+	locations []*time.Location
+}
+
+func NewKafkaReplayer(cfg model.Config) (*KafkaReplayer, error) {
+	config, err := newKafkaConfig(cfg.Kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(cfg.Kafka.GetBrokers(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := []*time.Location{cfg.Schedule.GetLocation(model.Instrument{})}
+	for _, instrument := range cfg.Instruments {
+		locations = append(locations, cfg.Schedule.GetLocation(instrument))
+	}
+
+	return &KafkaReplayer{
+		client:    client,
+		groupID:   cfg.Kafka.GroupID,
+		locations: locations,
+	}, nil
+}
+
+func (replayer *KafkaReplayer) Close() error {
+	return replayer.client.Close()
+}
+
+// Plan resolves the offset of the first replayed message of each partition of topics, and the date from which stock
+// summaries must be cleared, as described by replay. It fails when the consumer group still has active members.
+// Replays from a timestamp start at the start of the cleared date, so that cleared summaries are rebuilt whole.
+func (replayer *KafkaReplayer) Plan(topics []string, replay model.Replay) (model.ReplayPlan, error) {
+	plan := model.ReplayPlan{Offsets: map[string]map[int32]int64{}}
+
+	if err := replayer.checkGroupInactive(); err != nil {
+		return plan, err
+	}
+
+	var clearFromDate *time.Time
+	if replay.ClearFromDate != "" {
+		date, err := time.Parse(replayDateFmt, replay.ClearFromDate)
+		if err != nil {
+			return plan, fmt.Errorf("invalid clear_from_date %s: %w", replay.ClearFromDate, err)
+		}
+		clearFromDate = &date
+	}
+
+	var err error
+	switch replay.From {
+	case model.ReplayFromEarliest:
+		// Every transaction is replayed, so every summary must be recomputed
+		if clearFromDate != nil {
+			return plan, errors.New("clear_from_date can't be set when replaying from the earliest offsets")
+		}
+
+		for _, topic := range topics {
+			if plan.Offsets[topic], err = replayer.getOffsets(topic, sarama.OffsetOldest); err != nil {
+				return plan, err
+			}
+		}
+	case model.ReplayFromTimestamp:
+		timestamp, parseErr := time.Parse(time.RFC3339, replay.Timestamp)
+		if parseErr != nil {
+			return plan, fmt.Errorf("invalid timestamp %s: %w", replay.Timestamp, parseErr)
+		}
+
+		// Summaries dated after the first replayed transaction would count the replayed transactions twice. Like
+		// clear_from_date, the date is a day of each stock's timezone, so it's the earliest date of timestamp in them.
+		timestampDate := replayer.getEarliestDate(timestamp)
+		if clearFromDate != nil && clearFromDate.After(timestampDate) {
+			return plan, fmt.Errorf("clear_from_date %s is after the date of timestamp %s", replay.ClearFromDate, replay.Timestamp)
+		}
+		if clearFromDate == nil {
+			clearFromDate = &timestampDate
+		}
+
+		// Cleared summaries are rebuilt from the start of their day, which comes first in the easternmost timezone
+		clearFrom := replayer.getEarliestStart(*clearFromDate)
+		for _, topic := range topics {
+			if plan.Offsets[topic], err = replayer.getOffsets(topic, clearFrom.UnixMilli()); err != nil {
+				return plan, err
+			}
+		}
+	case model.ReplayFromOffsets:
+		// The dates of the transactions at the given offsets are unknown
+		if clearFromDate == nil {
+			return plan, errors.New("clear_from_date is required when replaying from offsets")
+		}
+
+		topic, err := getReplayTopic(topics, replay.Topic)
+		if err != nil {
+			return plan, err
+		}
+
+		if plan.Offsets[topic], err = replayer.checkOffsets(topic, replay.Offsets); err != nil {
+			return plan, err
+		}
+	default:
+		return plan, fmt.Errorf("invalid replay from %s", replay.From)
+	}
+
+	if clearFromDate != nil {
+		plan.ClearFromDate = *clearFromDate
+	}
+
+	return plan, nil
+}
+
+// Commit commits the offsets of plan for the consumer group
+func (replayer *KafkaReplayer) Commit(plan model.ReplayPlan) error {
+	coordinator, err := replayer.client.Coordinator(replayer.groupID)
+	if err != nil {
+		return err
+	}
+
+	// Offsets committed outside a group generation are accepted by Kafka as long as the group has no member
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           replayer.groupID,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+		RetentionTime:           -1,
+	}
+	for topic, offsets := range plan.Offsets {
+		for partition, offset := range offsets {
+			request.AddBlock(topic, partition, offset, 0, "")
+		}
+	}
+
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		return err
+	}
+
+	for topic, offsets := range plan.Offsets {
+		for _, partition := range sortedPartitions(offsets) {
+			if kerr := response.Errors[topic][partition]; kerr != sarama.ErrNoError {
+				return fmt.Errorf("failed to commit offset of partition %d of topic %s: %w", partition, topic, kerr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkGroupInactive returns an error when the consumer group has members, whose commits would overwrite the replay's
+func (replayer *KafkaReplayer) checkGroupInactive() error {
+	coordinator, err := replayer.client.Coordinator(replayer.groupID)
+	if err != nil {
+		return err
+	}
+
+	response, err := coordinator.DescribeGroups(&sarama.DescribeGroupsRequest{Groups: []string{replayer.groupID}})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range response.Groups {
+		if group.Err != sarama.ErrNoError {
+			return group.Err
+		}
+
+		if group.State != "Empty" && group.State != "Dead" {
+			return fmt.Errorf("consumer group %s is %s, its consumers must be stopped before replaying", group.GroupId, group.State)
+		}
+	}
+
+	return nil
+}
+
+// getEarliestDate returns the earliest of the dates of timestamp in the timezones of trading days, in UTC
+func (replayer *KafkaReplayer) getEarliestDate(timestamp time.Time) time.Time {
+	var earliest time.Time
+	for i, location := range replayer.locations {
+		date := model.TradingDate(timestamp.In(location), time.UTC)
+		if i == 0 || date.Before(earliest) {
+			earliest = date
+		}
+	}
+	return earliest
+}
+
+// getEarliestStart returns the earliest of the starts of date's day in the timezones of trading days
+func (replayer *KafkaReplayer) getEarliestStart(date time.Time) time.Time {
+	var earliest time.Time
+	for i, location := range replayer.locations {
+		start := model.TradingDate(date, location)
+		if i == 0 || start.Before(earliest) {
+			earliest = start
+		}
+	}
+	return earliest
+}
+
+// getOffsets returns the offset of each partition at time, either a timestamp in milliseconds or one of
+// sarama.OffsetOldest and sarama.OffsetNewest. Partitions without any message at or after time are replayed from
+// their newest offset, i.e. not replayed at all.
+func (replayer *KafkaReplayer) getOffsets(topic string, time int64) (map[int32]int64, error) {
+	partitions, err := replayer.client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := map[int32]int64{}
+	for _, partition := range partitions {
+		offset, err := replayer.client.GetOffset(topic, partition, time)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset < 0 {
+			offset, err = replayer.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		offsets[partition] = offset
+	}
+
+	return offsets, nil
+}
+
+// checkOffsets returns an error unless every offset is within the messages retained by its partition
+func (replayer *KafkaReplayer) checkOffsets(topic string, offsets map[int32]int64) (map[int32]int64, error) {
+	if len(offsets) == 0 {
+		return nil, errors.New("offsets are required when replaying from offsets")
+	}
+
+	partitions, err := replayer.client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, partition := range sortedPartitions(offsets) {
+		if !containsPartition(partitions, partition) {
+			return nil, fmt.Errorf("topic %s has no partition %d", topic, partition)
+		}
+
+		oldest, err := replayer.client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+
+		newest, err := replayer.client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset := offsets[partition]; offset < oldest || offset > newest {
+			return nil, fmt.Errorf("offset %d of partition %d is outside of its retained offsets [%d, %d]", offset, partition, oldest, newest)
+		}
+	}
+
+	return offsets, nil
+}
+
+// getReplayTopic returns the topic whose partitions' offsets are given, which may be omitted for a single topic
+func getReplayTopic(topics []string, topic string) (string, error) {
+	if topic == "" {
+		if len(topics) != 1 {
+			return "", errors.New("topic is required when replaying several topics from offsets")
+		}
+		return topics[0], nil
+	}
+
+	for _, consumedTopic := range topics {
+		if consumedTopic == topic {
+			return topic, nil
+		}
+	}
+	return "", fmt.Errorf("topic %s is not consumed", topic)
+}
+
+func sortedPartitions(offsets map[int32]int64) []int32 {
+	partitions := make([]int32, 0, len(offsets))
+	for partition := range offsets {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions
+}
+
+func containsPartition(partitions []int32, partition int32) bool {
+	for _, p := range partitions {
+		if p == partition {
+			return true
+		}
+	}
+	return false
+}
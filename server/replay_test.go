@@ -0,0 +1,259 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	testReplayGroupID = "stock_consumer_group"
+	testReplayTopic   = "stock"
+)
+
+// newTestKafkaReplayer returns a replayer of a topic with two partitions retaining offsets [10, 50) and [20, 80),
+// served by a mock broker that is also the group's coordinator
+func newTestKafkaReplayer(t *testing.T, groupState string, commitErr sarama.KError,
+	instruments model.Instruments) (*KafkaReplayer, *sarama.MockBroker) {
+	// Offsets are looked up at the start of days in UTC, the exchange's timezone, and in Jakarta
+	timestamp := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC).UnixMilli()
+	dayBefore := time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC).UnixMilli()
+	jakartaDayBefore := time.Date(2023, 8, 27, 17, 0, 0, 0, time.UTC).UnixMilli()
+
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(testReplayTopic, 0, broker.BrokerID()).
+			SetLeader(testReplayTopic, 1, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, testReplayGroupID, broker),
+		"DescribeGroupsRequest": sarama.NewMockDescribeGroupsResponse(t).
+			AddGroupDescription(testReplayGroupID, &sarama.GroupDescription{GroupId: testReplayGroupID, State: groupState}),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset(testReplayTopic, 0, sarama.OffsetOldest, 10).
+			SetOffset(testReplayTopic, 0, sarama.OffsetNewest, 50).
+			SetOffset(testReplayTopic, 0, timestamp, 42).
+			SetOffset(testReplayTopic, 0, dayBefore, 30).
+			SetOffset(testReplayTopic, 0, jakartaDayBefore, 25).
+			SetOffset(testReplayTopic, 1, sarama.OffsetOldest, 20).
+			SetOffset(testReplayTopic, 1, sarama.OffsetNewest, 80).
+			SetOffset(testReplayTopic, 1, timestamp, -1).
+			SetOffset(testReplayTopic, 1, dayBefore, 60).
+			SetOffset(testReplayTopic, 1, jakartaDayBefore, 55),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t).
+			SetError(testReplayGroupID, testReplayTopic, 1, commitErr),
+	})
+
+	cfg := model.DefaultConfigLocal
+	cfg.Kafka.Host = ""
+	cfg.Kafka.Port = broker.Addr()
+	cfg.Kafka.GroupID = testReplayGroupID
+	cfg.Instruments = instruments
+
+	replayer, err := NewKafkaReplayer(cfg)
+	if err != nil {
+		broker.Close()
+		t.Fatalf("NewKafkaReplayer() err = %v", err)
+	}
+
+	return replayer, broker
+}
+
+func Test_KafkaReplayer_Plan(t *testing.T) {
+	tests := []struct {
+		name        string
+		topics      []string
+		groupState  string
+		instruments model.Instruments
+		replay      model.Replay
+
+		wantResponse model.ReplayPlan
+		wantErr      bool
+	}{
+		{
+			name:       "success-earliest",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromEarliest},
+			wantResponse: model.ReplayPlan{
+				Offsets: map[string]map[int32]int64{testReplayTopic: {0: 10, 1: 20}},
+			},
+		},
+		{
+			name:       "success-timestamp",
+			groupState: "Dead",
+			replay:     model.Replay{From: model.ReplayFromTimestamp, Timestamp: "2023-08-29T16:00:00+07:00"},
+			wantResponse: model.ReplayPlan{
+				Offsets:       map[string]map[int32]int64{testReplayTopic: {0: 42, 1: 80}},
+				ClearFromDate: time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "success-timestamp-clear-from-date-before",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromTimestamp, Timestamp: "2023-08-29T16:00:00+07:00", ClearFromDate: "2023-08-28"},
+			wantResponse: model.ReplayPlan{
+				Offsets:       map[string]map[int32]int64{testReplayTopic: {0: 30, 1: 60}},
+				ClearFromDate: time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// The timestamp is on the 28th in UTC but the 29th in Jakarta, whose 28th starts first
+			name:        "success-timestamp-instrument-timezone",
+			groupState:  "Empty",
+			instruments: model.Instruments{{StockCode: "ASII", Timezone: "Asia/Jakarta"}},
+			replay:      model.Replay{From: model.ReplayFromTimestamp, Timestamp: "2023-08-29T06:00:00+07:00"},
+			wantResponse: model.ReplayPlan{
+				Offsets:       map[string]map[int32]int64{testReplayTopic: {0: 25, 1: 55}},
+				ClearFromDate: time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "success-offsets",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Offsets: map[int32]int64{1: 35}, ClearFromDate: "2023-08-28"},
+			wantResponse: model.ReplayPlan{
+				Offsets:       map[string]map[int32]int64{testReplayTopic: {1: 35}},
+				ClearFromDate: time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "error-group-stable",
+			groupState: "Stable",
+			replay:     model.Replay{From: model.ReplayFromEarliest},
+			wantErr:    true,
+		},
+		{
+			name:       "error-earliest-clear-from-date",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromEarliest, ClearFromDate: "2023-08-28"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-timestamp-clear-from-date-after",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromTimestamp, Timestamp: "2023-08-29T16:00:00+07:00", ClearFromDate: "2023-08-30"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-offsets-no-clear-from-date",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Offsets: map[int32]int64{1: 35}},
+			wantErr:    true,
+		},
+		{
+			name:       "error-offsets-out-of-range",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Offsets: map[int32]int64{0: 5}, ClearFromDate: "2023-08-28"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-offsets-unknown-partition",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Offsets: map[int32]int64{2: 0}, ClearFromDate: "2023-08-28"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-offsets-topic-required",
+			topics:     []string{testReplayTopic, "stock-csv"},
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Offsets: map[int32]int64{1: 35}, ClearFromDate: "2023-08-28"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-offsets-topic-not-consumed",
+			groupState: "Empty",
+			replay:     model.Replay{From: model.ReplayFromOffsets, Topic: "stock-csv", Offsets: map[int32]int64{1: 35}, ClearFromDate: "2023-08-28"},
+			wantErr:    true,
+		},
+		{
+			name:       "error-invalid-from",
+			groupState: "Empty",
+			replay:     model.Replay{From: "latest"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer, broker := newTestKafkaReplayer(t, tt.groupState, sarama.ErrNoError, tt.instruments)
+			defer broker.Close()
+			defer func() { _ = replayer.Close() }()
+
+			topics := tt.topics
+			if topics == nil {
+				topics = []string{testReplayTopic}
+			}
+
+			gotResponse, err := replayer.Plan(topics, tt.replay)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("replayer.Plan() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("replayer.Plan() gotResponse = %+v, wantResponse %+v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_KafkaReplayer_Commit(t *testing.T) {
+	plan := model.ReplayPlan{
+		Offsets: map[string]map[int32]int64{testReplayTopic: {0: 10, 1: 20}},
+	}
+
+	tests := []struct {
+		name      string
+		commitErr sarama.KError
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			commitErr: sarama.ErrNoError,
+		},
+		{
+			name:      "error-unknown-member",
+			commitErr: sarama.ErrUnknownMemberId,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer, broker := newTestKafkaReplayer(t, "Empty", tt.commitErr, nil)
+			defer broker.Close()
+			defer func() { _ = replayer.Close() }()
+
+			err := replayer.Commit(plan)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("replayer.Commit() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var request *sarama.OffsetCommitRequest
+			for _, requestResponse := range broker.History() {
+				if commitRequest, ok := requestResponse.Request.(*sarama.OffsetCommitRequest); ok {
+					request = commitRequest
+				}
+			}
+			if request == nil {
+				t.Fatalf("replayer.Commit() sent no offset commit request")
+			}
+
+			for partition, wantOffset := range plan.Offsets[testReplayTopic] {
+				if gotOffset, _, err := request.Offset(testReplayTopic, partition); err != nil || gotOffset != wantOffset {
+					t.Errorf("replayer.Commit() partition %d gotOffset = %d, wantOffset %d", partition, gotOffset, wantOffset)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"stock/handler"
+	"stock/logger"
+	"stock/model"
+)
+
+const (
+	defaultRetentionInterval = 24 * time.Hour
+)
+
+// ServeRetention runs the retention policy's compaction job at startup, then periodically in the background
+func ServeRetention(cfg model.Config, retentionHandler *handler.Handler) {
+	if !cfg.Retention.Enabled {
+		return
+	}
+
+	interval := cfg.Retention.Interval
+	if interval <= 0 {
+		interval = defaultRetentionInterval
+	}
+
+	logger.For("retention").Info("Compacting stock summaries periodically", "interval", interval.String())
+
+	ctx := logger.ContextWith(context.Background(), slog.String(logger.KeyComponent, "retention"))
+
+	// Compact once at startup, so that a restarted service doesn't wait a whole interval
+	_, _ = retentionHandler.CompactStockSummaries(ctx, cfg.Retention.DryRun)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		_, _ = retentionHandler.CompactStockSummaries(ctx, cfg.Retention.DryRun)
+	}
+}
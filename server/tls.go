@@ -0,0 +1,145 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"stock/logger"
+	"stock/model"
+)
+
+// certReloader builds the server's TLS config from the certificate files on disk and rebuilds it whenever
+// one of the files is modified, so rotated certificates are picked up by new connections without a restart
+type certReloader struct {
+	cfg model.TLS
+
+	mutex     sync.Mutex
+	modTimes  map[string]time.Time
+	tlsConfig *tls.Config
+}
+
+func newServerTLSConfig(cfg model.TLS) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls requires cert_file and key_file")
+	}
+
+	if cfg.RequireClientCert && cfg.ClientCAFile == "" {
+		return nil, errors.New("require_client_cert requires client_ca_file")
+	}
+
+	reloader := &certReloader{
+		cfg: cfg,
+	}
+	if _, err := reloader.getConfigForClient(nil); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: reloader.getConfigForClient,
+	}, nil
+}
+
+// getConfigForClient returns the TLS config for a new connection, reloading it first if any file has changed.
+// If the changed files can't be loaded (e.g. they are being rotated), the previous config is kept.
+func (reloader *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	modTimes, err := reloader.getModTimes()
+	if err == nil && reloader.tlsConfig != nil && !reloader.isModified(modTimes) {
+		return reloader.tlsConfig, nil
+	}
+
+	var tlsConfig *tls.Config
+	if err == nil {
+		tlsConfig, err = reloader.load()
+	}
+
+	switch {
+	case err == nil:
+		if reloader.tlsConfig != nil {
+			logger.For("grpc").Info("Reloaded TLS certificates")
+		}
+		reloader.tlsConfig, reloader.modTimes = tlsConfig, modTimes
+	case reloader.tlsConfig == nil:
+		return nil, err
+	default:
+		logger.For("grpc").Error("Failed reloading TLS certificates, keeping the previous ones", logger.Err(err))
+	}
+
+	return reloader.tlsConfig, nil
+}
+
+func (reloader *certReloader) files() []string {
+	files := []string{reloader.cfg.CertFile, reloader.cfg.KeyFile}
+	if reloader.cfg.ClientCAFile != "" {
+		files = append(files, reloader.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (reloader *certReloader) getModTimes() (map[string]time.Time, error) {
+	modTimes := map[string]time.Time{}
+	for _, file := range reloader.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (reloader *certReloader) isModified(modTimes map[string]time.Time) bool {
+	for file, modTime := range modTimes {
+		if !reloader.modTimes[file].Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func (reloader *certReloader) load() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(reloader.cfg.CertFile, reloader.cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.NoClientCert,
+	}
+
+	if reloader.cfg.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	clientCA, err := os.ReadFile(reloader.cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.ClientCAs = x509.NewCertPool()
+	if !tlsConfig.ClientCAs.AppendCertsFromPEM(clientCA) {
+		return nil, fmt.Errorf("no certificate found in %s", reloader.cfg.ClientCAFile)
+	}
+
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if reloader.cfg.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,359 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"stock/model"
+	"stock/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type testCertificate struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// generateTestCertificate writes a certificate signed by parent (self-signed when parent is nil) to dir
+func generateTestCertificate(t *testing.T, dir, name string, isCA bool, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+
+	serialNumber, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         isCA,
+
+		BasicConstraintsValid: true,
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certificate := testCertificate{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+	writeTestPEM(t, certificate.certFile, "CERTIFICATE", der)
+	writeTestPEM(t, certificate.keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certificate
+}
+
+func writeTestPEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+}
+
+// dialTestTLS performs a TLS handshake with the server and returns the common name of the server's certificate
+func dialTestTLS(address string, rootCA *x509.Certificate, clientCert *testCertificate) (string, error) {
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(rootCA)
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+		ServerName: "localhost",
+	}
+	if clientCert != nil {
+		certificate, err := tls.LoadX509KeyPair(clientCert.certFile, clientCert.keyFile)
+		if err != nil {
+			return "", err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	conn, err := tls.Dial("tcp", address, tlsConfig)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// With TLS 1.3, a rejected client certificate is only reported on the first read
+	_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+			return "", err
+		}
+	}
+
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func startTestTLSListener(t *testing.T, tlsConfig *tls.Config) string {
+	t.Helper()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	if err != nil {
+		t.Fatalf("tls.Listen() err = %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Read(make([]byte, 1))
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func Test_newServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := generateTestCertificate(t, dir, "ca", true, nil)
+	serverCert := generateTestCertificate(t, dir, "server", false, &ca)
+	clientCert := generateTestCertificate(t, dir, "client", false, &ca)
+	untrustedCA := generateTestCertificate(t, dir, "untrusted-ca", true, nil)
+	untrustedClientCert := generateTestCertificate(t, dir, "untrusted-client", false, &untrustedCA)
+
+	tests := []struct {
+		name       string
+		cfg        model.TLS
+		clientCert *testCertificate
+
+		wantConfigErr bool
+		wantDialErr   bool
+	}{
+		{
+			name: "success-tls",
+			cfg: model.TLS{
+				CertFile: serverCert.certFile,
+				KeyFile:  serverCert.keyFile,
+			},
+		},
+		{
+			name: "success-mtls",
+			cfg: model.TLS{
+				CertFile:          serverCert.certFile,
+				KeyFile:           serverCert.keyFile,
+				ClientCAFile:      ca.certFile,
+				RequireClientCert: true,
+			},
+			clientCert: &clientCert,
+		},
+		{
+			name: "success-optional-client-cert",
+			cfg: model.TLS{
+				CertFile:     serverCert.certFile,
+				KeyFile:      serverCert.keyFile,
+				ClientCAFile: ca.certFile,
+			},
+		},
+		{
+			name: "error-mtls-no-client-cert",
+			cfg: model.TLS{
+				CertFile:          serverCert.certFile,
+				KeyFile:           serverCert.keyFile,
+				ClientCAFile:      ca.certFile,
+				RequireClientCert: true,
+			},
+			wantDialErr: true,
+		},
+		{
+			name: "error-mtls-untrusted-client-cert",
+			cfg: model.TLS{
+				CertFile:          serverCert.certFile,
+				KeyFile:           serverCert.keyFile,
+				ClientCAFile:      ca.certFile,
+				RequireClientCert: true,
+			},
+			clientCert:  &untrustedClientCert,
+			wantDialErr: true,
+		},
+		{
+			name: "error-require-client-cert-no-client-ca",
+			cfg: model.TLS{
+				CertFile:          serverCert.certFile,
+				KeyFile:           serverCert.keyFile,
+				RequireClientCert: true,
+			},
+			wantConfigErr: true,
+		},
+		{
+			name: "error-missing-key-file",
+			cfg: model.TLS{
+				CertFile: serverCert.certFile,
+				KeyFile:  filepath.Join(dir, "missing.key"),
+			},
+			wantConfigErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := newServerTLSConfig(tt.cfg)
+			if (err != nil) != tt.wantConfigErr {
+				t.Errorf("newServerTLSConfig() err = %v, wantErr %v", err, tt.wantConfigErr)
+				return
+			}
+
+			if tt.wantConfigErr {
+				return
+			}
+
+			address := startTestTLSListener(t, tlsConfig)
+
+			_, err = dialTestTLS(address, ca.cert, tt.clientCert)
+			if (err != nil) != tt.wantDialErr {
+				t.Errorf("dialTestTLS() err = %v, wantErr %v", err, tt.wantDialErr)
+			}
+		})
+	}
+}
+
+func Test_newServerTLSConfig_Reload(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := generateTestCertificate(t, dir, "ca", true, nil)
+	serverCert := generateTestCertificate(t, dir, "server", false, &ca)
+
+	tlsConfig, err := newServerTLSConfig(model.TLS{
+		CertFile: serverCert.certFile,
+		KeyFile:  serverCert.keyFile,
+	})
+	if err != nil {
+		t.Fatalf("newServerTLSConfig() err = %v", err)
+	}
+
+	address := startTestTLSListener(t, tlsConfig)
+
+	if commonName, err := dialTestTLS(address, ca.cert, nil); err != nil || commonName != "server" {
+		t.Fatalf("dialTestTLS() commonName = %s, err = %v, want server", commonName, err)
+	}
+
+	// Rotate the certificate in place
+	rotatedCert := generateTestCertificate(t, t.TempDir(), "rotated-server", false, &ca)
+	for _, file := range [][2]string{{rotatedCert.certFile, serverCert.certFile}, {rotatedCert.keyFile, serverCert.keyFile}} {
+		data, _ := os.ReadFile(file[0])
+		if err := os.WriteFile(file[1], data, 0o600); err != nil {
+			t.Fatalf("os.WriteFile() err = %v", err)
+		}
+
+		modTime := time.Now().Add(time.Minute)
+		_ = os.Chtimes(file[1], modTime, modTime)
+	}
+
+	if commonName, err := dialTestTLS(address, ca.cert, nil); err != nil || commonName != "rotated-server" {
+		t.Errorf("dialTestTLS() commonName = %s, err = %v, want rotated-server", commonName, err)
+	}
+
+	// A broken rotation keeps serving the last valid certificate
+	if err := os.WriteFile(serverCert.keyFile, []byte("invalid"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	modTime := time.Now().Add(2 * time.Minute)
+	_ = os.Chtimes(serverCert.keyFile, modTime, modTime)
+
+	if commonName, err := dialTestTLS(address, ca.cert, nil); err != nil || commonName != "rotated-server" {
+		t.Errorf("dialTestTLS() commonName = %s, err = %v, want rotated-server", commonName, err)
+	}
+}
+
+func Test_newServerTLSConfig_GRPC(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := generateTestCertificate(t, dir, "ca", true, nil)
+	serverCert := generateTestCertificate(t, dir, "server", false, &ca)
+	clientCert := generateTestCertificate(t, dir, "client", false, &ca)
+
+	tlsConfig, err := newServerTLSConfig(model.TLS{
+		CertFile:          serverCert.certFile,
+		KeyFile:           serverCert.keyFile,
+		ClientCAFile:      ca.certFile,
+		RequireClientCert: true,
+	})
+	if err != nil {
+		t.Fatalf("newServerTLSConfig() err = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	proto.RegisterStockServer(grpcServer, &proto.UnimplementedStockServer{})
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	certificate, _ := tls.LoadX509KeyPair(clientCert.certFile, clientCert.keyFile)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		RootCAs:      rootCAs,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{certificate},
+	})))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() err = %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Reaching the unimplemented handler means the mutual TLS handshake succeeded
+	_, err = proto.NewStockClient(conn).GetStockSummary(ctx, &proto.GetStockSummaryRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("GetStockSummary() err = %v, want code %v", err, codes.Unimplemented)
+	}
+}
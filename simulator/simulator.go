@@ -0,0 +1,459 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"stock/model"
+)
+
+const (
+	orderNumberTimeFmt = "20060102150405"
+	sessionClockFmt    = "15:04:05"
+
+	// MinTransactions is the fewest transactions per stock that cover the previous price and both auctions
+	MinTransactions = 10
+
+	lotSize = 100
+
+	// Prices wander at most maxMovePercent from the previous price, well within the narrowest price band
+	maxMovePercent = 10
+
+	minPrev = 50
+	maxPrev = 20000
+)
+
+// Options configures a simulated trading day
+type Options struct {
+	Seed int64
+	// Stocks is the number of simulated stocks, coded SIM0001, SIM0002, ...
+	Stocks int
+	// Transactions is the number of transactions of each stock, including its previous price and auction orders
+	Transactions int
+	// Date is the trading day; only its date is used
+	Date time.Time
+	// Schedule gives the session hours and timezone of every simulated stock
+	Schedule model.TradingSchedule
+	// TickSizes keeps the simulated prices on valid ticks; every price is valid when empty
+	TickSizes []model.TickSize
+	// VWAPPrecision is the number of fractional digits of the expected VWAPs
+	VWAPPrecision int32
+}
+
+// Simulation is the trade flow of a simulated trading day and the stock summaries it produces
+type Simulation struct {
+	// Transactions of every stock, in chronological order
+	Transactions []model.KafkaTransaction
+	// Expected stock summaries, sorted by stock code
+	Expected []model.Summary
+}
+
+// event is a simulated transaction before it is given its order number
+type event struct {
+	stockCode string
+	at        time.Time
+	session   model.Session
+	typ       model.TransactionType
+	price     int64
+	quantity  int64
+	sequence  int64
+	orderBook string
+	orderVerb string
+}
+
+func (e event) isTrade() bool {
+	return e.typ == model.TransactionTypeE || e.typ == model.TransactionTypeP
+}
+
+// Simulate returns a trading day of random-walk prices for options.Stocks stocks, the same for the same options.
+// Each stock gets its previous price, a pre-opening auction, orders (A) and trades (E, P) during both sessions, and a
+// pre-closing auction. Auction trades happen at the last second of their auction, at a single price.
+func Simulate(options Options) (Simulation, error) {
+	if options.Stocks <= 0 {
+		return Simulation{}, errors.New("stocks must be positive")
+	}
+	if options.Transactions < MinTransactions {
+		return Simulation{}, fmt.Errorf("transactions must be at least %d", MinTransactions)
+	}
+
+	date := model.TradingDate(options.Date, options.Schedule.GetLocation(model.Instrument{}))
+	generator := &generator{
+		rng:       rand.New(rand.NewSource(options.Seed)),
+		date:      date,
+		tickSizes: options.TickSizes,
+	}
+
+	schedule := options.Schedule
+	for _, hours := range []model.SessionHours{schedule.PreOpening, schedule.SessionOne, schedule.SessionTwo, schedule.PreClosing} {
+		if err := validateHours(hours); err != nil {
+			return Simulation{}, err
+		}
+	}
+
+	var (
+		events []event
+		prevs  = map[string]int64{}
+	)
+	for i := 0; i < options.Stocks; i++ {
+		stockCode := fmt.Sprintf("SIM%04d", i+1)
+		stockEvents := generator.stock(stockCode, options.Transactions, schedule)
+
+		prevs[stockCode] = stockEvents[0].price
+		events = append(events, stockEvents...)
+	}
+
+	// Order numbers carry the time and a sequence in chronological order across stocks
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	simulation := Simulation{Transactions: make([]model.KafkaTransaction, 0, len(events))}
+	trades := map[string][]event{}
+	for i := range events {
+		events[i].sequence = int64(i + 1)
+		simulation.Transactions = append(simulation.Transactions, events[i].toKafkaTransaction())
+
+		if events[i].isTrade() {
+			trades[events[i].stockCode] = append(trades[events[i].stockCode], events[i])
+		}
+	}
+
+	for stockCode, prev := range prevs {
+		simulation.Expected = append(simulation.Expected, expectSummary(stockCode, date, prev, trades[stockCode], options.VWAPPrecision))
+	}
+	sort.Slice(simulation.Expected, func(i, j int) bool {
+		return simulation.Expected[i].StockCode < simulation.Expected[j].StockCode
+	})
+
+	return simulation, nil
+}
+
+func (e event) toKafkaTransaction() model.KafkaTransaction {
+	transaction := model.KafkaTransaction{
+		Type:        string(e.typ),
+		OrderBook:   e.orderBook,
+		OrderNumber: e.at.Format(orderNumberTimeFmt) + fmt.Sprintf("%06d", e.sequence),
+		OrderVerb:   e.orderVerb,
+		StockCode:   e.stockCode,
+	}
+
+	price := strconv.FormatInt(e.price, 10)
+	quantity := ""
+	if e.quantity > 0 {
+		quantity = strconv.FormatInt(e.quantity, 10)
+	}
+
+	// Executions report what was executed, other transactions what was ordered
+	if e.typ == model.TransactionTypeE {
+		transaction.ExecutionPrice, transaction.ExecutedQuantity = price, quantity
+	} else {
+		transaction.Price, transaction.Quantity = price, quantity
+	}
+
+	return transaction
+}
+
+// expectSummary computes the stock summary of chronologically ordered trades, independently of
+// model.Summary.ApplyTransaction: the auction prices, when there are auction trades, are the Open and Close
+func expectSummary(stockCode string, date time.Time, prev int64, trades []event, vwapPrecision int32) model.Summary {
+	summary := model.Summary{
+		StockCode: stockCode,
+		Date:      date,
+		Prev:      prev,
+	}
+
+	var opening, closing *event
+	for i := range trades {
+		trade := &trades[i]
+
+		if i == 0 || trade.price > summary.High {
+			summary.High = trade.price
+		}
+		if i == 0 || trade.price < summary.Low {
+			summary.Low = trade.price
+		}
+		summary.Volume += trade.quantity
+		summary.Value += trade.quantity * trade.price
+		summary.Trades++
+
+		if sessionSummary := summary.GetSessionSummary(trade.session); sessionSummary != nil {
+			if sessionSummary.Volume == 0 {
+				sessionSummary.Open, sessionSummary.High, sessionSummary.Low = trade.price, trade.price, trade.price
+			}
+			sessionSummary.High = max(sessionSummary.High, trade.price)
+			sessionSummary.Low = min(sessionSummary.Low, trade.price)
+			sessionSummary.Close = trade.price
+			sessionSummary.Volume += trade.quantity
+			sessionSummary.Value += trade.quantity * trade.price
+			sessionSummary.Trades++
+		}
+
+		boardSummary := map[string]*model.BoardSummary{"RG": &summary.Regular, "NG": &summary.Negotiated, "TN": &summary.Cash}[trade.orderBook]
+		boardSummary.Volume += trade.quantity
+		boardSummary.Value += trade.quantity * trade.price
+		boardSummary.Trades++
+
+		switch trade.orderVerb {
+		case "B":
+			summary.BuyVolume += trade.quantity
+		case "S":
+			summary.SellVolume += trade.quantity
+		}
+
+		if opening == nil || (trade.session == model.SessionPreOpening && opening.session != model.SessionPreOpening) {
+			opening = trade
+		}
+		if closing == nil || closing.session != model.SessionPreClosing || trade.session == model.SessionPreClosing {
+			closing = trade
+		}
+	}
+
+	if summary.Volume > 0 {
+		summary.Average = summary.Value / summary.Volume
+
+		// Rounded half up; simulated values are far too small to overflow
+		scaledValue := summary.Value
+		for i := int32(0); i < vwapPrecision; i++ {
+			scaledValue *= 10
+		}
+		summary.VWAP = (2*scaledValue + summary.Volume) / (2 * summary.Volume)
+		summary.VWAPScale = vwapPrecision
+	}
+	if opening != nil {
+		summary.Open, summary.FirstTradeTime, summary.FirstTradeSequence = opening.price, opening.at.UnixMilli(), opening.sequence
+	}
+	if closing != nil {
+		summary.Close, summary.LastTradeTime, summary.LastTradeSequence = closing.price, closing.at.UnixMilli(), closing.sequence
+	}
+
+	return summary
+}
+
+type generator struct {
+	rng       *rand.Rand
+	date      time.Time
+	tickSizes []model.TickSize
+}
+
+// window is the [start, end) time range of a session on the simulated date
+type window struct {
+	start, end time.Time
+}
+
+// validateHours checks that hours is a non-empty range of hh:mm:ss clocks
+func validateHours(hours model.SessionHours) error {
+	for _, clock := range []string{hours.Start, hours.End} {
+		if _, err := time.Parse(sessionClockFmt, clock); err != nil {
+			return fmt.Errorf("invalid trading schedule: %w", err)
+		}
+	}
+
+	if hours.Start >= hours.End {
+		return fmt.Errorf("invalid trading schedule: %s is not before %s", hours.Start, hours.End)
+	}
+
+	return nil
+}
+
+func (generator *generator) window(hours model.SessionHours) window {
+	clock := func(value string) time.Time {
+		parsed, _ := time.Parse(sessionClockFmt, value)
+		date := generator.date
+		return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, date.Location())
+	}
+
+	return window{start: clock(hours.Start), end: clock(hours.End)}
+}
+
+// stock returns the chronologically ordered events of a stock, the first being its previous price
+func (generator *generator) stock(stockCode string, transactions int, schedule model.TradingSchedule) []event {
+	rng := generator.rng
+
+	// Previous prices are spread log-uniformly, so that every tick size is covered
+	prev := int64(math.Exp(math.Log(minPrev) + rng.Float64()*(math.Log(maxPrev)-math.Log(minPrev))))
+	prev = generator.snap(prev)
+
+	walk := &randomWalk{generator: generator, prev: prev, price: prev}
+
+	preOpening := generator.window(schedule.PreOpening)
+	preClosing := generator.window(schedule.PreClosing)
+	auctionCount := max(2, transactions/20)
+	continuousCount := transactions - 1 - 2*auctionCount
+
+	events := []event{{
+		stockCode: stockCode,
+		at:        preOpening.start.Add(-15 * time.Minute),
+		session:   model.SessionUndefined,
+		typ:       model.TransactionTypeA,
+		price:     prev,
+	}}
+
+	events = append(events, walk.auction(stockCode, preOpening, model.SessionPreOpening, auctionCount)...)
+
+	// Continuous trading times are spread uniformly over both sessions
+	sessions := []struct {
+		name   model.Session
+		window window
+	}{
+		{name: model.SessionOne, window: generator.window(schedule.SessionOne)},
+		{name: model.SessionTwo, window: generator.window(schedule.SessionTwo)},
+	}
+	var totalSeconds int64
+	for _, session := range sessions {
+		totalSeconds += int64(session.window.end.Sub(session.window.start) / time.Second)
+	}
+
+	offsets := make([]int64, continuousCount)
+	for i := range offsets {
+		offsets[i] = rng.Int63n(totalSeconds)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	for _, offset := range offsets {
+		session := sessions[0]
+		if seconds := int64(session.window.end.Sub(session.window.start) / time.Second); offset >= seconds {
+			session, offset = sessions[1], offset-seconds
+		}
+		at := session.window.start.Add(time.Duration(offset) * time.Second)
+
+		// Roughly half of the transactions are orders; most trades are regular executions (E), some are P
+		switch r := rng.Float64(); {
+		case r < 0.55:
+			events = append(events, walk.order(stockCode, at, session.name))
+		case r < 0.95:
+			events = append(events, walk.trade(stockCode, at, session.name, model.TransactionTypeE))
+		default:
+			events = append(events, walk.trade(stockCode, at, session.name, model.TransactionTypeP))
+		}
+	}
+
+	return append(events, walk.auction(stockCode, preClosing, model.SessionPreClosing, auctionCount)...)
+}
+
+// randomWalk moves a stock's price by a few ticks per trade, within maxMovePercent of its previous price
+type randomWalk struct {
+	generator *generator
+	prev      int64
+	price     int64
+}
+
+func (walk *randomWalk) order(stockCode string, at time.Time, session model.Session) event {
+	rng := walk.generator.rng
+	return event{
+		stockCode: stockCode,
+		at:        at,
+		session:   session,
+		typ:       model.TransactionTypeA,
+		price:     walk.clamp(walk.price + int64(rng.Intn(7)-3)*walk.generator.tick(walk.price)),
+		quantity:  walk.generator.quantity(),
+	}
+}
+
+func (walk *randomWalk) trade(stockCode string, at time.Time, session model.Session, typ model.TransactionType) event {
+	rng := walk.generator.rng
+	walk.price = walk.clamp(walk.price + int64(rng.Intn(5)-2)*walk.generator.tick(walk.price))
+
+	// Most trades are on the regular board; each is initiated by a buy or a sell order
+	orderBook := "RG"
+	switch r := rng.Float64(); {
+	case r < 0.05:
+		orderBook = "NG"
+	case r < 0.1:
+		orderBook = "TN"
+	}
+
+	return event{
+		stockCode: stockCode,
+		at:        at,
+		session:   session,
+		typ:       typ,
+		price:     walk.price,
+		quantity:  walk.generator.quantity(),
+		orderBook: orderBook,
+		orderVerb: []string{"B", "S"}[rng.Intn(2)],
+	}
+}
+
+// auction returns count events within w: orders, then 1 to 3 trades at a single price at the last second of w
+func (walk *randomWalk) auction(stockCode string, w window, session model.Session, count int) []event {
+	rng := walk.generator.rng
+	trades := 1 + rng.Intn(min(3, count-1))
+
+	lastSecond := w.end.Add(-time.Second)
+	seconds := int64(lastSecond.Sub(w.start) / time.Second)
+
+	orderTimes := make([]time.Time, count-trades)
+	for i := range orderTimes {
+		orderTimes[i] = w.start.Add(time.Duration(rng.Int63n(max(seconds, 1))) * time.Second)
+	}
+	sort.Slice(orderTimes, func(i, j int) bool { return orderTimes[i].Before(orderTimes[j]) })
+
+	var events []event
+	for _, at := range orderTimes {
+		events = append(events, walk.order(stockCode, at, session))
+	}
+
+	// Auction trades are matched at a single price on the regular board, without an aggressor
+	first := walk.trade(stockCode, lastSecond, session, model.TransactionTypeE)
+	first.orderBook, first.orderVerb = "RG", ""
+	events = append(events, first)
+	for i := 1; i < trades; i++ {
+		events = append(events, event{
+			stockCode: stockCode,
+			at:        lastSecond,
+			session:   session,
+			typ:       model.TransactionTypeE,
+			price:     first.price,
+			quantity:  walk.generator.quantity(),
+			orderBook: first.orderBook,
+		})
+	}
+
+	return events
+}
+
+// clamp returns price on its tick, within maxMovePercent of the previous price
+func (walk *randomWalk) clamp(price int64) int64 {
+	low := walk.prev - walk.prev*maxMovePercent/100
+	high := walk.prev + walk.prev*maxMovePercent/100
+
+	price = walk.generator.snap(max(low, min(high, price)))
+	if price < low {
+		price += walk.generator.tick(price)
+	}
+
+	return max(price, 1)
+}
+
+// quantity returns a whole number of lots, mostly small orders with a long tail of large ones
+func (generator *generator) quantity() int64 {
+	lots := 1 + int64(generator.rng.ExpFloat64()*10)
+	return min(lots, 1000) * lotSize
+}
+
+// snap rounds price down to its tick
+func (generator *generator) snap(price int64) int64 {
+	return price - price%generator.tick(price)
+}
+
+// tick returns the tick size of price, picking the largest MinPrice that price reaches as validation does
+func (generator *generator) tick(price int64) int64 {
+	var (
+		tick     int64 = 1
+		minPrice int64 = -1
+	)
+	for _, tickSize := range generator.tickSizes {
+		if price >= tickSize.MinPrice && tickSize.MinPrice > minPrice && tickSize.Tick > 0 {
+			tick, minPrice = tickSize.Tick, tickSize.MinPrice
+		}
+	}
+	return tick
+}
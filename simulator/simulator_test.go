@@ -0,0 +1,243 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+)
+
+func Test_Simulate(t *testing.T) {
+	options := Options{
+		Seed:          42,
+		Stocks:        5,
+		Transactions:  200,
+		Date:          time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Schedule:      model.DefaultConfigLocal.Schedule,
+		TickSizes:     model.DefaultConfigLocal.Validation.TickSizes,
+		VWAPPrecision: 4,
+	}
+
+	type args struct {
+		options Options
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantTransactions int
+		wantErr          bool
+	}{
+		{
+			name:             "success",
+			args:             args{options: options},
+			wantTransactions: 1000,
+		},
+		{
+			name: "success-minimum-transactions",
+			args: args{options: func() Options {
+				options := options
+				options.Transactions = MinTransactions
+				return options
+			}()},
+			wantTransactions: 5 * MinTransactions,
+		},
+		{
+			name: "success-timezone",
+			args: args{options: func() Options {
+				options := options
+				options.Schedule.Timezone = "Asia/Jakarta"
+				return options
+			}()},
+			wantTransactions: 1000,
+		},
+		{
+			name: "error-stocks",
+			args: args{options: func() Options {
+				options := options
+				options.Stocks = 0
+				return options
+			}()},
+			wantErr: true,
+		},
+		{
+			name: "error-transactions",
+			args: args{options: func() Options {
+				options := options
+				options.Transactions = MinTransactions - 1
+				return options
+			}()},
+			wantErr: true,
+		},
+		{
+			name: "error-schedule",
+			args: args{options: func() Options {
+				options := options
+				options.Schedule.SessionTwo = model.SessionHours{}
+				return options
+			}()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := Simulate(tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Simulate() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(gotResponse.Transactions) != tt.wantTransactions {
+				t.Errorf("Simulate() got %d transactions, want %d", len(gotResponse.Transactions), tt.wantTransactions)
+			}
+
+			for i := 1; i < len(gotResponse.Transactions); i++ {
+				if gotResponse.Transactions[i-1].OrderNumber >= gotResponse.Transactions[i].OrderNumber {
+					t.Errorf("Simulate() order number %s is not after %s",
+						gotResponse.Transactions[i].OrderNumber, gotResponse.Transactions[i-1].OrderNumber)
+					return
+				}
+			}
+
+			// The independently computed summaries must match the ones the consumer computes, and every
+			// transaction must pass validation
+			validation := model.DefaultConfigLocal.Validation
+			validation.MaxFutureDays = 365 * 100
+
+			summaries := map[string]model.Summary{}
+			for _, input := range gotResponse.Transactions {
+				transaction, err := input.ToTransaction(tt.args.options.Schedule, model.Instrument{})
+				if err != nil {
+					t.Errorf("KafkaTransaction.ToTransaction() err = %v", err)
+					return
+				}
+
+				summary := summaries[transaction.StockCode]
+				if err := validation.Validate(transaction, summary.Prev, tt.args.options.Date); err != nil {
+					t.Errorf("Validation.Validate(%+v) err = %v", input, err)
+					return
+				}
+				_, summaries[transaction.StockCode] = summary.ApplyTransaction(transaction)
+			}
+
+			if len(gotResponse.Expected) != tt.args.options.Stocks {
+				t.Errorf("Simulate() got %d expected summaries, want %d", len(gotResponse.Expected), tt.args.options.Stocks)
+			}
+			for _, expected := range gotResponse.Expected {
+				applied, err := summaries[expected.StockCode].WithVWAP(tt.args.options.VWAPPrecision)
+				if err != nil {
+					t.Errorf("Summary.WithVWAP() err = %v", err)
+				}
+				if applied != expected {
+					t.Errorf("Simulate() expected = %+v, applied %+v", expected, applied)
+				}
+			}
+		})
+	}
+}
+
+func Test_Simulate_Seed(t *testing.T) {
+	options := Options{
+		Seed:         7,
+		Stocks:       3,
+		Transactions: 100,
+		Date:         time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+		Schedule:     model.DefaultConfigLocal.Schedule,
+		TickSizes:    model.DefaultConfigLocal.Validation.TickSizes,
+	}
+
+	first, _ := Simulate(options)
+	second, _ := Simulate(options)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Simulate() is not reproducible with seed %d", options.Seed)
+	}
+
+	options.Seed++
+	other, _ := Simulate(options)
+	if reflect.DeepEqual(first.Transactions, other.Transactions) {
+		t.Errorf("Simulate() is the same with seeds %d and %d", options.Seed-1, options.Seed)
+	}
+}
+
+type fakeSummaryReader struct {
+	summaries map[string]model.Summary
+	err       error
+}
+
+func (reader fakeSummaryReader) GetStockSummary(_ context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	if summary, ok := reader.summaries[request.StockCode]; ok {
+		return []model.Summary{summary}, nil
+	}
+	return nil, reader.err
+}
+
+func Test_Verify(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	expected := []model.Summary{
+		{StockCode: "SIM0001", Date: date, Prev: 8000, Open: 8050, High: 8100, Low: 8000, Close: 8100, Volume: 200, Value: 1610000, Average: 8050},
+		{StockCode: "SIM0002", Date: date, Prev: 100, Open: 101, High: 101, Low: 101, Close: 101, Volume: 100, Value: 10100, Average: 101},
+	}
+	stored := expected[0]
+	stored.Date = date.In(time.FixedZone("WIB", 7*60*60))
+
+	type args struct {
+		reader SummaryReader
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantResponse []Mismatch
+		wantErr      bool
+	}{
+		{
+			name: "success-match",
+			args: args{reader: fakeSummaryReader{summaries: map[string]model.Summary{"SIM0001": stored, "SIM0002": expected[1]}}},
+		},
+		{
+			name: "success-mismatch",
+			args: args{reader: fakeSummaryReader{summaries: map[string]model.Summary{
+				"SIM0001": stored,
+				"SIM0002": {StockCode: "SIM0002", Date: date, Prev: 100, Open: 101, High: 101, Low: 101, Close: 101, Volume: 200, Value: 20200, Average: 101},
+			}}},
+			wantResponse: []Mismatch{{
+				Expected: expected[1],
+				Actual:   model.Summary{StockCode: "SIM0002", Date: date, Prev: 100, Open: 101, High: 101, Low: 101, Close: 101, Volume: 200, Value: 20200, Average: 101},
+			}},
+		},
+		{
+			name:         "success-missing",
+			args:         args{reader: fakeSummaryReader{summaries: map[string]model.Summary{"SIM0001": stored}}},
+			wantResponse: []Mismatch{{Expected: expected[1]}},
+		},
+		{
+			name:    "error-reader",
+			args:    args{reader: fakeSummaryReader{err: errors.New("error-reader")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResponse, err := Verify(context.Background(), tt.args.reader, expected)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("Verify() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
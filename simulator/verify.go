@@ -0,0 +1,54 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package simulator
+
+import (
+	"context"
+
+	"stock/model"
+)
+
+// SummaryReader reads stored stock summaries, e.g. the repo
+type SummaryReader interface {
+	GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error)
+}
+
+// Mismatch is an expected stock summary that differs from the stored one; Actual is zero when none is stored
+type Mismatch struct {
+	Expected model.Summary
+	Actual   model.Summary
+}
+
+// Verify returns the expected stock summaries that differ from the ones stored in reader
+func Verify(ctx context.Context, reader SummaryReader, expected []model.Summary) ([]Mismatch, error) {
+	var mismatches []Mismatch
+	for _, summary := range expected {
+		stored, err := reader.GetStockSummary(ctx, model.GetStockSummaryRequest{
+			StockCode: summary.StockCode,
+			FromDate:  summary.Date,
+			ToDate:    summary.Date,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var actual model.Summary
+		if len(stored) > 0 {
+			actual = stored[0]
+		}
+
+		// Dates are compared as instants, as the stored ones may be in another location
+		if actual.Date.Equal(summary.Date) {
+			actual.Date = summary.Date
+		}
+
+		if actual != summary {
+			mismatches = append(mismatches, Mismatch{Expected: summary, Actual: actual})
+		}
+	}
+
+	return mismatches, nil
+}
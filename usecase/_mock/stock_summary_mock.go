@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "stock/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,109 @@ func (m *MockStockRepo) EXPECT() *MockStockRepoMockRecorder {
 	return m.recorder
 }
 
+// AppendTransaction mocks base method.
+func (m *MockStockRepo) AppendTransaction(ctx context.Context, transaction model.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendTransaction", ctx, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendTransaction indicates an expected call of AppendTransaction.
+func (mr *MockStockRepoMockRecorder) AppendTransaction(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendTransaction", reflect.TypeOf((*MockStockRepo)(nil).AppendTransaction), ctx, transaction)
+}
+
+// ArchiveStockSummary mocks base method.
+func (m *MockStockRepo) ArchiveStockSummary(ctx context.Context, archivedSummary model.Summary, request model.GetStockSummaryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveStockSummary", ctx, archivedSummary, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveStockSummary indicates an expected call of ArchiveStockSummary.
+func (mr *MockStockRepoMockRecorder) ArchiveStockSummary(ctx, archivedSummary, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveStockSummary", reflect.TypeOf((*MockStockRepo)(nil).ArchiveStockSummary), ctx, archivedSummary, request)
+}
+
+// ClearStockSummaries mocks base method.
+func (m *MockStockRepo) ClearStockSummaries(ctx context.Context, fromDate time.Time, getLocation func(string) *time.Location) (model.ClearReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearStockSummaries", ctx, fromDate, getLocation)
+	ret0, _ := ret[0].(model.ClearReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearStockSummaries indicates an expected call of ClearStockSummaries.
+func (mr *MockStockRepoMockRecorder) ClearStockSummaries(ctx, fromDate, getLocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearStockSummaries", reflect.TypeOf((*MockStockRepo)(nil).ClearStockSummaries), ctx, fromDate, getLocation)
+}
+
+// DeleteStockSummaries mocks base method.
+func (m *MockStockRepo) DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStockSummaries", ctx, request)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStockSummaries indicates an expected call of DeleteStockSummaries.
+func (mr *MockStockRepoMockRecorder) DeleteStockSummaries(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStockSummaries", reflect.TypeOf((*MockStockRepo)(nil).DeleteStockSummaries), ctx, request)
+}
+
+// DeleteTransactions mocks base method.
+func (m *MockStockRepo) DeleteTransactions(ctx context.Context, toDate time.Time, getLocation func(string) *time.Location) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransactions", ctx, toDate, getLocation)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransactions indicates an expected call of DeleteTransactions.
+func (mr *MockStockRepoMockRecorder) DeleteTransactions(ctx, toDate, getLocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactions", reflect.TypeOf((*MockStockRepo)(nil).DeleteTransactions), ctx, toDate, getLocation)
+}
+
+// GetArchivedStockSummary mocks base method.
+func (m *MockStockRepo) GetArchivedStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedStockSummary", ctx, request)
+	ret0, _ := ret[0].([]model.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedStockSummary indicates an expected call of GetArchivedStockSummary.
+func (mr *MockStockRepoMockRecorder) GetArchivedStockSummary(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedStockSummary", reflect.TypeOf((*MockStockRepo)(nil).GetArchivedStockSummary), ctx, request)
+}
+
+// GetStockCodes mocks base method.
+func (m *MockStockRepo) GetStockCodes(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockCodes", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockCodes indicates an expected call of GetStockCodes.
+func (mr *MockStockRepoMockRecorder) GetStockCodes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockCodes", reflect.TypeOf((*MockStockRepo)(nil).GetStockCodes), ctx)
+}
+
 // GetStockSummary mocks base method.
 func (m *MockStockRepo) GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +154,52 @@ func (mr *MockStockRepoMockRecorder) GetStockSummary(ctx, request interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockSummary", reflect.TypeOf((*MockStockRepo)(nil).GetStockSummary), ctx, request)
 }
 
+// GetStockSummaryPage mocks base method.
+func (m *MockStockRepo) GetStockSummaryPage(ctx context.Context, request model.GetStockSummaryRequest, offset, count int64) ([]model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockSummaryPage", ctx, request, offset, count)
+	ret0, _ := ret[0].([]model.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockSummaryPage indicates an expected call of GetStockSummaryPage.
+func (mr *MockStockRepoMockRecorder) GetStockSummaryPage(ctx, request, offset, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockSummaryPage", reflect.TypeOf((*MockStockRepo)(nil).GetStockSummaryPage), ctx, request, offset, count)
+}
+
+// GetTransactions mocks base method.
+func (m *MockStockRepo) GetTransactions(ctx context.Context, stockCode string, date time.Time) ([]model.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, stockCode, date)
+	ret0, _ := ret[0].([]model.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockStockRepoMockRecorder) GetTransactions(ctx, stockCode, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockStockRepo)(nil).GetTransactions), ctx, stockCode, date)
+}
+
+// OverrideStockSummary mocks base method.
+func (m *MockStockRepo) OverrideStockSummary(ctx context.Context, stockCode string, date time.Time, override func(model.Summary) (model.Summary, error)) (model.Summary, model.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideStockSummary", ctx, stockCode, date, override)
+	ret0, _ := ret[0].(model.Summary)
+	ret1, _ := ret[1].(model.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OverrideStockSummary indicates an expected call of OverrideStockSummary.
+func (mr *MockStockRepoMockRecorder) OverrideStockSummary(ctx, stockCode, date, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideStockSummary", reflect.TypeOf((*MockStockRepo)(nil).OverrideStockSummary), ctx, stockCode, date, override)
+}
+
 // UpdateStockSummary mocks base method.
 func (m *MockStockRepo) UpdateStockSummary(ctx context.Context, stockSummary model.Summary) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,53 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+
+	"stock/model"
+)
+
+// OverrideStockSummary sets the fields of request's override on the stock's summary of request's date, a day of the
+// stock's timezone, and returns the summary before and after. Average and VWAP are recomputed. The override is applied
+// atomically by the repo, so that it doesn't overwrite an update the Kafka consumer made in the meantime.
+func (uc *Usecase) OverrideStockSummary(ctx context.Context, request model.OverrideStockSummaryRequest) (before, after model.Summary, err error) {
+	date := model.TradingDate(request.Date, uc.getLocation(request.StockCode))
+
+	before, after, err = uc.stockRepo.OverrideStockSummary(ctx, request.StockCode, date, func(summary model.Summary) (model.Summary, error) {
+		return request.Override.Apply(summary).WithVWAP(uc.vwap.Precision)
+	})
+	if err != nil {
+		return model.Summary{}, model.Summary{}, err
+	}
+
+	uc.summaryCache.invalidate(request.StockCode, date, date)
+	summaries := []model.Summary{before, after}
+	uc.localizeSummaries(summaries)
+	return summaries[0], summaries[1], nil
+}
+
+// DeleteStockSummaries removes the stock's summaries of the requested date range, days of the stock's timezone, and
+// returns the removed summaries
+func (uc *Usecase) DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	request = uc.localize(request)
+
+	summaries, err := uc.stockRepo.GetStockSummary(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return summaries, nil
+	}
+
+	if _, err := uc.stockRepo.DeleteStockSummaries(ctx, request); err != nil {
+		return nil, err
+	}
+
+	uc.summaryCache.invalidate(request.StockCode, request.FromDate, request.ToDate)
+	uc.localizeSummaries(summaries)
+	return summaries, nil
+}
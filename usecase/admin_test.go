@@ -0,0 +1,280 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Usecase_OverrideStockSummary(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	high, volume := int64(6150), int64(200)
+
+	summary := model.Summary{
+		StockCode: "ASII",
+		Date:      date,
+		Prev:      6000,
+		Open:      6100,
+		High:      61000,
+		Low:       6100,
+		Close:     6125,
+		Volume:    200,
+		Value:     1222500,
+		Average:   6112,
+		Trades:    2,
+		VWAP:      611250,
+		VWAPScale: 2,
+	}
+	overridden := summary
+	overridden.High = 6150
+
+	type args struct {
+		ctx   context.Context
+		input model.OverrideStockSummaryRequest
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantBefore model.Summary
+		wantAfter  model.Summary
+		wantErr    error
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				input: model.OverrideStockSummaryRequest{
+					StockCode: "ASII",
+					Date:      date,
+					Override:  model.SummaryOverride{High: &high},
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), "ASII", date, gomock.Any()).
+						DoAndReturn(overrideStockSummary(summary))
+
+					return m
+				},
+			},
+			wantBefore: summary,
+			wantAfter:  overridden,
+		},
+		{
+			name: "success-recompute-average",
+			args: args{
+				ctx: context.Background(),
+				input: model.OverrideStockSummaryRequest{
+					StockCode: "ASII",
+					Date:      date,
+					Override:  model.SummaryOverride{Volume: &volume},
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), "ASII", date, gomock.Any()).
+						DoAndReturn(overrideStockSummary(model.Summary{StockCode: "ASII", Date: date, Volume: 100, Value: 1222500, Average: 12225}))
+
+					return m
+				},
+			},
+			wantBefore: model.Summary{StockCode: "ASII", Date: date, Volume: 100, Value: 1222500, Average: 12225},
+			wantAfter: model.Summary{
+				StockCode: "ASII", Date: date, Volume: 200, Value: 1222500, Average: 6112, VWAP: 611250, VWAPScale: 2,
+			},
+		},
+		{
+			name: "error-not-found",
+			args: args{
+				ctx: context.Background(),
+				input: model.OverrideStockSummaryRequest{
+					StockCode: "ASII",
+					Date:      date,
+					Override:  model.SummaryOverride{High: &high},
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), "ASII", date, gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, model.ErrSummaryNotFound)
+
+					return m
+				},
+			},
+			wantErr: model.ErrSummaryNotFound,
+		},
+		{
+			name: "error-override-stock-summary",
+			args: args{
+				ctx: context.Background(),
+				input: model.OverrideStockSummaryRequest{
+					StockCode: "ASII",
+					Date:      date,
+					Override:  model.SummaryOverride{High: &high},
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().OverrideStockSummary(gomock.Any(), "ASII", date, gomock.Any()).
+						Return(model.Summary{}, model.Summary{}, errors.New("error-override-stock-summary"))
+
+					return m
+				},
+			},
+			wantErr: errors.New("error-override-stock-summary"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := &Usecase{
+				stockRepo: tt.fields.stockRepo(ctrl),
+				vwap:      model.VWAP{Precision: 2},
+			}
+
+			gotBefore, gotAfter, err := usecase.OverrideStockSummary(tt.args.ctx, tt.args.input)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("usecase.OverrideStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(tt.wantErr, model.ErrSummaryNotFound) && !errors.Is(err, model.ErrSummaryNotFound) {
+				t.Errorf("usecase.OverrideStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotBefore, tt.wantBefore) {
+				t.Errorf("usecase.OverrideStockSummary() gotBefore = %v, wantBefore %v", gotBefore, tt.wantBefore)
+			}
+			if !reflect.DeepEqual(gotAfter, tt.wantAfter) {
+				t.Errorf("usecase.OverrideStockSummary() gotAfter = %v, wantAfter %v", gotAfter, tt.wantAfter)
+			}
+		})
+	}
+}
+
+// overrideStockSummary returns a mock StockRepo.OverrideStockSummary applying its override to stored
+func overrideStockSummary(stored model.Summary) func(ctx context.Context, stockCode string, date time.Time,
+	override func(summary model.Summary) (model.Summary, error)) (model.Summary, model.Summary, error) {
+	return func(_ context.Context, _ string, _ time.Time, override func(summary model.Summary) (model.Summary, error)) (model.Summary, model.Summary, error) {
+		after, err := override(stored)
+		return stored, after, err
+	}
+}
+
+func Test_Usecase_DeleteStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 28, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	request := model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate}
+
+	summaries := []model.Summary{
+		{StockCode: "BBCA", Date: fromDate, Close: 9025},
+		{StockCode: "BBCA", Date: toDate, Close: 9050},
+	}
+
+	type args struct {
+		ctx   context.Context
+		input model.GetStockSummaryRequest
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []model.Summary
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), input: request},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), request).Return(summaries, nil)
+					m.EXPECT().DeleteStockSummaries(gomock.Any(), request).Return(int64(2), nil)
+
+					return m
+				},
+			},
+			wantResponse: summaries,
+		},
+		{
+			name: "success-nothing-to-delete",
+			args: args{ctx: context.Background(), input: request},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), request).Return([]model.Summary{}, nil)
+
+					return m
+				},
+			},
+			wantResponse: []model.Summary{},
+		},
+		{
+			name: "error-delete-stock-summaries",
+			args: args{ctx: context.Background(), input: request},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), request).Return(summaries, nil)
+					m.EXPECT().DeleteStockSummaries(gomock.Any(), request).Return(int64(0), errors.New("error-delete-stock-summaries"))
+
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := &Usecase{
+				stockRepo: tt.fields.stockRepo(ctrl),
+			}
+
+			gotResponse, err := usecase.DeleteStockSummaries(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("usecase.DeleteStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("usecase.DeleteStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
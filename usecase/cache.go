@@ -0,0 +1,189 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"container/list"
+	"expvar"
+	"sync"
+	"time"
+
+	"stock/model"
+)
+
+var (
+	summaryCacheMetrics = expvar.NewMap("summary_cache")
+)
+
+type summaryCacheKey struct {
+	stockCode string
+	fromDate  int64
+	toDate    int64
+}
+
+type summaryCacheEntry struct {
+	key       summaryCacheKey
+	summaries []model.Summary
+}
+
+// SummaryCacheStats holds a summaryCache's counters since it was created
+type SummaryCacheStats struct {
+	Entries       int
+	Hits          int64
+	Misses        int64
+	Evictions     int64
+	Invalidations int64
+}
+
+// summaryCache is an LRU cache of GetStockSummary results keyed by (stockCode, fromDate, toDate).
+// Entries of a stock are invalidated whenever a summary within their date range is written.
+// A nil *summaryCache is a valid, disabled cache.
+type summaryCache struct {
+	mutex   sync.Mutex
+	size    int
+	maxRows int
+
+	entries     *list.List // Most recently used entries first
+	index       map[summaryCacheKey]*list.Element
+	stockKeys   map[string]map[summaryCacheKey]struct{}
+	generations map[string]uint64 // Bumped on each invalidation of a stock, see set
+	stats       SummaryCacheStats
+}
+
+func newSummaryCache(cfg model.Cache) *summaryCache {
+	if cfg.Size <= 0 {
+		return nil
+	}
+
+	return &summaryCache{
+		size:        cfg.Size,
+		maxRows:     cfg.MaxRows,
+		entries:     list.New(),
+		index:       map[summaryCacheKey]*list.Element{},
+		stockKeys:   map[string]map[summaryCacheKey]struct{}{},
+		generations: map[string]uint64{},
+	}
+}
+
+func newSummaryCacheKey(request model.GetStockSummaryRequest) summaryCacheKey {
+	return summaryCacheKey{
+		stockCode: request.StockCode,
+		fromDate:  request.FromDate.Unix(),
+		toDate:    request.ToDate.Unix(),
+	}
+}
+
+// get returns the cached summaries of request, along with the stock's current generation to be passed to set on a miss
+func (cache *summaryCache) get(request model.GetStockSummaryRequest) ([]model.Summary, uint64, bool) {
+	if cache == nil {
+		return nil, 0, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	generation := cache.generations[request.StockCode]
+
+	element, ok := cache.index[newSummaryCacheKey(request)]
+	if !ok {
+		cache.stats.Misses++
+		summaryCacheMetrics.Add("misses", 1)
+		return nil, generation, false
+	}
+
+	cache.entries.MoveToFront(element)
+	cache.stats.Hits++
+	summaryCacheMetrics.Add("hits", 1)
+
+	summaries := element.Value.(*summaryCacheEntry).summaries
+	return append([]model.Summary{}, summaries...), generation, true
+}
+
+// set caches the summaries of request that were read from the repo after get returned generation.
+// If the stock was invalidated in the meantime, summaries may already be stale and are not cached.
+func (cache *summaryCache) set(request model.GetStockSummaryRequest, generation uint64, summaries []model.Summary) {
+	if cache == nil || (cache.maxRows > 0 && len(summaries) > cache.maxRows) {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.generations[request.StockCode] != generation {
+		return
+	}
+
+	key := newSummaryCacheKey(request)
+	entry := &summaryCacheEntry{
+		key:       key,
+		summaries: append([]model.Summary{}, summaries...),
+	}
+
+	if element, ok := cache.index[key]; ok {
+		element.Value = entry
+		cache.entries.MoveToFront(element)
+		return
+	}
+
+	cache.index[key] = cache.entries.PushFront(entry)
+	if cache.stockKeys[key.stockCode] == nil {
+		cache.stockKeys[key.stockCode] = map[summaryCacheKey]struct{}{}
+	}
+	cache.stockKeys[key.stockCode][key] = struct{}{}
+
+	for cache.entries.Len() > cache.size {
+		cache.remove(cache.entries.Back())
+		cache.stats.Evictions++
+		summaryCacheMetrics.Add("evictions", 1)
+	}
+}
+
+// invalidate removes the cached results of stockCode whose date range overlaps [fromDate, toDate]
+func (cache *summaryCache) invalidate(stockCode string, fromDate, toDate time.Time) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.generations[stockCode]++
+
+	for key := range cache.stockKeys[stockCode] {
+		if key.fromDate > toDate.Unix() || key.toDate < fromDate.Unix() {
+			continue
+		}
+
+		cache.remove(cache.index[key])
+		cache.stats.Invalidations++
+		summaryCacheMetrics.Add("invalidations", 1)
+	}
+}
+
+// Stats returns the cache's counters; a disabled cache has none
+func (cache *summaryCache) Stats() SummaryCacheStats {
+	if cache == nil {
+		return SummaryCacheStats{}
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	stats := cache.stats
+	stats.Entries = cache.entries.Len()
+	return stats
+}
+
+func (cache *summaryCache) remove(element *list.Element) {
+	key := element.Value.(*summaryCacheEntry).key
+
+	cache.entries.Remove(element)
+	delete(cache.index, key)
+	delete(cache.stockKeys[key.stockCode], key)
+	if len(cache.stockKeys[key.stockCode]) == 0 {
+		delete(cache.stockKeys, key.stockCode)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func newTestRequest(stockCode string, fromDay, toDay int) model.GetStockSummaryRequest {
+	return model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  time.Time{}.AddDate(0, 0, fromDay),
+		ToDate:    time.Time{}.AddDate(0, 0, toDay),
+	}
+}
+
+func Test_summaryCache(t *testing.T) {
+	summaries := []model.Summary{
+		{
+			StockCode: "BBCA",
+			Date:      time.Time{}.AddDate(0, 0, 1),
+			Close:     8100,
+		},
+	}
+
+	tests := []struct {
+		name string
+		run  func(cache *summaryCache)
+
+		wantHits  []model.GetStockSummaryRequest
+		wantMiss  []model.GetStockSummaryRequest
+		wantStats SummaryCacheStats
+	}{
+		{
+			name: "success-lru-eviction",
+			run: func(cache *summaryCache) {
+				cache.set(newTestRequest("BBCA", 1, 1), 0, summaries)
+				cache.set(newTestRequest("BBCA", 1, 2), 0, summaries)
+				_, _, _ = cache.get(newTestRequest("BBCA", 1, 1))
+				cache.set(newTestRequest("BBRI", 1, 1), 0, summaries)
+			},
+			wantHits: []model.GetStockSummaryRequest{newTestRequest("BBCA", 1, 1), newTestRequest("BBRI", 1, 1)},
+			wantMiss: []model.GetStockSummaryRequest{newTestRequest("BBCA", 1, 2)},
+			wantStats: SummaryCacheStats{
+				Entries:   2,
+				Hits:      3,
+				Misses:    1,
+				Evictions: 1,
+			},
+		},
+		{
+			name: "success-invalidate-overlapping-ranges-only",
+			run: func(cache *summaryCache) {
+				cache.set(newTestRequest("BBCA", 1, 3), 0, summaries)
+				cache.set(newTestRequest("BBCA", 4, 5), 0, summaries)
+				cache.invalidate("BBCA", time.Time{}.AddDate(0, 0, 3), time.Time{}.AddDate(0, 0, 3))
+			},
+			wantHits: []model.GetStockSummaryRequest{newTestRequest("BBCA", 4, 5)},
+			wantMiss: []model.GetStockSummaryRequest{newTestRequest("BBCA", 1, 3)},
+			wantStats: SummaryCacheStats{
+				Entries:       1,
+				Hits:          1,
+				Misses:        1,
+				Invalidations: 1,
+			},
+		},
+		{
+			name: "success-skip-set-after-invalidation",
+			run: func(cache *summaryCache) {
+				_, generation, _ := cache.get(newTestRequest("BBCA", 1, 1))
+				cache.invalidate("BBCA", time.Time{}.AddDate(0, 0, 1), time.Time{}.AddDate(0, 0, 1))
+				cache.set(newTestRequest("BBCA", 1, 1), generation, summaries)
+			},
+			wantMiss: []model.GetStockSummaryRequest{newTestRequest("BBCA", 1, 1)},
+			wantStats: SummaryCacheStats{
+				Misses: 2,
+			},
+		},
+		{
+			name: "success-skip-set-above-max-rows",
+			run: func(cache *summaryCache) {
+				cache.set(newTestRequest("BBCA", 1, 5), 0, append(summaries, summaries...))
+			},
+			wantMiss: []model.GetStockSummaryRequest{newTestRequest("BBCA", 1, 5)},
+			wantStats: SummaryCacheStats{
+				Misses: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newSummaryCache(model.Cache{Size: 2, MaxRows: 1})
+
+			tt.run(cache)
+
+			for _, request := range tt.wantHits {
+				if got, _, ok := cache.get(request); !ok || !reflect.DeepEqual(got, summaries) {
+					t.Errorf("summaryCache.get(%v) got = %v, ok = %v, want hit", request, got, ok)
+				}
+			}
+
+			for _, request := range tt.wantMiss {
+				if _, _, ok := cache.get(request); ok {
+					t.Errorf("summaryCache.get(%v) ok = %v, want miss", request, ok)
+				}
+			}
+
+			if gotStats := cache.Stats(); !reflect.DeepEqual(gotStats, tt.wantStats) {
+				t.Errorf("summaryCache.Stats() gotStats = %+v, wantStats %+v", gotStats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func Test_summaryCache_Concurrent(t *testing.T) {
+	cache := newSummaryCache(model.Cache{Size: 8})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(day int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				request := newTestRequest("BBCA", day, day+j%3)
+				if _, generation, ok := cache.get(request); !ok {
+					cache.set(request, generation, []model.Summary{})
+				}
+				cache.invalidate("BBCA", request.ToDate, request.ToDate)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := cache.Stats(); stats.Entries > 8 {
+		t.Errorf("summaryCache.Stats() Entries = %d, want at most 8", stats.Entries)
+	}
+}
+
+func Test_Usecase_GetStockSummary_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	request := newTestRequest("BBCA", 1, 1)
+	summary := model.Summary{
+		StockCode: "BBCA",
+		Date:      time.Time{}.AddDate(0, 0, 1),
+		Prev:      8000,
+		Open:      8050,
+		High:      8050,
+		Low:       8050,
+		Close:     8050,
+		Volume:    100,
+		Value:     805000,
+		Average:   8050,
+	}
+
+	m := mock.NewMockStockRepo(ctrl)
+	gomock.InOrder(
+		m.EXPECT().GetStockSummary(gomock.Any(), request).Return([]model.Summary{summary}, nil),
+		m.EXPECT().GetStockSummary(gomock.Any(), request).Return([]model.Summary{summary}, nil),
+		m.EXPECT().UpdateStockSummary(gomock.Any(), gomock.Any()).Return(nil),
+		m.EXPECT().GetStockSummary(gomock.Any(), request).Return([]model.Summary{summary}, nil),
+	)
+
+	usecase := &Usecase{
+		stockRepo:    m,
+		summaryCache: newSummaryCache(model.Cache{Size: 10}),
+	}
+
+	// Miss, then hit
+	for i := 0; i < 2; i++ {
+		if _, err := usecase.GetStockSummary(context.Background(), request); err != nil {
+			t.Fatalf("usecase.GetStockSummary() err = %v", err)
+		}
+	}
+
+	// A trade on the cached date invalidates it
+	err := usecase.UpdateStockSummary(context.Background(), model.Transaction{
+		StockCode: "BBCA",
+		Price:     8100,
+		Quantity:  100,
+		Type:      model.TransactionTypeP,
+		Date:      time.Time{}.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		t.Fatalf("usecase.UpdateStockSummary() err = %v", err)
+	}
+
+	if _, err := usecase.GetStockSummary(context.Background(), request); err != nil {
+		t.Fatalf("usecase.GetStockSummary() err = %v", err)
+	}
+
+	wantStats := SummaryCacheStats{
+		Entries:       1,
+		Hits:          1,
+		Misses:        2,
+		Invalidations: 1,
+	}
+	if gotStats := usecase.summaryCache.Stats(); !reflect.DeepEqual(gotStats, wantStats) {
+		t.Errorf("summaryCache.Stats() gotStats = %+v, wantStats %+v", gotStats, wantStats)
+	}
+}
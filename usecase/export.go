@@ -0,0 +1,72 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"stock/model"
+)
+
+const (
+	// exportPageSize bounds the number of summaries held in memory by an export
+	exportPageSize = 500
+)
+
+// ExportStockSummaries reads the requested stock summaries one page at a time and passes each page to write,
+// ordered by stock code then date. The summary cache is bypassed, as exports read long date ranges only once.
+// A stock's archived monthly summaries are written first, as compaction leaves no daily summaries before them.
+func (uc *Usecase) ExportStockSummaries(ctx context.Context, request model.ExportStockSummariesRequest,
+	write func(summaries []model.Summary) error) error {
+	stockCodes := request.StockCodes
+	if len(stockCodes) == 0 {
+		var err error
+		stockCodes, err = uc.stockRepo.GetStockCodes(ctx)
+		if err != nil {
+			return err
+		}
+		sort.Strings(stockCodes)
+	}
+
+	for _, stockCode := range stockCodes {
+		summaryRequest := uc.localize(model.GetStockSummaryRequest{
+			StockCode: stockCode,
+			FromDate:  request.FromDate,
+			ToDate:    request.ToDate,
+		})
+
+		archivedSummaries, err := uc.getArchivedSummaries(ctx, summaryRequest)
+		if err != nil {
+			return err
+		}
+		if len(archivedSummaries) > 0 {
+			if err := write(archivedSummaries); err != nil {
+				return err
+			}
+		}
+
+		for offset := int64(0); ; offset += exportPageSize {
+			summaries, err := uc.stockRepo.GetStockSummaryPage(ctx, summaryRequest, offset, exportPageSize)
+			if err != nil {
+				return err
+			}
+
+			if len(summaries) > 0 {
+				uc.localizeSummaries(summaries)
+				if err := write(summaries); err != nil {
+					return err
+				}
+			}
+
+			if len(summaries) < exportPageSize {
+				break
+			}
+		}
+	}
+
+	return nil
+}
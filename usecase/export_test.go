@@ -0,0 +1,219 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Usecase_ExportStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	newSummaries := func(stockCode string, n int) []model.Summary {
+		summaries := make([]model.Summary, n)
+		for i := range summaries {
+			summaries[i] = model.Summary{StockCode: stockCode, Date: fromDate.AddDate(0, 0, i%31)}
+		}
+		return summaries
+	}
+
+	fullPage := newSummaries("BBCA", exportPageSize)
+	lastPage := newSummaries("BBCA", 10)
+	tlkmPage := newSummaries("TLKM", 3)
+	archivedPage := []model.Summary{
+		{StockCode: "TLKM", Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Monthly: true},
+		{StockCode: "TLKM", Date: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), Monthly: true},
+	}
+
+	type args struct {
+		ctx     context.Context
+		request model.ExportStockSummariesRequest
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+		retention model.Retention
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantPages [][]model.Summary
+		wantErr   bool
+	}{
+		{
+			name: "success-paged",
+			args: args{
+				ctx: context.Background(),
+				request: model.ExportStockSummariesRequest{
+					StockCodes: []string{"BBCA", "TLKM"},
+					FromDate:   fromDate,
+					ToDate:     toDate,
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					bbca := model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: fromDate, ToDate: toDate}
+					tlkm := model.GetStockSummaryRequest{StockCode: "TLKM", FromDate: fromDate, ToDate: toDate}
+					gomock.InOrder(
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), bbca, int64(0), int64(exportPageSize)).Return(fullPage, nil),
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), bbca, int64(exportPageSize), int64(exportPageSize)).Return(lastPage, nil),
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), tlkm, int64(0), int64(exportPageSize)).Return(tlkmPage, nil),
+					)
+					return m
+				},
+			},
+			wantPages: [][]model.Summary{fullPage, lastPage, tlkmPage},
+		},
+		{
+			name: "success-every-stock",
+			args: args{
+				ctx:     context.Background(),
+				request: model.ExportStockSummariesRequest{FromDate: fromDate, ToDate: toDate},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{"TLKM", "ASII"}, nil)
+					gomock.InOrder(
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), model.GetStockSummaryRequest{
+							StockCode: "ASII", FromDate: fromDate, ToDate: toDate,
+						}, int64(0), int64(exportPageSize)).Return([]model.Summary{}, nil),
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), model.GetStockSummaryRequest{
+							StockCode: "TLKM", FromDate: fromDate, ToDate: toDate,
+						}, int64(0), int64(exportPageSize)).Return(tlkmPage, nil),
+					)
+					return m
+				},
+			},
+			wantPages: [][]model.Summary{tlkmPage},
+		},
+		{
+			name: "success-archived",
+			args: args{
+				ctx: context.Background(),
+				request: model.ExportStockSummariesRequest{
+					StockCodes: []string{"TLKM"},
+					FromDate:   time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+					ToDate:     toDate,
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					tlkm := model.GetStockSummaryRequest{
+						StockCode: "TLKM", FromDate: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), ToDate: toDate,
+					}
+					gomock.InOrder(
+						m.EXPECT().GetArchivedStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+							StockCode: "TLKM", FromDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), ToDate: toDate,
+						}).Return(archivedPage, nil),
+						m.EXPECT().GetStockSummaryPage(gomock.Any(), tlkm, int64(0), int64(exportPageSize)).Return(tlkmPage, nil),
+					)
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantPages: [][]model.Summary{archivedPage, tlkmPage},
+		},
+		{
+			name: "error-get-stock-codes",
+			args: args{
+				ctx:     context.Background(),
+				request: model.ExportStockSummariesRequest{FromDate: fromDate, ToDate: toDate},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{}, errors.New("error-get-stock-codes"))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-get-stock-summary-page",
+			args: args{
+				ctx: context.Background(),
+				request: model.ExportStockSummariesRequest{
+					StockCodes: []string{"BBCA"},
+					FromDate:   fromDate,
+					ToDate:     toDate,
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					m.EXPECT().GetStockSummaryPage(gomock.Any(), gomock.Any(), int64(0), int64(exportPageSize)).
+						Return([]model.Summary{}, errors.New("error-get-stock-summary-page"))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-get-archived-stock-summary",
+			args: args{
+				ctx: context.Background(),
+				request: model.ExportStockSummariesRequest{
+					StockCodes: []string{"TLKM"},
+					FromDate:   time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+					ToDate:     toDate,
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), gomock.Any()).
+						Return([]model.Summary{}, errors.New("error-get-archived-stock-summary"))
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := &Usecase{
+				stockRepo: tt.fields.stockRepo(ctrl),
+				retention: tt.fields.retention,
+			}
+
+			var gotPages [][]model.Summary
+			err := uc.ExportStockSummaries(tt.args.ctx, tt.args.request, func(summaries []model.Summary) error {
+				gotPages = append(gotPages, summaries)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("usecase.ExportStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotPages, tt.wantPages) {
+				t.Errorf("usecase.ExportStockSummaries() gotPages = %d pages, wantPages %d pages", len(gotPages), len(tt.wantPages))
+			}
+		})
+	}
+}
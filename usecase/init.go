@@ -7,6 +7,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"stock/model"
 )
@@ -14,15 +15,63 @@ import (
 //go:generate mockgen -source=./init.go -destination=./_mock/stock_summary_mock.go -package=mock
 type StockRepo interface {
 	GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) (result []model.Summary, err error)
+	GetStockSummaryPage(ctx context.Context, request model.GetStockSummaryRequest, offset, count int64) (result []model.Summary, err error)
 	UpdateStockSummary(ctx context.Context, stockSummary model.Summary) (err error)
+	OverrideStockSummary(ctx context.Context, stockCode string, date time.Time, override func(summary model.Summary) (model.Summary, error)) (before, after model.Summary, err error)
+	GetStockCodes(ctx context.Context) (result []string, err error)
+	GetArchivedStockSummary(ctx context.Context, request model.GetStockSummaryRequest) (result []model.Summary, err error)
+	ArchiveStockSummary(ctx context.Context, archivedSummary model.Summary, request model.GetStockSummaryRequest) (err error)
+	ClearStockSummaries(ctx context.Context, fromDate time.Time, getLocation func(stockCode string) *time.Location) (report model.ClearReport, err error)
+	DeleteStockSummaries(ctx context.Context, request model.GetStockSummaryRequest) (deleted int64, err error)
+	AppendTransaction(ctx context.Context, transaction model.Transaction) (err error)
+	GetTransactions(ctx context.Context, stockCode string, date time.Time) (result []model.JournalEntry, err error)
+	DeleteTransactions(ctx context.Context, toDate time.Time, getLocation func(stockCode string) *time.Location) (deleted int64, err error)
 }
 
 type Usecase struct {
-	stockRepo StockRepo
+	stockRepo    StockRepo
+	retention    model.Retention
+	validation   model.Validation
+	lateness     model.Lateness
+	vwap         model.VWAP
+	journal      model.Journal
+	schedule     model.TradingSchedule
+	instruments  model.Instruments
+	summaryCache *summaryCache
 }
 
-func New(stockRepo StockRepo) *Usecase {
+func New(cfg model.Config, stockRepo StockRepo) *Usecase {
 	return &Usecase{
-		stockRepo: stockRepo,
+		stockRepo:    stockRepo,
+		retention:    cfg.Retention,
+		validation:   cfg.Validation,
+		lateness:     cfg.Lateness,
+		vwap:         cfg.VWAP,
+		journal:      cfg.Journal,
+		schedule:     cfg.Schedule,
+		instruments:  cfg.Instruments,
+		summaryCache: newSummaryCache(cfg.Cache),
+	}
+}
+
+// getLocation returns the timezone of stockCode's trading days
+func (uc *Usecase) getLocation(stockCode string) *time.Location {
+	return uc.schedule.GetLocation(uc.instruments.Get(stockCode))
+}
+
+// localize returns request with its dates moved to the start of their trading day in the stock's timezone
+func (uc *Usecase) localize(request model.GetStockSummaryRequest) model.GetStockSummaryRequest {
+	location := uc.getLocation(request.StockCode)
+	if !request.FromDate.IsZero() {
+		request.FromDate = model.TradingDate(request.FromDate, location)
+	}
+	request.ToDate = model.TradingDate(request.ToDate, location)
+	return request
+}
+
+// localizeSummaries sets the location of the dates of summaries, which are read back in UTC, to their stock's timezone
+func (uc *Usecase) localizeSummaries(summaries []model.Summary) {
+	for i := range summaries {
+		summaries[i].Date = summaries[i].Date.In(uc.getLocation(summaries[i].StockCode))
 	}
 }
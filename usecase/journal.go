@@ -0,0 +1,73 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"stock/model"
+)
+
+// GetTransactions returns the journal of the stock's summary of date, a day of the stock's timezone
+func (uc *Usecase) GetTransactions(ctx context.Context, stockCode string, date time.Time) ([]model.JournalEntry, error) {
+	if !uc.journal.Enabled {
+		return nil, model.ErrJournalDisabled
+	}
+
+	location := uc.getLocation(stockCode)
+	entries, err := uc.stockRepo.GetTransactions(ctx, stockCode, model.TradingDate(date, location))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Transaction.Date = entries[i].Transaction.Date.In(location)
+		if !entries[i].Transaction.Timestamp.IsZero() {
+			entries[i].Transaction.Timestamp = entries[i].Transaction.Timestamp.In(location)
+		}
+	}
+
+	return entries, nil
+}
+
+// RecomputeStockSummary rebuilds the stock's summary of date, a day of the stock's timezone, from its journal and
+// returns the summary before, zero when there was none, and after
+func (uc *Usecase) RecomputeStockSummary(ctx context.Context, stockCode string, date time.Time) (before, after model.Summary, err error) {
+	entries, err := uc.GetTransactions(ctx, stockCode, date)
+	if err != nil {
+		return model.Summary{}, model.Summary{}, err
+	}
+	if len(entries) == 0 {
+		return model.Summary{}, model.Summary{}, model.ErrJournalNotFound
+	}
+
+	date = model.TradingDate(date, uc.getLocation(stockCode))
+	summaries, err := uc.stockRepo.GetStockSummary(ctx, model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  date,
+		ToDate:    date,
+	})
+	if err != nil {
+		return model.Summary{}, model.Summary{}, err
+	}
+	if len(summaries) > 0 {
+		uc.localizeSummaries(summaries)
+		before = summaries[0]
+	}
+
+	after, err = model.RebuildSummary(entries).WithVWAP(uc.vwap.Precision)
+	if err != nil {
+		return model.Summary{}, model.Summary{}, err
+	}
+
+	if err := uc.stockRepo.UpdateStockSummary(ctx, after); err != nil {
+		return model.Summary{}, model.Summary{}, err
+	}
+
+	uc.summaryCache.invalidate(stockCode, date, date)
+	return before, after, nil
+}
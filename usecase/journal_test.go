@@ -0,0 +1,280 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Usecase_GetTransactions(t *testing.T) {
+	jakarta, err := model.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("model.LoadLocation() err = %v", err)
+	}
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	jakartaDate := time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta)
+	timestamp := time.Date(2023, 8, 29, 9, 30, 0, 0, jakarta)
+
+	type args struct {
+		ctx       context.Context
+		stockCode string
+		date      time.Time
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+		journal   model.Journal
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse []model.JournalEntry
+		wantErr      error
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), stockCode: "ASII", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					// Journals are read back in UTC
+					m.EXPECT().GetTransactions(gomock.Any(), "ASII", jakartaDate).Return([]model.JournalEntry{
+						{ID: "1693276200000-0", Transaction: model.Transaction{StockCode: "ASII", Date: jakartaDate.UTC(), Timestamp: timestamp.UTC()}},
+						{ID: "1693276260000-0", Transaction: model.Transaction{StockCode: "ASII", Date: jakartaDate.UTC()}},
+					}, nil)
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantResponse: []model.JournalEntry{
+				{ID: "1693276200000-0", Transaction: model.Transaction{StockCode: "ASII", Date: jakartaDate, Timestamp: timestamp}},
+				{ID: "1693276260000-0", Transaction: model.Transaction{StockCode: "ASII", Date: jakartaDate}},
+			},
+		},
+		{
+			name: "error-journal-disabled",
+			args: args{ctx: context.Background(), stockCode: "ASII", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo { return mock.NewMockStockRepo(ctrl) },
+			},
+			wantErr: model.ErrJournalDisabled,
+		},
+		{
+			name: "error-get-transactions",
+			args: args{ctx: context.Background(), stockCode: "ASII", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "ASII", jakartaDate).Return(nil, errors.New("error-get-transactions"))
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantErr: errors.New("error-get-transactions"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := &Usecase{
+				stockRepo:   tt.fields.stockRepo(ctrl),
+				journal:     tt.fields.journal,
+				instruments: model.Instruments{{StockCode: "ASII", Timezone: "Asia/Jakarta"}},
+			}
+
+			gotResponse, err := usecase.GetTransactions(tt.args.ctx, tt.args.stockCode, tt.args.date)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("usecase.GetTransactions() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(tt.wantErr, model.ErrJournalDisabled) && !errors.Is(err, model.ErrJournalDisabled) {
+				t.Errorf("usecase.GetTransactions() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("usecase.GetTransactions() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func Test_Usecase_RecomputeStockSummary(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	entries := []model.JournalEntry{
+		{ID: "1693272600000-0", Transaction: model.Transaction{Type: model.TransactionTypeA, StockCode: "BBCA", Price: 9000, Date: date}},
+		{ID: "1693301400000-0", Transaction: model.Transaction{
+			Type: model.TransactionTypeE, StockCode: "BBCA", Price: 9050, Quantity: 100, Date: date, Session: model.SessionOne,
+		}},
+		{ID: "1693301460000-0", Transaction: model.Transaction{
+			Type: model.TransactionTypeE, StockCode: "BBCA", Price: 9025, Quantity: 200, Date: date, Session: model.SessionOne,
+		}},
+	}
+
+	// A bad tick was overridden by hand
+	corrupted := model.Summary{StockCode: "BBCA", Date: date, Prev: 9000, Open: 9050, High: 90500, Low: 9025, Close: 9025}
+
+	rebuilt := model.Summary{
+		StockCode: "BBCA",
+		Date:      date,
+		Prev:      9000,
+		Open:      9050,
+		High:      9050,
+		Low:       9025,
+		Close:     9025,
+		Volume:    300,
+		Value:     2710000,
+		Average:   9033,
+		Trades:    2,
+		SessionOne: model.SessionSummary{
+			Open: 9050, High: 9050, Low: 9025, Close: 9025, Volume: 300, Value: 2710000, Trades: 2,
+		},
+		VWAP:      903333,
+		VWAPScale: 2,
+	}
+
+	type args struct {
+		ctx       context.Context
+		stockCode string
+		date      time.Time
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+		journal   model.Journal
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantBefore model.Summary
+		wantAfter  model.Summary
+		wantErr    error
+	}{
+		{
+			name: "success",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", date).Return(entries, nil)
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{StockCode: "BBCA", FromDate: date, ToDate: date}).
+						Return([]model.Summary{corrupted}, nil)
+					m.EXPECT().UpdateStockSummary(gomock.Any(), rebuilt).Return(nil)
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantBefore: corrupted,
+			wantAfter:  rebuilt,
+		},
+		{
+			name: "success-deleted-summary",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", date).Return(entries, nil)
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+					m.EXPECT().UpdateStockSummary(gomock.Any(), rebuilt).Return(nil)
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantAfter: rebuilt,
+		},
+		{
+			name: "error-journal-not-found",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", date).Return([]model.JournalEntry{}, nil)
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantErr: model.ErrJournalNotFound,
+		},
+		{
+			name: "error-journal-disabled",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo { return mock.NewMockStockRepo(ctrl) },
+			},
+			wantErr: model.ErrJournalDisabled,
+		},
+		{
+			name: "error-update-stock-summary",
+			args: args{ctx: context.Background(), stockCode: "BBCA", date: date},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetTransactions(gomock.Any(), "BBCA", date).Return(entries, nil)
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{corrupted}, nil)
+					m.EXPECT().UpdateStockSummary(gomock.Any(), gomock.Any()).Return(errors.New("error-update-stock-summary"))
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantErr: errors.New("error-update-stock-summary"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := &Usecase{
+				stockRepo: tt.fields.stockRepo(ctrl),
+				journal:   tt.fields.journal,
+				vwap:      model.VWAP{Precision: 2},
+			}
+
+			gotBefore, gotAfter, err := usecase.RecomputeStockSummary(tt.args.ctx, tt.args.stockCode, tt.args.date)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("usecase.RecomputeStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, sentinel := range []error{model.ErrJournalNotFound, model.ErrJournalDisabled} {
+				if errors.Is(tt.wantErr, sentinel) && !errors.Is(err, sentinel) {
+					t.Errorf("usecase.RecomputeStockSummary() err = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if !reflect.DeepEqual(gotBefore, tt.wantBefore) {
+				t.Errorf("usecase.RecomputeStockSummary() gotBefore = %v, wantBefore %v", gotBefore, tt.wantBefore)
+			}
+			if !reflect.DeepEqual(gotAfter, tt.wantAfter) {
+				t.Errorf("usecase.RecomputeStockSummary() gotAfter = %v, wantAfter %v", gotAfter, tt.wantAfter)
+			}
+		})
+	}
+}
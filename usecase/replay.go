@@ -0,0 +1,50 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stock/model"
+)
+
+var (
+	// maxDate bounds the open-ended date ranges cleared before a replay
+	maxDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// ClearStockSummaries removes the stock summaries dated fromDate or later, in the timezone of each stock, so that
+// replayed transactions are applied to empty summaries instead of being counted twice. A zero fromDate removes every
+// summary. Archived monthly summaries are never cleared, so with retention enabled fromDate can't be before the
+// retention cutoff: the replayed days would be counted twice once compacted into their archived months.
+func (uc *Usecase) ClearStockSummaries(ctx context.Context, fromDate time.Time) (model.ClearReport, error) {
+	if uc.retention.Enabled {
+		exchange := uc.schedule.GetLocation(model.Instrument{})
+		cutoff := uc.retentionCutoff(time.Now())
+		if fromDate.IsZero() || model.TradingDate(fromDate, exchange).Before(cutoff) {
+			return model.ClearReport{FromDate: fromDate}, fmt.Errorf(
+				"stock summaries can't be cleared before the retention cutoff %s, as archived summaries are kept",
+				cutoff.Format(time.DateOnly))
+		}
+	}
+
+	report, err := uc.stockRepo.ClearStockSummaries(ctx, fromDate, uc.getLocation)
+	if err != nil {
+		return report, err
+	}
+
+	for _, stockCode := range report.StockCodes {
+		stockFromDate := fromDate
+		if !fromDate.IsZero() {
+			stockFromDate = model.TradingDate(fromDate, uc.getLocation(stockCode))
+		}
+		uc.summaryCache.invalidate(stockCode, stockFromDate, maxDate)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,188 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Usecase_ClearStockSummaries(t *testing.T) {
+	fromDate := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+
+	cachedRequest := model.GetStockSummaryRequest{
+		StockCode: "BBCA",
+		FromDate:  time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:    time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	type args struct {
+		ctx      context.Context
+		fromDate time.Time
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+		retention model.Retention
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse      model.ClearReport
+		wantInvalidations int64
+		wantErr           bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), fromDate, gomock.Any()).
+						Return(model.ClearReport{FromDate: fromDate, Rows: 3, StockCodes: []string{"BBCA"}}, nil)
+
+					return m
+				},
+			},
+			wantResponse:      model.ClearReport{FromDate: fromDate, Rows: 3, StockCodes: []string{"BBCA"}},
+			wantInvalidations: 1,
+		},
+		{
+			name: "success-every-date-retention-disabled",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), time.Time{}, gomock.Any()).
+						Return(model.ClearReport{Rows: 10, StockCodes: []string{"BBCA", "BBRI"}}, nil)
+
+					return m
+				},
+				retention: model.DefaultConfigLocal.Retention,
+			},
+			wantResponse:      model.ClearReport{Rows: 10, StockCodes: []string{"BBCA", "BBRI"}},
+			wantInvalidations: 1,
+		},
+		{
+			name: "success-after-retention-cutoff",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: time.Now().UTC().Truncate(24 * time.Hour),
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ClearReport{}, nil)
+
+					return m
+				},
+				retention: model.Retention{
+					Enabled:   true,
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.ClearReport{},
+		},
+		{
+			name: "error-before-retention-cutoff",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					return mock.NewMockStockRepo(ctrl)
+				},
+				retention: model.Retention{
+					Enabled:   true,
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate},
+			wantErr:      true,
+		},
+		{
+			name: "error-every-date-with-retention",
+			args: args{
+				ctx: context.Background(),
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					return mock.NewMockStockRepo(ctrl)
+				},
+				retention: model.Retention{
+					Enabled:   true,
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.ClearReport{},
+			wantErr:      true,
+		},
+		{
+			name: "error-clear-stock-summaries",
+			args: args{
+				ctx:      context.Background(),
+				fromDate: fromDate,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().ClearStockSummaries(gomock.Any(), fromDate, gomock.Any()).
+						Return(model.ClearReport{FromDate: fromDate}, errors.New("error-clear-stock-summaries"))
+
+					return m
+				},
+			},
+			wantResponse: model.ClearReport{FromDate: fromDate},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			uc := &Usecase{
+				stockRepo:    tt.fields.stockRepo(ctrl),
+				retention:    tt.fields.retention,
+				summaryCache: newSummaryCache(model.Cache{Size: 10}),
+			}
+			_, generation, _ := uc.summaryCache.get(cachedRequest)
+			uc.summaryCache.set(cachedRequest, generation, []model.Summary{{StockCode: "BBCA", Date: fromDate}})
+
+			gotResponse, err := uc.ClearStockSummaries(tt.args.ctx, tt.args.fromDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("usecase.ClearStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("usecase.ClearStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+
+			if got := uc.summaryCache.Stats().Invalidations; got != tt.wantInvalidations {
+				t.Errorf("usecase.ClearStockSummaries() invalidations = %d, want %d", got, tt.wantInvalidations)
+			}
+		})
+	}
+}
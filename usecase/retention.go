@@ -0,0 +1,201 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sort"
+	"time"
+
+	"stock/model"
+)
+
+var (
+	retentionMetrics = expvar.NewMap("retention")
+)
+
+// CompactStockSummaries applies the retention policy: daily stock summaries dated more than DailyDays before now are
+// downsampled into monthly summaries stored in the archive, then removed along with their transaction journals. Reads
+// of those days return the archived monthly summaries instead. The cutoff is a day of the exchange's timezone, and
+// months are those of each stock's timezone. On a dry run, nothing is written and the returned report only describes
+// what would be archived.
+func (uc *Usecase) CompactStockSummaries(ctx context.Context, now time.Time, dryRun bool) (model.RetentionReport, error) {
+	if uc.retention.DailyDays <= 0 {
+		return model.RetentionReport{}, errors.New("retention policy daily_days must be positive")
+	}
+
+	report := model.RetentionReport{
+		Cutoff: uc.retentionCutoff(now),
+		DryRun: dryRun,
+	}
+
+	retentionMetrics.Add("runs", 1)
+
+	stockCodes, err := uc.stockRepo.GetStockCodes(ctx)
+	if err != nil {
+		retentionMetrics.Add("errors", 1)
+		return report, err
+	}
+
+	for _, stockCode := range stockCodes {
+		stockReport, err := uc.compactStockSummary(ctx, stockCode, report.Cutoff, dryRun)
+		if err != nil {
+			retentionMetrics.Add("errors", 1)
+			return report, err
+		}
+
+		if stockReport.DailyRows == 0 {
+			continue
+		}
+
+		report.Stocks = append(report.Stocks, stockReport)
+		report.DailyRows += stockReport.DailyRows
+		report.MonthlyRows += stockReport.MonthlyRows
+	}
+
+	if !dryRun {
+		retentionMetrics.Add("archived_daily_rows", int64(report.DailyRows))
+		retentionMetrics.Add("archived_monthly_rows", int64(report.MonthlyRows))
+
+		// Journals can only rebuild daily summaries, so they're kept as long as those
+		report.Journals, err = uc.stockRepo.DeleteTransactions(ctx, report.Cutoff, uc.getLocation)
+		if err != nil {
+			retentionMetrics.Add("errors", 1)
+			return report, err
+		}
+		retentionMetrics.Add("deleted_journals", report.Journals)
+	}
+
+	return report, nil
+}
+
+// compactStockSummary archives a stock's daily summaries dated before cutoff, one month at a time.
+// A month that was already partially archived by a previous run is merged with its archived summary.
+func (uc *Usecase) compactStockSummary(ctx context.Context, stockCode string, cutoff time.Time, dryRun bool) (model.StockRetentionReport, error) {
+	report := model.StockRetentionReport{
+		StockCode: stockCode,
+	}
+
+	// Daily summaries are sorted by date (score)
+	location := uc.getLocation(stockCode)
+	dailySummaries, err := uc.stockRepo.GetStockSummary(ctx, model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  time.Time{},
+		ToDate:    model.TradingDate(cutoff, location).AddDate(0, 0, -1),
+	})
+	if err != nil || len(dailySummaries) == 0 {
+		return report, err
+	}
+	uc.localizeSummaries(dailySummaries)
+
+	report.FromDate = dailySummaries[0].Date
+	report.ToDate = dailySummaries[len(dailySummaries)-1].Date
+	report.DailyRows = len(dailySummaries)
+
+	for start := 0; start < len(dailySummaries); {
+		month := startOfMonth(dailySummaries[start].Date)
+
+		end := start
+		for end < len(dailySummaries) && startOfMonth(dailySummaries[end].Date).Equal(month) {
+			end++
+		}
+
+		if err := uc.archiveMonth(ctx, month, dailySummaries[start:end], dryRun); err != nil {
+			return report, err
+		}
+
+		report.MonthlyRows++
+		start = end
+	}
+
+	return report, nil
+}
+
+func (uc *Usecase) archiveMonth(ctx context.Context, month time.Time, dailySummaries []model.Summary, dryRun bool) error {
+	stockCode := dailySummaries[0].StockCode
+
+	archivedSummaries, err := uc.stockRepo.GetArchivedStockSummary(ctx, model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  month,
+		ToDate:    month,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Previously archived days of the month always come before the remaining daily summaries
+	monthlySummary, err := model.AggregateSummaries(month, append(archivedSummaries, dailySummaries...))
+	if err != nil {
+		return fmt.Errorf("aggregate %s summaries of %s: %w", stockCode, month.Format("2006-01"), err)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	request := model.GetStockSummaryRequest{
+		StockCode: stockCode,
+		FromDate:  dailySummaries[0].Date,
+		ToDate:    dailySummaries[len(dailySummaries)-1].Date,
+	}
+
+	if err := uc.stockRepo.ArchiveStockSummary(ctx, monthlySummary, request); err != nil {
+		return err
+	}
+
+	uc.summaryCache.invalidate(stockCode, request.FromDate, request.ToDate)
+	return nil
+}
+
+// retentionCutoff returns the day of the exchange's timezone before which daily summaries are archived at now
+func (uc *Usecase) retentionCutoff(now time.Time) time.Time {
+	exchange := uc.schedule.GetLocation(model.Instrument{})
+	today := model.TradingDate(now.In(exchange), exchange)
+
+	return today.AddDate(0, 0, -uc.retention.DailyDays)
+}
+
+// getArchivedSummaries returns the monthly summaries archived for the months of a localized request that reaches back
+// before the retention cutoff, as the daily summaries of those months may have been compacted
+func (uc *Usecase) getArchivedSummaries(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
+	if uc.retention.DailyDays <= 0 || !request.FromDate.Before(uc.retentionCutoff(time.Now())) {
+		return []model.Summary{}, nil
+	}
+
+	archivedSummaries, err := uc.stockRepo.GetArchivedStockSummary(ctx, model.GetStockSummaryRequest{
+		StockCode: request.StockCode,
+		FromDate:  startOfMonth(request.FromDate),
+		ToDate:    request.ToDate,
+	})
+	if err != nil {
+		return []model.Summary{}, err
+	}
+
+	uc.localizeSummaries(archivedSummaries)
+	return archivedSummaries, nil
+}
+
+// mergeArchivedSummaries returns the archived monthly summaries and the daily summaries ordered by date, a month's
+// summary coming before the daily summaries of its first day
+func mergeArchivedSummaries(archivedSummaries, dailySummaries []model.Summary) []model.Summary {
+	if len(archivedSummaries) == 0 {
+		return dailySummaries
+	}
+
+	summaries := append(append([]model.Summary{}, archivedSummaries...), dailySummaries...)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Date.Before(summaries[j].Date)
+	})
+
+	return summaries
+}
+
+func startOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,351 @@
+/*
+	Hans Nicolaus
+	19 Oct 2026
+*/
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"stock/model"
+	mock "stock/usecase/_mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_Usecase_CompactStockSummaries(t *testing.T) {
+	var (
+		now    = time.Date(2023, 8, 29, 10, 0, 0, 0, time.UTC)
+		cutoff = time.Date(2022, 8, 29, 0, 0, 0, 0, time.UTC)
+		july   = time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+		august = time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	dailySummaries := []model.Summary{
+		{
+			StockCode: "BBCA",
+			Date:      time.Date(2022, 7, 29, 0, 0, 0, 0, time.UTC),
+			Prev:      7900,
+			Open:      8000,
+			High:      8100,
+			Low:       7950,
+			Close:     8050,
+			Volume:    200,
+			Value:     1605000,
+			Average:   8025,
+			VWAP:      80250000,
+			VWAPScale: 4,
+		},
+		{
+			StockCode: "BBCA",
+			Date:      time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+			Prev:      8050,
+			Open:      8050,
+			High:      8200,
+			Low:       8050,
+			Close:     8150,
+			Volume:    100,
+			Value:     815000,
+			Average:   8150,
+			Regular:   model.BoardSummary{Volume: 100, Value: 815000, Trades: 1},
+			BuyVolume: 100,
+		},
+		{
+			StockCode: "BBCA",
+			Date:      time.Date(2022, 8, 2, 0, 0, 0, 0, time.UTC),
+			Prev:      8150,
+			Open:      8100,
+			High:      8150,
+			Low:       8000,
+			Close:     8000,
+			Volume:    300,
+			Value:     2415000,
+			Average:   8050,
+
+			Regular:    model.BoardSummary{Volume: 200, Value: 1615000, Trades: 2},
+			Negotiated: model.BoardSummary{Volume: 100, Value: 800000, Trades: 1},
+			SellVolume: 200,
+		},
+	}
+
+	archivedJuly := model.Summary{
+		StockCode: "BBCA",
+		Date:      july,
+		Prev:      7800,
+		Open:      7850,
+		High:      7950,
+		Low:       7800,
+		Close:     7900,
+		Volume:    1000,
+		Value:     7880000,
+		Average:   7880,
+	}
+
+	type args struct {
+		ctx    context.Context
+		now    time.Time
+		dryRun bool
+	}
+	type fields struct {
+		stockRepo func(ctrl *gomock.Controller) StockRepo
+		retention model.Retention
+	}
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+
+		wantResponse model.RetentionReport
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{"BBCA", "BBRI"}, nil)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						ToDate:    cutoff.AddDate(0, 0, -1),
+					}).Return(dailySummaries, nil)
+
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  july,
+						ToDate:    july,
+					}).Return([]model.Summary{archivedJuly}, nil)
+
+					m.EXPECT().ArchiveStockSummary(gomock.Any(), model.Summary{
+						StockCode: "BBCA",
+						Date:      july,
+						Prev:      7800,
+						Open:      7850,
+						High:      8100,
+						Low:       7800,
+						Close:     8050,
+						Volume:    1200,
+						Value:     9485000,
+						Average:   7904,
+						VWAP:      79041667,
+						VWAPScale: 4,
+					}, model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  dailySummaries[0].Date,
+						ToDate:    dailySummaries[0].Date,
+					}).Return(nil)
+
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  august,
+						ToDate:    august,
+					}).Return([]model.Summary{}, nil)
+
+					m.EXPECT().ArchiveStockSummary(gomock.Any(), model.Summary{
+						StockCode: "BBCA",
+						Date:      august,
+						Prev:      8050,
+						Open:      8050,
+						High:      8200,
+						Low:       8000,
+						Close:     8000,
+						Volume:    400,
+						Value:     3230000,
+						Average:   8075,
+						VWAP:      8075,
+
+						Regular:    model.BoardSummary{Volume: 300, Value: 2430000, Trades: 3},
+						Negotiated: model.BoardSummary{Volume: 100, Value: 800000, Trades: 1},
+						BuyVolume:  100,
+						SellVolume: 200,
+					}, model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  dailySummaries[1].Date,
+						ToDate:    dailySummaries[2].Date,
+					}).Return(nil)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBRI",
+						ToDate:    cutoff.AddDate(0, 0, -1),
+					}).Return([]model.Summary{}, nil)
+
+					m.EXPECT().DeleteTransactions(gomock.Any(), cutoff, gomock.Any()).Return(int64(3), nil)
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.RetentionReport{
+				Cutoff:      cutoff,
+				DailyRows:   3,
+				MonthlyRows: 2,
+				Journals:    3,
+				Stocks: []model.StockRetentionReport{
+					{
+						StockCode:   "BBCA",
+						FromDate:    dailySummaries[0].Date,
+						ToDate:      dailySummaries[2].Date,
+						DailyRows:   3,
+						MonthlyRows: 2,
+					},
+				},
+			},
+		},
+		{
+			name: "success-dry-run",
+			args: args{
+				ctx:    context.Background(),
+				now:    now,
+				dryRun: true,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{"BBCA"}, nil)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						ToDate:    cutoff.AddDate(0, 0, -1),
+					}).Return(dailySummaries[1:], nil)
+
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  august,
+						ToDate:    august,
+					}).Return([]model.Summary{}, nil)
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.RetentionReport{
+				Cutoff:      cutoff,
+				DryRun:      true,
+				DailyRows:   2,
+				MonthlyRows: 1,
+				Stocks: []model.StockRetentionReport{
+					{
+						StockCode:   "BBCA",
+						FromDate:    dailySummaries[1].Date,
+						ToDate:      dailySummaries[2].Date,
+						DailyRows:   2,
+						MonthlyRows: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "error-no-retention-policy",
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					return mock.NewMockStockRepo(ctrl)
+				},
+			},
+			wantResponse: model.RetentionReport{},
+			wantErr:      true,
+		},
+		{
+			name: "error-archive-stock-summary",
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{"BBCA"}, nil)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						ToDate:    cutoff.AddDate(0, 0, -1),
+					}).Return(dailySummaries[1:], nil)
+
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+
+					m.EXPECT().ArchiveStockSummary(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(errors.New("error-archive-stock-summary"))
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.RetentionReport{
+				Cutoff: cutoff,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-delete-transactions",
+			args: args{
+				ctx: context.Background(),
+				now: now,
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockCodes(gomock.Any()).Return([]string{"BBRI"}, nil)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBRI",
+						ToDate:    cutoff.AddDate(0, 0, -1),
+					}).Return([]model.Summary{}, nil)
+
+					m.EXPECT().DeleteTransactions(gomock.Any(), cutoff, gomock.Any()).
+						Return(int64(0), errors.New("error-delete-transactions"))
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: model.RetentionReport{
+				Cutoff: cutoff,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			usecase := &Usecase{
+				stockRepo: tt.fields.stockRepo(ctrl),
+				retention: tt.fields.retention,
+			}
+
+			gotResponse, err := usecase.CompactStockSummaries(tt.args.ctx, tt.args.now, tt.args.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("usecase.CompactStockSummaries() err = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("usecase.CompactStockSummaries() gotResponse = %v, wantResponse %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
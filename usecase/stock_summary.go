@@ -7,11 +7,32 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"expvar"
+	"time"
 
 	"stock/model"
+	"stock/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	tracer = otel.Tracer("stock/usecase")
+
+	// validationMetrics counts rejected transactions by reason
+	validationMetrics = expvar.NewMap("validation")
 )
 
-func (uc *Usecase) UpdateStockSummary(ctx context.Context, transaction model.Transaction) error {
+func (uc *Usecase) UpdateStockSummary(ctx context.Context, transaction model.Transaction) (err error) {
+	ctx, span := tracer.Start(ctx, "UpdateStockSummary", trace.WithAttributes(
+		attribute.String("stock_code", transaction.StockCode),
+		attribute.String("type", string(transaction.Type)),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	// Get stock summary by stockCode and date if already exists
 	summaryResult, err := uc.stockRepo.GetStockSummary(ctx, model.GetStockSummaryRequest{
 		StockCode: transaction.StockCode,
@@ -27,8 +48,42 @@ func (uc *Usecase) UpdateStockSummary(ctx context.Context, transaction model.Tra
 		summary = summaryResult[0]
 	}
 
+	// Reject transactions before they are applied: ones scaled differently from the summary or overflowing it,
+	// invalid ones, e.g. a negative price that would become the Low, and trades arriving too long after the
+	// latest trade of the summary
+	err = summary.CheckArithmetic(transaction)
+	if err == nil {
+		err = uc.validation.ForInstrument(uc.instruments.Get(transaction.StockCode)).Validate(transaction, summary.Prev, time.Now())
+	}
+	if err == nil {
+		err = summary.CheckLateness(transaction, uc.lateness.Window)
+	}
+	if err != nil {
+		var rejection *model.RejectionError
+		if errors.As(err, &rejection) {
+			validationMetrics.Add(string(rejection.Reason), 1)
+			span.SetAttributes(attribute.String("rejection_reason", string(rejection.Reason)))
+		}
+		return err
+	}
+
+	// Journal the accepted transaction before applying it, so that the summary can be rebuilt from the journal even
+	// if it isn't stored
+	if uc.journal.Enabled {
+		err = uc.stockRepo.AppendTransaction(ctx, transaction)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update stock summary data based on the transaction
 	isUpdated, updatedSummary := summary.ApplyTransaction(transaction)
+	span.SetAttributes(attribute.Bool("updated", isUpdated))
+
+	updatedSummary, err = updatedSummary.WithVWAP(uc.vwap.Precision)
+	if err != nil {
+		return err
+	}
 
 	if isUpdated {
 		// Persist updated stock summary to our data store
@@ -36,11 +91,36 @@ func (uc *Usecase) UpdateStockSummary(ctx context.Context, transaction model.Tra
 		if err != nil {
 			return err
 		}
+
+		uc.summaryCache.invalidate(updatedSummary.StockCode, updatedSummary.Date, updatedSummary.Date)
 	}
 
 	return nil
 }
 
+// GetStockSummary reads through the summary cache, which is kept up to date by UpdateStockSummary. The requested dates
+// are days of the stock's timezone, as are the dates of the returned summaries. Ranges reaching back before the
+// retention cutoff also get the monthly summaries archived for their months.
 func (uc *Usecase) GetStockSummary(ctx context.Context, request model.GetStockSummaryRequest) ([]model.Summary, error) {
-	return uc.stockRepo.GetStockSummary(ctx, request)
+	request = uc.localize(request)
+
+	summaries, generation, ok := uc.summaryCache.get(request)
+	if ok {
+		return summaries, nil
+	}
+
+	summaries, err := uc.stockRepo.GetStockSummary(ctx, request)
+	if err != nil {
+		return summaries, err
+	}
+	uc.localizeSummaries(summaries)
+
+	archivedSummaries, err := uc.getArchivedSummaries(ctx, request)
+	if err != nil {
+		return []model.Summary{}, err
+	}
+
+	summaries = mergeArchivedSummaries(archivedSummaries, summaries)
+	uc.summaryCache.set(request, generation, summaries)
+	return summaries, nil
 }
@@ -8,6 +8,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"expvar"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -19,12 +21,20 @@ import (
 )
 
 func Test_Usecase_GetStockSummary(t *testing.T) {
+	jakarta, err := model.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("model.LoadLocation() err = %v", err)
+	}
+	jakartaDate := time.Date(2023, 8, 29, 0, 0, 0, 0, jakarta)
+
 	type args struct {
 		ctx   context.Context
 		input model.GetStockSummaryRequest
 	}
 	type fields struct {
-		stockRepo func(ctrl *gomock.Controller) StockRepo
+		stockRepo   func(ctrl *gomock.Controller) StockRepo
+		instruments model.Instruments
+		retention   model.Retention
 	}
 	tests := []struct {
 		name   string
@@ -85,6 +95,106 @@ func Test_Usecase_GetStockSummary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success-instrument-timezone",
+			args: args{
+				ctx: context.Background(),
+				input: model.GetStockSummaryRequest{
+					StockCode: "ASII",
+					FromDate:  time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+					ToDate:    time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					// Summaries are read back in UTC
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "ASII",
+						FromDate:  jakartaDate,
+						ToDate:    jakartaDate,
+					}).Return([]model.Summary{
+						{StockCode: "ASII", Date: jakartaDate.UTC(), Prev: 6000},
+					}, nil)
+
+					return m
+				},
+				instruments: model.Instruments{{StockCode: "ASII", Timezone: "Asia/Jakarta"}},
+			},
+			wantResponse: []model.Summary{
+				{StockCode: "ASII", Date: jakartaDate, Prev: 6000},
+			},
+		},
+		{
+			name: "success-archived",
+			args: args{
+				ctx: context.Background(),
+				input: model.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+					ToDate:    time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+						ToDate:    time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC),
+					}).Return([]model.Summary{
+						{StockCode: "BBCA", Date: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), Close: 8200},
+					}, nil)
+
+					// The whole month of the first requested day is archived under its first day
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+						ToDate:    time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC),
+					}).Return([]model.Summary{
+						{StockCode: "BBCA", Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Close: 8000, Monthly: true},
+						{StockCode: "BBCA", Date: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), Close: 8100, Monthly: true},
+					}, nil)
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: []model.Summary{
+				{StockCode: "BBCA", Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Close: 8000, Monthly: true},
+				{StockCode: "BBCA", Date: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), Close: 8100, Monthly: true},
+				{StockCode: "BBCA", Date: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), Close: 8200},
+			},
+		},
+		{
+			name: "success-within-retention",
+			args: args{
+				ctx: context.Background(),
+				input: model.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  time.Now().UTC().Truncate(24 * time.Hour),
+					ToDate:    time.Now().UTC().Truncate(24 * time.Hour),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					// The archive isn't read for days that are still kept daily
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: []model.Summary{},
+		},
 		{
 			name: "success-no-result",
 			args: args{
@@ -136,13 +246,43 @@ func Test_Usecase_GetStockSummary(t *testing.T) {
 			wantResponse: []model.Summary{},
 			wantErr:      true,
 		},
+		{
+			name: "error-get-archived-stock-summary",
+			args: args{
+				ctx: context.Background(),
+				input: model.GetStockSummaryRequest{
+					StockCode: "BBCA",
+					FromDate:  time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+					ToDate:    time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+
+					m.EXPECT().GetArchivedStockSummary(gomock.Any(), gomock.Any()).
+						Return([]model.Summary{}, errors.New("error-get-archived-stock-summary"))
+
+					return m
+				},
+				retention: model.Retention{
+					DailyDays: 365,
+				},
+			},
+			wantResponse: []model.Summary{},
+			wantErr:      true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			usecase := &Usecase{
-				stockRepo: tt.fields.stockRepo(ctrl),
+				stockRepo:   tt.fields.stockRepo(ctrl),
+				instruments: tt.fields.instruments,
+				retention:   tt.fields.retention,
 			}
 
 			gotResponse, err := usecase.GetStockSummary(tt.args.ctx, tt.args.input)
@@ -165,6 +305,7 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 	}
 	type fields struct {
 		stockRepo func(ctrl *gomock.Controller) StockRepo
+		journal   model.Journal
 	}
 	tests := []struct {
 		name   string
@@ -282,6 +423,9 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 						Volume:    100,
 						Value:     805000,
 						Average:   8050,
+						VWAP:      805000,
+						VWAPScale: 2,
+						Trades:    1,
 					}).Return(nil)
 
 					return m
@@ -320,6 +464,7 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 							Volume:    100,
 							Value:     805000,
 							Average:   8050,
+							Trades:    1,
 						},
 					}, nil)
 
@@ -334,6 +479,9 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 						Volume:    600,
 						Value:     4780000,
 						Average:   7966,
+						VWAP:      796667,
+						VWAPScale: 2,
+						Trades:    2,
 					}).Return(nil)
 
 					return m
@@ -372,6 +520,7 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 							Volume:    600,
 							Value:     4780000,
 							Average:   7966,
+							Trades:    2,
 						},
 					}, nil)
 
@@ -411,6 +560,7 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 							Volume:    600,
 							Value:     4780000,
 							Average:   7966,
+							Trades:    2,
 						},
 					}, nil)
 
@@ -425,6 +575,9 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 						Volume:    900,
 						Value:     7210000,
 						Average:   8011,
+						VWAP:      801111,
+						VWAPScale: 2,
+						Trades:    3,
 					}).Return(nil)
 
 					return m
@@ -463,13 +616,227 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 							Volume:    900,
 							Value:     7210000,
 							Average:   8011,
+							Trades:    3,
+						},
+					}, nil)
+
+					return m
+				},
+			},
+		},
+		{
+			name: "success-type-p-pre-opening-sets-open",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{
+					StockCode: "BBCA",
+					Price:     8025,
+					Quantity:  200,
+					Type:      model.TransactionTypeP,
+					Date:      time.Time{}.AddDate(0, 0, 1),
+					Session:   model.SessionPreOpening,
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Time{}.AddDate(0, 0, 1),
+						ToDate:    time.Time{}.AddDate(0, 0, 1),
+					}).Return([]model.Summary{
+						{
+							StockCode: "BBCA",
+							Date:      time.Time{}.AddDate(0, 0, 1),
+							Prev:      8000,
+							Open:      8050,
+							High:      8050,
+							Low:       8050,
+							Close:     8050,
+							Volume:    100,
+							Value:     805000,
+							Average:   8050,
+							Trades:    1,
+							SessionOne: model.SessionSummary{
+								Open:   8050,
+								High:   8050,
+								Low:    8050,
+								Close:  8050,
+								Volume: 100,
+								Value:  805000,
+								Trades: 1,
+							},
 						},
 					}, nil)
 
+					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Summary{
+						StockCode: "BBCA",
+						Date:      time.Time{}.AddDate(0, 0, 1),
+						Prev:      8000,
+						Open:      8025,
+						High:      8050,
+						Low:       8025,
+						Close:     8025,
+						Volume:    300,
+						Value:     2410000,
+						Average:   8033,
+						VWAP:      803333,
+						VWAPScale: 2,
+						Trades:    2,
+						PreOpening: model.SessionSummary{
+							Open:   8025,
+							High:   8025,
+							Low:    8025,
+							Close:  8025,
+							Volume: 200,
+							Value:  1605000,
+							Trades: 1,
+						},
+						SessionOne: model.SessionSummary{
+							Open:   8050,
+							High:   8050,
+							Low:    8050,
+							Close:  8050,
+							Volume: 100,
+							Value:  805000,
+							Trades: 1,
+						},
+					}).Return(nil)
+
 					return m
 				},
 			},
 		},
+		{
+			name: "success-type-e-after-pre-closing-keeps-close",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{
+					StockCode: "BBCA",
+					Price:     8150,
+					Quantity:  100,
+					Type:      model.TransactionTypeE,
+					Date:      time.Time{}.AddDate(0, 0, 1),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), model.GetStockSummaryRequest{
+						StockCode: "BBCA",
+						FromDate:  time.Time{}.AddDate(0, 0, 1),
+						ToDate:    time.Time{}.AddDate(0, 0, 1),
+					}).Return([]model.Summary{
+						{
+							StockCode: "BBCA",
+							Date:      time.Time{}.AddDate(0, 0, 1),
+							Prev:      8000,
+							Open:      8050,
+							High:      8100,
+							Low:       8050,
+							Close:     8100,
+							Volume:    300,
+							Value:     2425000,
+							Average:   8083,
+							Trades:    2,
+							PreClosing: model.SessionSummary{
+								Open:   8100,
+								High:   8100,
+								Low:    8100,
+								Close:  8100,
+								Volume: 200,
+								Value:  1620000,
+								Trades: 1,
+							},
+						},
+					}, nil)
+
+					m.EXPECT().UpdateStockSummary(gomock.Any(), model.Summary{
+						StockCode: "BBCA",
+						Date:      time.Time{}.AddDate(0, 0, 1),
+						Prev:      8000,
+						Open:      8050,
+						High:      8150,
+						Low:       8050,
+						Close:     8100,
+						Volume:    400,
+						Value:     3240000,
+						Average:   8100,
+						VWAP:      810000,
+						VWAPScale: 2,
+						Trades:    3,
+						PreClosing: model.SessionSummary{
+							Open:   8100,
+							High:   8100,
+							Low:    8100,
+							Close:  8100,
+							Volume: 200,
+							Value:  1620000,
+							Trades: 1,
+						},
+					}).Return(nil)
+
+					return m
+				},
+			},
+		},
+		{
+			name: "success-journal",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{
+					StockCode: "BBCA",
+					Price:     8000,
+					Type:      model.TransactionTypeA,
+					Date:      time.Time{}.AddDate(0, 0, 1),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+					gomock.InOrder(
+						m.EXPECT().AppendTransaction(gomock.Any(), model.Transaction{
+							StockCode: "BBCA",
+							Price:     8000,
+							Type:      model.TransactionTypeA,
+							Date:      time.Time{}.AddDate(0, 0, 1),
+						}).Return(nil),
+						m.EXPECT().UpdateStockSummary(gomock.Any(), gomock.Any()).Return(nil),
+					)
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+		},
+		{
+			name: "error-append-transaction",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{
+					StockCode: "BBCA",
+					Price:     8000,
+					Type:      model.TransactionTypeA,
+					Date:      time.Time{}.AddDate(0, 0, 1),
+				},
+			},
+			fields: fields{
+				stockRepo: func(ctrl *gomock.Controller) StockRepo {
+					m := mock.NewMockStockRepo(ctrl)
+
+					m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{}, nil)
+					m.EXPECT().AppendTransaction(gomock.Any(), gomock.Any()).Return(errors.New("error-append-transaction"))
+
+					return m
+				},
+				journal: model.Journal{Enabled: true},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -478,6 +845,8 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 
 			usecase := &Usecase{
 				stockRepo: tt.fields.stockRepo(ctrl),
+				vwap:      model.VWAP{Precision: 2},
+				journal:   tt.fields.journal,
 			}
 
 			err := usecase.UpdateStockSummary(tt.args.ctx, tt.args.input)
@@ -488,3 +857,429 @@ func Test_Usecase_UpdateStockSummary(t *testing.T) {
 		})
 	}
 }
+
+func Test_Usecase_UpdateStockSummary_Validation(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	validation := model.DefaultConfigLocal.Validation
+	validation.MaxPastDays = 30
+
+	// USDIDR has its own tick sizes, while the default tick sizes are scaled for BBCA-W
+	instruments := model.Instruments{
+		{StockCode: "USDIDR", PriceScale: 2, QuantityScale: 1, TickSizes: []model.TickSize{{MinPrice: 0, Tick: 25}}},
+		{StockCode: "BBCA-W", PriceScale: 2},
+	}
+
+	type args struct {
+		ctx   context.Context
+		input model.Transaction
+	}
+	tests := []struct {
+		name    string
+		args    args
+		summary model.Summary
+
+		wantReason model.RejectionReason
+		wantUpdate bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: today},
+			},
+			summary:    model.Summary{StockCode: "BBCA", Date: today, Prev: 8000},
+			wantUpdate: true,
+		},
+		{
+			name: "success-prev-unknown",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 16000, Quantity: 100, Date: today},
+			},
+			wantUpdate: true,
+		},
+		{
+			name: "success-type-a-prev",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeA, Price: 8013, Date: today},
+			},
+			summary:    model.Summary{StockCode: "BBCA", Date: today, Prev: 4000},
+			wantUpdate: true,
+		},
+		{
+			name: "error-zero-price",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 0, Quantity: 100, Date: today},
+			},
+			wantReason: model.RejectionNonPositivePrice,
+		},
+		{
+			name: "error-negative-price",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: -8200, Quantity: 100, Date: today},
+			},
+			wantReason: model.RejectionNonPositivePrice,
+		},
+		{
+			name: "error-negative-quantity",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: -100, Date: today},
+			},
+			wantReason: model.RejectionNegativeQuantity,
+		},
+		{
+			name: "error-tick-size",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8210, Quantity: 100, Date: today},
+			},
+			wantReason: model.RejectionTickSize,
+		},
+		{
+			name: "error-price-band-upper",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 9625, Quantity: 100, Date: today},
+			},
+			summary:    model.Summary{StockCode: "BBCA", Date: today, Prev: 8000},
+			wantReason: model.RejectionPriceBand,
+		},
+		{
+			name: "error-price-band-lower",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "GOTO", Type: model.TransactionTypeP, Price: 64, Quantity: 100, Date: today},
+			},
+			summary:    model.Summary{StockCode: "GOTO", Date: today, Prev: 100},
+			wantReason: model.RejectionPriceBand,
+		},
+		{
+			name: "success-price-band-large-price",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "BBCA-W", Type: model.TransactionTypeE, Price: 4_500_000_000_000_000_000, Quantity: 1,
+					Date: today, PriceScale: 2},
+			},
+			summary:    model.Summary{StockCode: "BBCA-W", Date: today, Prev: 4_000_000_000_000_000_000, PriceScale: 2},
+			wantUpdate: true,
+		},
+		{
+			name: "success-price-band-clamped",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "BBCA-W", Type: model.TransactionTypeE, Price: 9_000_000_000_000_000_000, Quantity: 1,
+					Date: today, PriceScale: 2},
+			},
+			summary:    model.Summary{StockCode: "BBCA-W", Date: today, Prev: 8_000_000_000_000_000_000, PriceScale: 2},
+			wantUpdate: true,
+		},
+		{
+			name: "error-price-band-large-price",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "BBCA-W", Type: model.TransactionTypeE, Price: 5_000_000_000_000_000_000, Quantity: 1,
+					Date: today, PriceScale: 2},
+			},
+			summary:    model.Summary{StockCode: "BBCA-W", Date: today, Prev: 4_000_000_000_000_000_000, PriceScale: 2},
+			wantReason: model.RejectionPriceBand,
+		},
+		{
+			name: "error-date-future",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: today.AddDate(0, 0, 10)},
+			},
+			wantReason: model.RejectionDateOutsideWindow,
+		},
+		{
+			name: "error-date-past",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: today.AddDate(0, 0, -31)},
+			},
+			wantReason: model.RejectionDateOutsideWindow,
+		},
+		{
+			name: "success-decimal-price",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "USDIDR", Type: model.TransactionTypeE, Price: 1523425, Quantity: 10005, Date: today,
+					PriceScale: 2, QuantityScale: 1},
+			},
+			summary:    model.Summary{StockCode: "USDIDR", Date: today, Prev: 1523400, PriceScale: 2, QuantityScale: 1},
+			wantUpdate: true,
+		},
+		{
+			name: "error-decimal-tick-size",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "USDIDR", Type: model.TransactionTypeE, Price: 1523410, Quantity: 10005, Date: today,
+					PriceScale: 2, QuantityScale: 1},
+			},
+			summary:    model.Summary{StockCode: "USDIDR", Date: today, Prev: 1523400, PriceScale: 2, QuantityScale: 1},
+			wantReason: model.RejectionTickSize,
+		},
+		{
+			name: "success-scaled-tick-size",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "BBCA-W", Type: model.TransactionTypeE, Price: 820000, Quantity: 100, Date: today,
+					PriceScale: 2},
+			},
+			wantUpdate: true,
+		},
+		{
+			name: "error-scaled-tick-size",
+			args: args{
+				ctx: context.Background(),
+				input: model.Transaction{StockCode: "BBCA-W", Type: model.TransactionTypeE, Price: 820050, Quantity: 100, Date: today,
+					PriceScale: 2},
+			},
+			wantReason: model.RejectionTickSize,
+		},
+		{
+			name: "error-scale",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "USDIDR", Type: model.TransactionTypeE, Price: 15234, Quantity: 1000, Date: today},
+			},
+			summary:    model.Summary{StockCode: "USDIDR", Date: today, Prev: 1523400, PriceScale: 2, QuantityScale: 1},
+			wantReason: model.RejectionScale,
+		},
+		{
+			name: "error-overflow",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: today},
+			},
+			summary:    model.Summary{StockCode: "BBCA", Date: today, Prev: 8000, Volume: 1 << 50, Value: math.MaxInt64 - 1000},
+			wantReason: model.RejectionOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			m := mock.NewMockStockRepo(ctrl)
+			m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{tt.summary}, nil)
+			if tt.wantUpdate {
+				m.EXPECT().UpdateStockSummary(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			usecase := &Usecase{
+				stockRepo:   m,
+				validation:  validation,
+				instruments: instruments,
+			}
+
+			var rejected int64
+			if tt.wantReason != "" {
+				rejected = getExpvarInt(validationMetrics, string(tt.wantReason))
+			}
+
+			err := usecase.UpdateStockSummary(tt.args.ctx, tt.args.input)
+
+			var rejection *model.RejectionError
+			if gotRejected := errors.As(err, &rejection); gotRejected != (tt.wantReason != "") {
+				t.Errorf("usecase.UpdateStockSummary() err = %v, wantReason %v", err, tt.wantReason)
+				return
+			}
+
+			if tt.wantReason == "" {
+				return
+			}
+
+			if rejection.Reason != tt.wantReason {
+				t.Errorf("usecase.UpdateStockSummary() gotReason = %v, wantReason %v", rejection.Reason, tt.wantReason)
+			}
+
+			if got := getExpvarInt(validationMetrics, string(tt.wantReason)); got != rejected+1 {
+				t.Errorf("validationMetrics[%s] = %d, want %d", tt.wantReason, got, rejected+1)
+			}
+		})
+	}
+}
+
+func Test_Usecase_UpdateStockSummary_OutOfOrder(t *testing.T) {
+	date := time.Date(2023, 8, 29, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+
+	// Trades of 100 shares at 8100 (09:05, sequence 1) and 8150 (10:00, sequence 5)
+	summary := model.Summary{
+		StockCode:          "BBCA",
+		Date:               date,
+		Prev:               8000,
+		Open:               8100,
+		High:               8150,
+		Low:                8100,
+		Close:              8150,
+		Volume:             200,
+		Value:              1625000,
+		Average:            8125,
+		Trades:             2,
+		FirstTradeTime:     at(9, 5).UnixMilli(),
+		FirstTradeSequence: 1,
+		LastTradeTime:      at(10, 0).UnixMilli(),
+		LastTradeSequence:  5,
+	}
+
+	type args struct {
+		ctx   context.Context
+		input model.Transaction
+	}
+	tests := []struct {
+		name string
+		args args
+
+		wantSummary model.Summary
+		wantReason  model.RejectionReason
+	}{
+		{
+			name: "success-earlier-trade-sets-open",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8050, Quantity: 100, Date: date, Timestamp: at(9, 1), Sequence: 9},
+			},
+			wantSummary: model.Summary{
+				StockCode:          "BBCA",
+				Date:               date,
+				Prev:               8000,
+				Open:               8050,
+				High:               8150,
+				Low:                8050,
+				Close:              8150,
+				Volume:             300,
+				Value:              2430000,
+				Average:            8100,
+				VWAP:               8100,
+				Trades:             3,
+				FirstTradeTime:     at(9, 1).UnixMilli(),
+				FirstTradeSequence: 9,
+				LastTradeTime:      at(10, 0).UnixMilli(),
+				LastTradeSequence:  5,
+			},
+		},
+		{
+			name: "success-earlier-trade-keeps-close",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: date, Timestamp: at(9, 50), Sequence: 2},
+			},
+			wantSummary: model.Summary{
+				StockCode:          "BBCA",
+				Date:               date,
+				Prev:               8000,
+				Open:               8100,
+				High:               8200,
+				Low:                8100,
+				Close:              8150,
+				Volume:             300,
+				Value:              2445000,
+				Average:            8150,
+				VWAP:               8150,
+				Trades:             3,
+				FirstTradeTime:     at(9, 5).UnixMilli(),
+				FirstTradeSequence: 1,
+				LastTradeTime:      at(10, 0).UnixMilli(),
+				LastTradeSequence:  5,
+			},
+		},
+		{
+			name: "success-same-time-later-sequence-sets-close",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8125, Quantity: 100, Date: date, Timestamp: at(10, 0), Sequence: 6},
+			},
+			wantSummary: model.Summary{
+				StockCode:          "BBCA",
+				Date:               date,
+				Prev:               8000,
+				Open:               8100,
+				High:               8150,
+				Low:                8100,
+				Close:              8125,
+				Volume:             300,
+				Value:              2437500,
+				Average:            8125,
+				VWAP:               8125,
+				Trades:             3,
+				FirstTradeTime:     at(9, 5).UnixMilli(),
+				FirstTradeSequence: 1,
+				LastTradeTime:      at(10, 0).UnixMilli(),
+				LastTradeSequence:  6,
+			},
+		},
+		{
+			name: "success-unknown-time-arrival-order",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8125, Quantity: 100, Date: date},
+			},
+			wantSummary: model.Summary{
+				StockCode:          "BBCA",
+				Date:               date,
+				Prev:               8000,
+				Open:               8100,
+				High:               8150,
+				Low:                8100,
+				Close:              8125,
+				Volume:             300,
+				Value:              2437500,
+				Average:            8125,
+				VWAP:               8125,
+				Trades:             3,
+				FirstTradeTime:     at(9, 5).UnixMilli(),
+				FirstTradeSequence: 1,
+			},
+		},
+		{
+			name: "error-late",
+			args: args{
+				ctx:   context.Background(),
+				input: model.Transaction{StockCode: "BBCA", Type: model.TransactionTypeE, Price: 8200, Quantity: 100, Date: date, Timestamp: at(8, 59), Sequence: 2},
+			},
+			wantReason: model.RejectionLate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			m := mock.NewMockStockRepo(ctrl)
+			m.EXPECT().GetStockSummary(gomock.Any(), gomock.Any()).Return([]model.Summary{summary}, nil)
+			if tt.wantReason == "" {
+				m.EXPECT().UpdateStockSummary(gomock.Any(), tt.wantSummary).Return(nil)
+			}
+
+			usecase := &Usecase{
+				stockRepo: m,
+				lateness:  model.Lateness{Window: time.Hour},
+			}
+
+			err := usecase.UpdateStockSummary(tt.args.ctx, tt.args.input)
+
+			var rejection *model.RejectionError
+			if gotRejected := errors.As(err, &rejection); gotRejected != (tt.wantReason != "") {
+				t.Errorf("usecase.UpdateStockSummary() err = %v, wantReason %v", err, tt.wantReason)
+				return
+			}
+
+			if tt.wantReason != "" && rejection.Reason != tt.wantReason {
+				t.Errorf("usecase.UpdateStockSummary() gotReason = %v, wantReason %v", rejection.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func getExpvarInt(metrics *expvar.Map, key string) int64 {
+	if value, ok := metrics.Get(key).(*expvar.Int); ok {
+		return value.Value()
+	}
+	return 0
+}